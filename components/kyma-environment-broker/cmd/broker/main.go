@@ -7,7 +7,10 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"sort"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/suspension"
@@ -39,6 +42,7 @@ import (
 	orchestrationExt "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/appinfo"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/artifact"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/auditlog"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
@@ -67,6 +71,7 @@ import (
 	uaa "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager/xsuaa"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/webhook"
 )
 
 // Config holds configuration for the whole application
@@ -93,6 +98,17 @@ type Config struct {
 	// It is used for provisioning and deprovisioning operations.
 	OperationTimeout time.Duration `envconfig:"default=24h"`
 
+	// StaleUpgradeOperations configures the periodic check which fails the in progress upgrade operations
+	// which were not updated for longer than MaxAge, e.g. after their processing stopped on a crash.
+	StaleUpgradeOperations struct {
+		CheckInterval time.Duration `envconfig:"default=1h"`
+		MaxAge        time.Duration `envconfig:"default=24h"`
+	}
+
+	// DrainTimeout limits how long the operations in progress are processed on shutdown,
+	// the operations which are not finished by then are resumed on start.
+	DrainTimeout time.Duration `envconfig:"default=20s"`
+
 	Host       string `envconfig:"optional"`
 	Port       string `envconfig:"default=8080"`
 	StatusPort string `envconfig:"default=8071"`
@@ -117,6 +133,10 @@ type Config struct {
 	IAS ias.Config
 	EDP edp.Config
 
+	Webhook webhook.Config
+
+	Artifacts artifact.Config
+
 	// Service Manager services
 	XSUAA struct {
 		Disabled bool `envconfig:"default=true"`
@@ -250,6 +270,17 @@ func main() {
 	// metrics collectors
 	metrics.RegisterAll(eventBroker, db.Operations(), db.Instances())
 
+	// operation outcome webhooks
+	if !cfg.Webhook.Disabled {
+		webhook.NewDispatcher(cfg.Webhook, httputil.NewClient(60, false), logs.WithField("service", "webhook")).Register(eventBroker)
+	}
+
+	// logs of the failed steps saved as operation artifacts
+	artifactStore := artifact.NewFilesystemStore(cfg.Artifacts.Directory)
+	if !cfg.Artifacts.Disabled {
+		artifact.NewCollector(artifactStore, logs.WithField("service", "artifacts")).Register(eventBroker)
+	}
+
 	//setup runtime overrides appender
 	runtimeOverrides := runtimeoverrides.NewRuntimeOverrides(ctx, cli)
 
@@ -467,7 +498,10 @@ func main() {
 	router.Handle("/metrics", promhttp.Handler())
 
 	gardenerNamespace := fmt.Sprintf("garden-%s", cfg.Gardener.Project)
-	kymaQueue, err := NewOrchestrationProcessingQueue(ctx, db, runtimeOverrides, provisionerClient, gardenerClient,
+	// retryCtx is canceled on shutdown before the queues are drained, so the operations requesting a retry are canceled instead of waiting for it
+	retryCtx, cancelRetries := context.WithCancel(ctx)
+	defer cancelRetries()
+	kymaQueue, err := NewOrchestrationProcessingQueue(retryCtx, db, runtimeOverrides, provisionerClient, gardenerClient,
 		gardenerNamespace, eventBroker, inputFactory, nil, time.Minute, runtimeVerConfigurator, cfg.DefaultRequestRegion, upgradeEvalManager, logs)
 	fatalOnError(err)
 
@@ -475,18 +509,21 @@ func main() {
 	orchestrationHandler := orchestrate.NewOrchestrationHandler(db, kymaQueue, cfg.MaxPaginationPage, logs)
 
 	if !cfg.DisableProcessOperationsInProgress {
-		err = processOperationsInProgressByType(dbmodel.OperationTypeProvision, db.Operations(), provisionQueue, logs)
-		fatalOnError(err)
-		err = processOperationsInProgressByType(dbmodel.OperationTypeDeprovision, db.Operations(), deprovisionQueue, logs)
+		err = process.NewOperationsResumer(db.Operations(), logs).
+			WithQueue(dbmodel.OperationTypeProvision, provisionQueue).
+			WithQueue(dbmodel.OperationTypeDeprovision, deprovisionQueue).
+			ResumePendingOperations(ctx)
 		fatalOnError(err)
 		err = reprocessOrchestrations(db.Orchestrations(), db.Operations(), kymaQueue, logs)
 		fatalOnError(err)
+		go failStaleUpgradeOperations(ctx, db.Operations(), cfg.StaleUpgradeOperations.CheckInterval, cfg.StaleUpgradeOperations.MaxAge, logs)
 	} else {
 		logger.Info("Skipping processing operation in progress on start")
 	}
 
 	// create OSB API endpoints
 	router.Use(middleware.AddRegionToContext(cfg.DefaultRequestRegion))
+	router.Use(middleware.AddUserToContext())
 	for _, prefix := range []string{
 		"/oauth/",          // oauth2 handled by Ory
 		"/oauth/{region}/", // oauth2 handled by Ory with region
@@ -502,25 +539,67 @@ func main() {
 	runtimeHandler := runtime.NewHandler(db.Instances(), db.Operations(), cfg.MaxPaginationPage, cfg.DefaultRequestRegion)
 	runtimeHandler.AttachRoutes(router)
 
+	// create account quota endpoint, the trial plan is the only plan limited by the broker
+	quotaLimits := map[string]int{}
+	if cfg.Broker.OnlySingleTrialPerGA {
+		quotaLimits[broker.TrialPlanID] = 1
+	}
+	runtime.NewQuotaHandler(db.Instances(), cfg.Broker.EnablePlans, quotaLimits).AttachRoutes(router)
+
+	// create operation failures endpoint
+	runtime.NewFailuresHandler(db.Operations()).AttachRoutes(router)
+
+	// create operation SLA report endpoint
+	runtime.NewSLAHandler(db.Operations()).AttachRoutes(router)
+
+	// create operation artifacts endpoints
+	runtime.NewArtifactsHandler(artifactStore, logs.WithField("service", "artifacts")).AttachRoutes(router)
+
 	router.StrictSlash(true).PathPrefix("/").Handler(http.StripPrefix("/", http.FileServer(http.Dir("/swagger"))))
 	svr := handlers.CustomLoggingHandler(os.Stdout, router, func(writer io.Writer, params handlers.LogFormatterParams) {
 		logs.Infof("Call handled: method=%s url=%s statusCode=%d size=%d", params.Request.Method, params.URL.Path, params.StatusCode, params.Size)
 	})
 
-	fatalOnError(http.ListenAndServe(cfg.Host+":"+cfg.Port, svr))
+	server := &http.Server{Addr: cfg.Host + ":" + cfg.Port, Handler: svr}
+	go func() {
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			fatalOnError(err)
+		}
+	}()
+
+	term := make(chan os.Signal, 1)
+	signal.Notify(term, os.Interrupt, syscall.SIGTERM)
+	<-term
+	logger.Info("Received termination signal, draining the operations in progress")
+
+	cancelRetries()
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.DrainTimeout)
+	defer cancelDrain()
+	if err := server.Shutdown(drainCtx); err != nil {
+		logs.Errorf("while shutting down the server: %s", err)
+	}
+	drainQueues(drainCtx, map[string]*process.Queue{
+		"provisioning":   provisionQueue,
+		"deprovisioning": deprovisionQueue,
+		"orchestration":  kymaQueue,
+	}, logs)
 }
 
-// queues all in progress operations by type
-func processOperationsInProgressByType(opType dbmodel.OperationType, op storage.Operations, queue *process.Queue, log logrus.FieldLogger) error {
-	operations, err := op.GetNotFinishedOperationsByType(opType)
-	if err != nil {
-		return errors.Wrap(err, "while getting in progress operations from storage")
-	}
-	for _, operation := range operations {
-		queue.Add(operation.ID)
-		log.Infof("Resuming the processing of %s operation ID: %s", opType, operation.ID)
+// drainQueues drains all the queues at the same time and waits until they are drained or the context is done
+func drainQueues(ctx context.Context, queues map[string]*process.Queue, log logrus.FieldLogger) {
+	var wg sync.WaitGroup
+	for name, queue := range queues {
+		wg.Add(1)
+		go func(name string, queue *process.Queue) {
+			defer wg.Done()
+			if err := queue.Drain(ctx); err != nil {
+				log.Errorf("while draining the %s queue: %s", name, err)
+				return
+			}
+			log.Infof("The %s queue was drained", name)
+		}(name, queue)
 	}
-	return nil
+	wg.Wait()
 }
 
 func reprocessOrchestrations(orchestrationsStorage storage.Orchestrations, operationsStorage storage.Operations, queue *process.Queue, log logrus.FieldLogger) error {
@@ -577,6 +656,20 @@ func processCancelingOrchestrations(orchestrationsStorage storage.Orchestrations
 	return nil
 }
 
+// failStaleUpgradeOperations periodically fails the in progress upgrade operations which were not updated for longer than maxAge until the context is done
+func failStaleUpgradeOperations(ctx context.Context, operations storage.Operations, interval, maxAge time.Duration, log logrus.FieldLogger) {
+	operationManager := process.NewUpgradeKymaOperationManager(operations)
+	wait.Until(func() {
+		failed, err := operationManager.MarkStaleOperationsFailed(maxAge)
+		if err != nil {
+			log.Errorf("while failing stale upgrade kyma operations: %s", err)
+		}
+		for _, op := range failed {
+			log.Infof("Upgrade kyma operation %s was not updated for more than %s, it was marked as failed", op.Operation.ID, maxAge)
+		}
+	}, interval, ctx.Done())
+}
+
 func initClient(cfg *rest.Config) (client.Client, error) {
 	mapper, err := apiutil.NewDiscoveryRESTMapper(cfg)
 	if err != nil {
@@ -611,7 +704,7 @@ func NewOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStora
 	pollingInterval time.Duration, runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator,
 	defaultRegion string, upgradeEvalManager *upgrade_kyma.EvaluationManager, logs logrus.FieldLogger) (*process.Queue, error) {
 
-	upgradeKymaManager := upgrade_kyma.NewManager(db.Operations(), pub, logs.WithField("upgradeKyma", "manager"))
+	upgradeKymaManager := upgrade_kyma.NewManager(db.Operations(), pub, logs.WithField("upgradeKyma", "manager")).WithContext(ctx)
 	upgradeKymaInit := upgrade_kyma.NewInitialisationStep(db.Operations(), db.Orchestrations(), db.Instances(),
 		provisionerClient, inputFactory, upgradeEvalManager, icfg, runtimeVerConfigurator)
 
@@ -637,7 +730,8 @@ func NewOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStora
 	}
 
 	runtimeLister := orchestration.NewRuntimeLister(db.Instances(), db.Operations(), runtime.NewConverter(defaultRegion), logs)
-	runtimeResolver := orchestrationExt.NewGardenerRuntimeResolver(gardenerClient, gardenerNamespace, runtimeLister, logs)
+	runtimeResolver := orchestrationExt.NewGardenerRuntimeResolver(gardenerClient, gardenerNamespace, runtimeLister, logs).
+		WithEligibility(orchestration.UpgradeEligibility(runtimeVerConfigurator, logs))
 
 	orchestrateKymaManager := kyma.NewUpgradeKymaManager(db.Orchestrations(), db.Operations(), db.Instances(),
 		upgradeKymaManager, runtimeResolver, pollingInterval, logs)
@@ -27,6 +27,7 @@ type Client interface {
 	GetOperation(orchestrationID, operationID string) (OperationDetailResponse, error)
 	UpgradeKyma(params Parameters) (UpgradeResponse, error)
 	CancelOrchestration(orchestrationID string) error
+	ForceFailOperation(orchestrationID, operationID string, request ForceFailRequest) (OperationResponse, error)
 }
 
 type client struct {
@@ -312,6 +313,51 @@ func (c client) CancelOrchestration(orchestrationID string) error {
 	return nil
 }
 
+// ForceFailOperation marks the given operation of the orchestration as failed regardless of its state
+func (c client) ForceFailOperation(orchestrationID, operationID string, request ForceFailRequest) (OperationResponse, error) {
+	or := OperationResponse{}
+	blob, err := json.Marshal(request)
+	if err != nil {
+		return or, errors.Wrap(err, "while converting force fail request to JSON")
+	}
+
+	url := fmt.Sprintf("%s/orchestrations/%s/operations/%s/fail", c.url, orchestrationID, operationID)
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(blob))
+	if err != nil {
+		return or, errors.Wrap(err, "while creating force fail request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return or, errors.Wrapf(err, "while calling %s", url)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return or, fmt.Errorf("calling %s returned %s status", url, resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&or)
+	if err != nil {
+		return or, errors.Wrap(err, "while decoding response body")
+	}
+
+	return or, nil
+}
+
 func setQuery(url *url.URL, params ListParameters) {
 	query := url.Query()
 	query.Add(pagination.PageParam, strconv.Itoa(params.Page))
@@ -251,6 +251,39 @@ func TestClient_CancelOrchestration(t *testing.T) {
 	})
 }
 
+func TestClient_ForceFailOperation(t *testing.T) {
+	t.Run("test_URL__NoError_path", func(t *testing.T) {
+		// given
+		called := 0
+		request := ForceFailRequest{Reason: "shoot is gone", Initiator: "admin@kyma.cx"}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, fmt.Sprintf("/orchestrations/%s/operations/%s/fail", orch1.OrchestrationID, "op1"), r.URL.Path)
+			assert.Equal(t, fmt.Sprintf("Bearer %s", fixToken), r.Header.Get("Authorization"))
+			var body ForceFailRequest
+			err := json.NewDecoder(r.Body).Decode(&body)
+			require.NoError(t, err)
+			assert.Equal(t, request, body)
+
+			op := fixOperationResponse("op1", orch1.OrchestrationID)
+			op.State = Failed
+			err = json.NewEncoder(w).Encode(op)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		op, err := client.ForceFailOperation(orch1.OrchestrationID, "op1", request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, called)
+		assert.Equal(t, Failed, op.State)
+	})
+}
+
 func fixStatusResponse(id string) StatusResponse {
 	return StatusResponse{
 		OrchestrationID: id,
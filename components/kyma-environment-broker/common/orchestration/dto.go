@@ -24,6 +24,7 @@ const (
 	InProgress = "in progress"
 	Canceling  = "canceling"
 	Canceled   = "canceled"
+	Paused     = "paused"
 	Succeeded  = "succeeded"
 	Failed     = "failed"
 )
@@ -61,6 +62,8 @@ type StrategyType string
 
 const (
 	ParallelStrategy StrategyType = "parallel"
+	// CanaryStrategy executes the operations of the canary runtimes first and the rest of the operations only if the canary succeeds
+	CanaryStrategy StrategyType = "canary"
 )
 
 type ScheduleType string
@@ -70,9 +73,34 @@ const (
 	MaintenanceWindow ScheduleType = "maintenanceWindow"
 )
 
+// FairnessPolicy defines the order in which the parallel strategy passes the operations of runtimes with different plans to the workers
+type FairnessPolicy string
+
+const (
+	// FairnessNone passes the operations to the workers in the order given by the schedule
+	FairnessNone FairnessPolicy = "none"
+	// FairnessRoundRobin passes the operations of each plan in turns, so that plans with many runtimes do not delay plans with few runtimes
+	FairnessRoundRobin FairnessPolicy = "roundRobin"
+)
+
 // ParallelStrategySpec defines parameters for the parallel orchestration strategy
 type ParallelStrategySpec struct {
-	Workers int `json:"workers"`
+	Workers  int            `json:"workers"`
+	Fairness FairnessPolicy `json:"fairness,omitempty"`
+	// BatchSize is the number of operations executed in a single batch, the next batch starts when all operations of the current one are finished, zero value means a single batch
+	BatchSize int `json:"batchSize,omitempty"`
+	// FailureThreshold is the number of failed operations in a batch which stops the execution of the next batches, zero value means the execution is never stopped
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+}
+
+// CanaryStrategySpec defines parameters for the canary orchestration strategy, which uses the parallel strategy parameters to execute the operations
+type CanaryStrategySpec struct {
+	// Size is the number of canary runtimes
+	Size int `json:"size,omitempty"`
+	// Percentage is the percentage of canary runtimes, used when the size is not set
+	Percentage int `json:"percentage,omitempty"`
+	// MaxFailures is the number of failed canary operations for which the canary still succeeds
+	MaxFailures int `json:"maxFailures,omitempty"`
 }
 
 // StrategySpec is the strategy part common for all orchestration trigger/status API
@@ -80,6 +108,9 @@ type StrategySpec struct {
 	Type     StrategyType         `json:"type"`
 	Schedule ScheduleType         `json:"schedule,omitempty"`
 	Parallel ParallelStrategySpec `json:"parallel,omitempty"`
+	Canary   CanaryStrategySpec   `json:"canary,omitempty"`
+	// ScheduledAt is the time before which the operations must not be started, zero value means no such restriction
+	ScheduledAt time.Time `json:"scheduledAt,omitempty"`
 }
 
 // TargetSpec is the targets part common for all orchestration trigger/status API
@@ -112,6 +143,15 @@ type OperationResponse struct {
 	MaintenanceWindowEnd   time.Time `json:"maintenanceWindowEnd"`
 	State                  string    `json:"state"`
 	Description            string    `json:"description"`
+	DeferralReason         string    `json:"deferralReason,omitempty"`
+	ProgressPercent        int       `json:"progressPercent"`
+}
+
+// ForceFailRequest is the body of the request marking an operation as failed regardless of its state
+type ForceFailRequest struct {
+	Reason string `json:"reason"`
+	// Initiator is recorded as unverified and only if the request is not authenticated, otherwise the authenticated user is the initiator
+	Initiator string `json:"initiator"`
 }
 
 type OperationResponseList struct {
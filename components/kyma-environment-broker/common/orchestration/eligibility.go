@@ -0,0 +1,118 @@
+package orchestration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	brokerapi "github.com/pivotal-cf/brokerapi/v7/domain"
+)
+
+// EligibilityPredicate decides if a runtime can be selected as a target of an orchestration.
+// A runtime which is not eligible is skipped with the returned reason.
+type EligibilityPredicate func(rt runtime.RuntimeDTO) (eligible bool, reason string)
+
+// DefaultEligibility selects the provisioned runtimes which are not suspended
+var DefaultEligibility = AllOf(Provisioned(), NotSuspended())
+
+// AllOf returns a predicate which is satisfied when all the given predicates are satisfied, the reason of the first unsatisfied predicate is returned
+func AllOf(predicates ...EligibilityPredicate) EligibilityPredicate {
+	return func(rt runtime.RuntimeDTO) (bool, string) {
+		for _, predicate := range predicates {
+			if eligible, reason := predicate(rt); !eligible {
+				return false, reason
+			}
+		}
+		return true, ""
+	}
+}
+
+// AnyOf returns a predicate which is satisfied when any of the given predicates is satisfied, the reasons of all unsatisfied predicates are returned
+func AnyOf(predicates ...EligibilityPredicate) EligibilityPredicate {
+	return func(rt runtime.RuntimeDTO) (bool, string) {
+		var reasons []string
+		for _, predicate := range predicates {
+			eligible, reason := predicate(rt)
+			if eligible {
+				return true, ""
+			}
+			reasons = append(reasons, reason)
+		}
+		return false, strings.Join(reasons, ", ")
+	}
+}
+
+// Provisioned selects the runtimes with a succeeded provisioning which are not deprovisioned.
+// Suspension is not considered a deprovisioning, use NotSuspended to skip suspended runtimes.
+func Provisioned() EligibilityPredicate {
+	return func(rt runtime.RuntimeDTO) (bool, string) {
+		provState := ""
+		if rt.Status.Provisioning != nil {
+			provState = rt.Status.Provisioning.State
+		}
+		if provState != string(brokerapi.Succeeded) {
+			return false, fmt.Sprintf("provisioning state is %q", provState)
+		}
+		if rt.Status.Deprovisioning != nil && !isSuspension(rt, rt.Status.Deprovisioning.OperationID) {
+			return false, fmt.Sprintf("deprovisioning state is %q", rt.Status.Deprovisioning.State)
+		}
+		return true, ""
+	}
+}
+
+// NotSuspended selects the runtimes which were never suspended or were unsuspended after the last suspension
+func NotSuspended() EligibilityPredicate {
+	return func(rt runtime.RuntimeDTO) (bool, string) {
+		suspension, found := latestOperation(rt.Status.Suspension.Data)
+		if !found {
+			return true, ""
+		}
+		unsuspension, found := latestOperation(rt.Status.Unsuspension.Data)
+		if found && unsuspension.CreatedAt.After(suspension.CreatedAt) {
+			return true, ""
+		}
+		return false, "runtime is suspended"
+	}
+}
+
+// NotOnVersion selects the runtimes whose Kyma version differs from the target version returned for the runtime.
+// Runtimes with unknown Kyma version are selected.
+func NotOnVersion(targetVersion func(rt runtime.RuntimeDTO) string) EligibilityPredicate {
+	return func(rt runtime.RuntimeDTO) (bool, string) {
+		if rt.KymaVersion != "" && rt.KymaVersion == targetVersion(rt) {
+			return false, fmt.Sprintf("runtime is already on Kyma version %s", rt.KymaVersion)
+		}
+		return true, ""
+	}
+}
+
+// PlanNotIn selects the runtimes whose service plan is not one of the given plans
+func PlanNotIn(plans ...string) EligibilityPredicate {
+	return func(rt runtime.RuntimeDTO) (bool, string) {
+		for _, plan := range plans {
+			if rt.ServicePlanName == plan {
+				return false, fmt.Sprintf("plan %s is excluded", plan)
+			}
+		}
+		return true, ""
+	}
+}
+
+func isSuspension(rt runtime.RuntimeDTO, operationID string) bool {
+	for _, op := range rt.Status.Suspension.Data {
+		if op.OperationID == operationID {
+			return true
+		}
+	}
+	return false
+}
+
+func latestOperation(operations []runtime.Operation) (runtime.Operation, bool) {
+	var latest runtime.Operation
+	for _, op := range operations {
+		if op.CreatedAt.After(latest.CreatedAt) {
+			latest = op
+		}
+	}
+	return latest, len(operations) > 0
+}
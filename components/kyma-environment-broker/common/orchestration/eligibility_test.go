@@ -0,0 +1,109 @@
+package orchestration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	brokerapi "github.com/pivotal-cf/brokerapi/v7/domain"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+)
+
+func TestDefaultEligibility(t *testing.T) {
+	now := time.Now()
+	suspended := fixRuntimeDTO(1, globalAccountID1, string(brokerapi.Succeeded), string(brokerapi.Succeeded), plan1)
+	suspended.Status.Deprovisioning.OperationID = "suspension-id"
+	suspended.Status.Suspension.Data = []runtime.Operation{{OperationID: "suspension-id", CreatedAt: now}}
+	unsuspended := suspended
+	unsuspended.Status.Unsuspension.Data = []runtime.Operation{{OperationID: "unsuspension-id", CreatedAt: now.Add(time.Hour)}}
+
+	for name, tc := range map[string]struct {
+		runtime  runtime.RuntimeDTO
+		eligible bool
+	}{
+		"provisioned": {
+			runtime:  fixRuntimeDTO(1, globalAccountID1, string(brokerapi.Succeeded), "", plan1),
+			eligible: true,
+		},
+		"failed provisioning": {
+			runtime:  fixRuntimeDTO(1, globalAccountID1, string(brokerapi.Failed), "", plan1),
+			eligible: false,
+		},
+		"deprovisioning": {
+			runtime:  fixRuntimeDTO(1, globalAccountID1, string(brokerapi.Succeeded), string(brokerapi.InProgress), plan1),
+			eligible: false,
+		},
+		"suspended": {
+			runtime:  suspended,
+			eligible: false,
+		},
+		"unsuspended": {
+			runtime:  unsuspended,
+			eligible: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			eligible, reason := DefaultEligibility(tc.runtime)
+
+			// then
+			assert.Equal(t, tc.eligible, eligible)
+			if !tc.eligible {
+				assert.NotEmpty(t, reason)
+			}
+		})
+	}
+}
+
+func TestNotOnVersion(t *testing.T) {
+	// given
+	predicate := NotOnVersion(func(rt runtime.RuntimeDTO) string {
+		return "1.20.0"
+	})
+	upgraded := fixRuntimeDTO(1, globalAccountID1, string(brokerapi.Succeeded), "", plan1)
+	upgraded.KymaVersion = "1.20.0"
+	outdated := fixRuntimeDTO(2, globalAccountID1, string(brokerapi.Succeeded), "", plan1)
+	outdated.KymaVersion = "1.19.0"
+	unknown := fixRuntimeDTO(3, globalAccountID1, string(brokerapi.Succeeded), "", plan1)
+
+	// then
+	eligible, reason := predicate(upgraded)
+	assert.False(t, eligible)
+	assert.Equal(t, "runtime is already on Kyma version 1.20.0", reason)
+	eligible, _ = predicate(outdated)
+	assert.True(t, eligible)
+	eligible, _ = predicate(unknown)
+	assert.True(t, eligible)
+}
+
+func TestEligibilityComposition(t *testing.T) {
+	// given
+	gcp := fixRuntimeDTO(1, globalAccountID1, string(brokerapi.Succeeded), "", plan2)
+	azure := fixRuntimeDTO(2, globalAccountID1, string(brokerapi.Succeeded), "", plan1)
+	failedAzure := fixRuntimeDTO(3, globalAccountID1, string(brokerapi.Failed), "", plan1)
+
+	t.Run("AllOf", func(t *testing.T) {
+		predicate := AllOf(Provisioned(), PlanNotIn(plan2))
+
+		eligible, reason := predicate(gcp)
+		assert.False(t, eligible)
+		assert.Equal(t, "plan gcp is excluded", reason)
+		eligible, _ = predicate(azure)
+		assert.True(t, eligible)
+		eligible, _ = predicate(failedAzure)
+		assert.False(t, eligible)
+	})
+
+	t.Run("AnyOf", func(t *testing.T) {
+		predicate := AnyOf(Provisioned(), PlanNotIn(plan1))
+
+		eligible, _ := predicate(gcp)
+		assert.True(t, eligible)
+		eligible, reason := predicate(failedAzure)
+		require.False(t, eligible)
+		assert.Equal(t, `provisioning state is "failed", plan azure is excluded`, reason)
+	})
+}
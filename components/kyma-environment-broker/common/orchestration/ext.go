@@ -12,10 +12,14 @@ type Runtime struct {
 	SubAccountID    string `json:"subaccountId"`
 	// The corresponding shoot cluster's .metadata.name value
 	ShootName string `json:"shootName"`
+	// The name of the service plan of the runtime
+	PlanName string `json:"planName,omitempty"`
 	// The corresponding shoot cluster's .spec.maintenance.timeWindow.Begin value, which is in in "HHMMSS+[HHMM TZ]" format, e.g. "040000+0000"
 	MaintenanceWindowBegin time.Time `json:"maintenanceWindowBegin"`
 	// The corresponding shoot cluster's .spec.maintenance.timeWindow.End value, which is in "HHMMSS+[HHMM TZ]" format, e.g. "040000+0000"
 	MaintenanceWindowEnd time.Time `json:"maintenanceWindowEnd"`
+	// Optional weekly window taken from the shoot cluster's kcp.kyma-project.io/maintenance-window annotation, e.g. "Sat,Sun 01:00-05:00 Europe/Berlin"
+	TimeWindow *TimeWindow `json:"timeWindow,omitempty"`
 }
 
 // RuntimeOperation holds information about operation performed on a runtime
@@ -23,6 +27,23 @@ type RuntimeOperation struct {
 	Runtime `json:""`
 	ID      string `json:"-"`
 	DryRun  bool   `json:"dryRun"`
+	// ScheduledAt is the time before which the operation must not be started, zero value means the operation can be started immediately
+	ScheduledAt time.Time `json:"scheduledAt"`
+}
+
+// Delay returns how long the operation must wait at the given time before it can be started.
+// If the operation is scheduled with the maintenance window, the later of the maintenance window begin and ScheduledAt wins.
+func (op RuntimeOperation) Delay(schedule ScheduleType, now time.Time) time.Duration {
+	delay := op.ScheduledAt.Sub(now)
+	if schedule == MaintenanceWindow {
+		if until := op.MaintenanceWindowBegin.Sub(now); until > delay {
+			delay = until
+		}
+	}
+	if delay < 0 {
+		return 0
+	}
+	return delay
 }
 
 //go:generate mockery --name=RuntimeResolver --output=automock --outpkg=automock --case=underscore
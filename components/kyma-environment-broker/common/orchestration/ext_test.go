@@ -0,0 +1,52 @@
+package orchestration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeOperation_Delay(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	for name, tc := range map[string]struct {
+		operation RuntimeOperation
+		schedule  ScheduleType
+		expected  time.Duration
+	}{
+		"not scheduled": {
+			operation: RuntimeOperation{},
+			schedule:  Immediate,
+			expected:  0,
+		},
+		"before scheduled time": {
+			operation: RuntimeOperation{ScheduledAt: now.Add(time.Hour)},
+			schedule:  Immediate,
+			expected:  time.Hour,
+		},
+		"after scheduled time": {
+			operation: RuntimeOperation{ScheduledAt: now.Add(-time.Hour)},
+			schedule:  Immediate,
+			expected:  0,
+		},
+		"maintenance window ignored for immediate schedule": {
+			operation: RuntimeOperation{Runtime: Runtime{MaintenanceWindowBegin: now.Add(2 * time.Hour)}},
+			schedule:  Immediate,
+			expected:  0,
+		},
+		"maintenance window later than scheduled time": {
+			operation: RuntimeOperation{ScheduledAt: now.Add(time.Hour), Runtime: Runtime{MaintenanceWindowBegin: now.Add(2 * time.Hour)}},
+			schedule:  MaintenanceWindow,
+			expected:  2 * time.Hour,
+		},
+		"scheduled time later than maintenance window": {
+			operation: RuntimeOperation{ScheduledAt: now.Add(3 * time.Hour), Runtime: Runtime{MaintenanceWindowBegin: now.Add(2 * time.Hour)}},
+			schedule:  MaintenanceWindow,
+			expected:  3 * time.Hour,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.operation.Delay(tc.schedule, now))
+		})
+	}
+}
@@ -11,7 +11,6 @@ import (
 
 	gardenerapi "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	gardenerclient "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
-	brokerapi "github.com/pivotal-cf/brokerapi/v7/domain"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,6 +31,7 @@ type GardenerRuntimeResolver struct {
 	gardenerNamespace string
 	runtimeLister     RuntimeLister
 	runtimes          map[string]runtime.RuntimeDTO
+	eligibility       EligibilityPredicate
 	mutex             sync.RWMutex
 	logger            logrus.FieldLogger
 }
@@ -40,6 +40,7 @@ const (
 	globalAccountLabel      = "account"
 	subAccountLabel         = "subaccount"
 	runtimeIDAnnotation     = "kcp.provisioner.kyma-project.io/runtime-id"
+	timeWindowAnnotation    = "kcp.kyma-project.io/maintenance-window"
 	maintenanceWindowFormat = "150405-0700"
 )
 
@@ -50,10 +51,17 @@ func NewGardenerRuntimeResolver(gardenerClient gardenerclient.CoreV1beta1Interfa
 		gardenerNamespace: gardenerNamespace,
 		runtimeLister:     lister,
 		runtimes:          map[string]runtime.RuntimeDTO{},
+		eligibility:       DefaultEligibility,
 		logger:            logger.WithField("orchestration", "resolver"),
 	}
 }
 
+// WithEligibility replaces the DefaultEligibility predicate, which decides if a runtime can be selected as a target.
+func (resolver *GardenerRuntimeResolver) WithEligibility(predicate EligibilityPredicate) *GardenerRuntimeResolver {
+	resolver.eligibility = predicate
+	return resolver
+}
+
 // Resolve given an input slice of target specs to include and exclude, returns back a list of unique Runtime objects
 func (resolver *GardenerRuntimeResolver) Resolve(targets TargetSpec) ([]Runtime, error) {
 	runtimeIncluded := map[string]bool{}
@@ -133,9 +141,6 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 
 	// Iterate over all shoots. Evaluate target specs. If multiple are specified, all must match for a given shoot.
 	for _, shoot := range shoots {
-		// Skip runtimes for which
-		//  - there is no succeeded instance provision operation in DB
-		//  - deprovision operation exists in DB
 		runtimeID := shoot.Annotations[runtimeIDAnnotation]
 		if runtimeID == "" {
 			resolver.logger.Errorf("Failed to get runtimeID from %s annotation for Shoot %s", runtimeIDAnnotation, shoot.Name)
@@ -146,16 +151,8 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			resolver.logger.Errorf("Couldn't find runtime for runtimeID %s", runtimeID)
 			continue
 		}
-		provState := ""
-		deprovState := ""
-		if runtime.Status.Provisioning != nil {
-			provState = runtime.Status.Provisioning.State
-		}
-		if runtime.Status.Deprovisioning != nil {
-			deprovState = runtime.Status.Deprovisioning.State
-		}
-		if provState != string(brokerapi.Succeeded) || deprovState != "" {
-			resolver.logger.Infof("Skipping Shoot %s (runtimeID: %s, instanceID %s) due to provisioning/deprovisioning state: %s/%s", shoot.Name, runtimeID, runtime.InstanceID, provState, deprovState)
+		if eligible, reason := resolver.eligibility(runtime); !eligible {
+			resolver.logger.Infof("Skipping Shoot %s (runtimeID: %s, instanceID %s): %s", shoot.Name, runtimeID, runtime.InstanceID, reason)
 			continue
 		}
 		maintenanceWindowBegin, err := time.Parse(maintenanceWindowFormat, shoot.Spec.Maintenance.TimeWindow.Begin)
@@ -168,11 +165,20 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			resolver.logger.Errorf("Failed to parse maintenanceWindowEnd value %s of shoot %s ", shoot.Spec.Maintenance.TimeWindow.End, shoot.Name)
 			continue
 		}
+		var timeWindow *TimeWindow
+		if value, ok := shoot.Annotations[timeWindowAnnotation]; ok {
+			window, err := ParseTimeWindow(value)
+			if err != nil {
+				resolver.logger.Errorf("Failed to parse %s annotation value %s of shoot %s: %v", timeWindowAnnotation, value, shoot.Name, err)
+				continue
+			}
+			timeWindow = &window
+		}
 
 		// Match exact shoot by runtimeID
 		if rt.RuntimeID != "" {
 			if rt.RuntimeID == runtimeID {
-				runtimes = append(runtimes, resolver.runtimeFromDTO(runtime, shoot.Name, maintenanceWindowBegin, maintenanceWindowEnd))
+				runtimes = append(runtimes, resolver.runtimeFromDTO(runtime, shoot.Name, maintenanceWindowBegin, maintenanceWindowEnd, timeWindow))
 			}
 			continue
 		}
@@ -218,20 +224,22 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 			continue
 		}
 
-		runtimes = append(runtimes, resolver.runtimeFromDTO(runtime, shoot.Name, maintenanceWindowBegin, maintenanceWindowEnd))
+		runtimes = append(runtimes, resolver.runtimeFromDTO(runtime, shoot.Name, maintenanceWindowBegin, maintenanceWindowEnd, timeWindow))
 	}
 
 	return runtimes, nil
 }
 
-func (*GardenerRuntimeResolver) runtimeFromDTO(runtime runtime.RuntimeDTO, shootName string, windowBegin, windowEnd time.Time) Runtime {
+func (*GardenerRuntimeResolver) runtimeFromDTO(runtime runtime.RuntimeDTO, shootName string, windowBegin, windowEnd time.Time, timeWindow *TimeWindow) Runtime {
 	return Runtime{
 		InstanceID:             runtime.InstanceID,
 		RuntimeID:              runtime.RuntimeID,
 		GlobalAccountID:        runtime.GlobalAccountID,
 		SubAccountID:           runtime.SubAccountID,
 		ShootName:              shootName,
+		PlanName:               runtime.ServicePlanName,
 		MaintenanceWindowBegin: windowBegin,
 		MaintenanceWindowEnd:   windowEnd,
+		TimeWindow:             timeWindow,
 	}
 }
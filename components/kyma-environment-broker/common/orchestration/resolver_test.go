@@ -186,6 +186,39 @@ func TestResolver_Resolve(t *testing.T) {
 	}
 }
 
+func TestResolver_Resolve_Eligibility(t *testing.T) {
+	// given
+	suspended := fixRuntimeDTO(1, globalAccountID1, string(brokerapi.Succeeded), string(brokerapi.Succeeded), plan2)
+	suspended.Status.Deprovisioning.OperationID = "suspension-id"
+	suspended.Status.Suspension.Data = []runtime.Operation{{OperationID: "suspension-id"}}
+	upgraded := fixRuntimeDTO(2, globalAccountID1, string(brokerapi.Succeeded), "", plan1)
+	upgraded.KymaVersion = "1.20.0"
+	eligible := fixRuntimeDTO(3, globalAccountID2, string(brokerapi.Succeeded), "", plan1)
+	eligible.KymaVersion = "1.19.0"
+
+	client := newFakeGardenerClient()
+	lister := &RuntimeListerMock{}
+	lister.On("ListAllRuntimes").Return([]runtime.RuntimeDTO{suspended, upgraded, eligible}, nil)
+	defer lister.AssertExpectations(t)
+	resolver := NewGardenerRuntimeResolver(client, shootNamespace, lister, newLogDummy()).
+		WithEligibility(AllOf(DefaultEligibility, NotOnVersion(func(runtime.RuntimeDTO) string {
+			return "1.20.0"
+		})))
+
+	// when
+	runtimes, err := resolver.Resolve(TargetSpec{
+		Include: []RuntimeTarget{
+			{
+				Target: TargetAll,
+			},
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	assertRuntimeTargets(t, []expectedRuntime{{shoot: &shoot3, runtime: &eligible}}, runtimes)
+}
+
 func TestResolver_Resolve_GardenerFailure(t *testing.T) {
 	// given
 	fake := &k8stesting.Fake{}
@@ -0,0 +1,63 @@
+package strategies
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/sirupsen/logrus"
+)
+
+type CanaryOrchestrationStrategy struct {
+	*ParallelOrchestrationStrategy
+}
+
+// NewCanaryOrchestrationStrategy returns a new canary orchestration strategy, which executes the operations of the canary runtimes first
+// and the rest of the operations only when the number of failed canary operations does not exceed the allowed maximum.
+func NewCanaryOrchestrationStrategy(executor Executor, log logrus.FieldLogger) orchestration.Strategy {
+	return &CanaryOrchestrationStrategy{
+		ParallelOrchestrationStrategy: NewParallelOrchestrationStrategy(executor, log).(*ParallelOrchestrationStrategy),
+	}
+}
+
+// Execute starts the execution of the canary operations followed by the rest of the operations, which are executed in batches if the batch size is set.
+func (c *CanaryOrchestrationStrategy) Execute(operations []orchestration.RuntimeOperation, strategySpec orchestration.StrategySpec) (string, error) {
+	if len(operations) == 0 {
+		return "", nil
+	}
+
+	operations = orderOperations(operations, strategySpec)
+	size := canarySize(strategySpec.Canary, len(operations))
+	batches := [][]orchestration.RuntimeOperation{operations[:size]}
+	if size < len(operations) {
+		batches = append(batches, splitIntoBatches(operations[size:], strategySpec.Parallel.BatchSize)...)
+	}
+	c.log.Infof("Executing %d canary operations out of %d", size, len(operations))
+
+	thresholdReached := failureThresholdReached(strategySpec.Parallel.FailureThreshold)
+	return c.executeInBatches(batches, strategySpec, func(batch, failed int) bool {
+		if batch == 0 {
+			if failed > strategySpec.Canary.MaxFailures {
+				c.log.Errorf("Canary failed with %d failed operations, the maximum is %d", failed, strategySpec.Canary.MaxFailures)
+				return true
+			}
+			return false
+		}
+		return thresholdReached(batch, failed)
+	}), nil
+}
+
+// canarySize returns the number of canary operations, which is at least one and at most the number of all operations
+func canarySize(spec orchestration.CanaryStrategySpec, total int) int {
+	size := spec.Size
+	if size == 0 && spec.Percentage > 0 {
+		// round up, so that any percentage selects at least one runtime
+		size = (total*spec.Percentage + 99) / 100
+	}
+
+	switch {
+	case size < 1:
+		return 1
+	case size > total:
+		return total
+	default:
+		return size
+	}
+}
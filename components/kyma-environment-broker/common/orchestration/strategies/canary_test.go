@@ -0,0 +1,93 @@
+package strategies
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanaryOrchestrationStrategy_Execute(t *testing.T) {
+	ops := make([]orchestration.RuntimeOperation, 5)
+	for i := range ops {
+		ops[i] = orchestration.RuntimeOperation{ID: fmt.Sprintf("op-%d", i)}
+	}
+	spec := orchestration.StrategySpec{
+		Type:     orchestration.CanaryStrategy,
+		Schedule: orchestration.Immediate,
+		Parallel: orchestration.ParallelStrategySpec{Workers: 2},
+		Canary:   orchestration.CanaryStrategySpec{Size: 2},
+	}
+
+	t.Run("should execute the rest of the operations when the canary succeeds", func(t *testing.T) {
+		// given
+		executor := newBatchExecutor("op-3")
+		s := NewCanaryOrchestrationStrategy(executor, logrus.New())
+
+		// when
+		id, err := s.Execute(ops, spec)
+		assert.NoError(t, err)
+		s.Wait(id)
+
+		// then
+		assert.Len(t, executor.started, len(ops))
+		for _, canary := range ops[:2] {
+			for _, op := range ops[2:] {
+				assert.False(t, executor.begun[op.ID].Before(executor.finished[canary.ID]), "%s started before canary %s finished", op.ID, canary.ID)
+			}
+		}
+	})
+
+	t.Run("should not execute the rest of the operations when the canary fails", func(t *testing.T) {
+		// given
+		executor := newBatchExecutor("op-1")
+		s := NewCanaryOrchestrationStrategy(executor, logrus.New())
+
+		// when
+		id, err := s.Execute(ops, spec)
+		assert.NoError(t, err)
+		s.Wait(id)
+
+		// then
+		assert.ElementsMatch(t, []string{"op-0", "op-1"}, executor.started)
+	})
+
+	t.Run("should execute the rest of the operations when the canary failures do not exceed the maximum", func(t *testing.T) {
+		// given
+		executor := newBatchExecutor("op-1")
+		s := NewCanaryOrchestrationStrategy(executor, logrus.New())
+		tolerant := spec
+		tolerant.Canary.MaxFailures = 1
+
+		// when
+		id, err := s.Execute(ops, tolerant)
+		assert.NoError(t, err)
+		s.Wait(id)
+
+		// then
+		assert.Len(t, executor.started, len(ops))
+	})
+}
+
+func TestCanarySize(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec     orchestration.CanaryStrategySpec
+		total    int
+		expected int
+	}{
+		"default":               {spec: orchestration.CanaryStrategySpec{}, total: 10, expected: 1},
+		"size":                  {spec: orchestration.CanaryStrategySpec{Size: 3}, total: 10, expected: 3},
+		"size over total":       {spec: orchestration.CanaryStrategySpec{Size: 30}, total: 10, expected: 10},
+		"percentage":            {spec: orchestration.CanaryStrategySpec{Percentage: 20}, total: 10, expected: 2},
+		"percentage rounded up": {spec: orchestration.CanaryStrategySpec{Percentage: 1}, total: 10, expected: 1},
+		"size over percentage":  {spec: orchestration.CanaryStrategySpec{Size: 4, Percentage: 50}, total: 10, expected: 4},
+		"full percentage":       {spec: orchestration.CanaryStrategySpec{Percentage: 100}, total: 7, expected: 7},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, canarySize(tc.spec, tc.total))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"runtime/debug"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,35 +37,144 @@ func NewParallelOrchestrationStrategy(executor Executor, log logrus.FieldLogger)
 }
 
 // Execute starts the parallel execution of operations.
+// If the batch size is set, the operations are executed in batches and the next batch is started only when all operations of the current batch are finished.
 func (p *ParallelOrchestrationStrategy) Execute(operations []orchestration.RuntimeOperation, strategySpec orchestration.StrategySpec) (string, error) {
 	if len(operations) == 0 {
 		return "", nil
 	}
-	ops := make(chan orchestration.RuntimeOperation, len(operations))
-	execID := uuid.New().String()
-	p.mux.Lock()
-	defer p.mux.Unlock()
-	p.wg[execID] = &sync.WaitGroup{}
-	p.dq[execID] = workqueue.NewDelayingQueue()
 
+	operations = orderOperations(operations, strategySpec)
+	batches := splitIntoBatches(operations, strategySpec.Parallel.BatchSize)
+
+	return p.executeInBatches(batches, strategySpec, failureThresholdReached(strategySpec.Parallel.FailureThreshold)), nil
+}
+
+// stopCondition decides if the execution stops after the batch with the given index and number of failed operations
+type stopCondition func(batch, failed int) bool
+
+// failureThresholdReached stops the execution when the number of failed operations in a batch reaches the threshold, zero threshold never stops it
+func failureThresholdReached(threshold int) stopCondition {
+	return func(_, failed int) bool {
+		return threshold > 0 && failed >= threshold
+	}
+}
+
+// orderOperations sorts the operations according to the schedule and the fairness policy of the strategy
+func orderOperations(operations []orchestration.RuntimeOperation, strategySpec orchestration.StrategySpec) []orchestration.RuntimeOperation {
 	if strategySpec.Schedule == orchestration.MaintenanceWindow {
 		sort.Slice(operations, func(i, j int) bool {
 			return operations[i].MaintenanceWindowBegin.Before(operations[j].MaintenanceWindowBegin)
 		})
 	}
+	if strategySpec.Parallel.Fairness == orchestration.FairnessRoundRobin {
+		operations = roundRobinByPlan(operations)
+	}
 
-	// Create workers
-	for i := 0; i < strategySpec.Parallel.Workers; i++ {
-		p.createWorker(execID, ops, strategySpec)
+	return operations
+}
+
+// splitIntoBatches splits the operations into consecutive batches of the given size, a size lower than 1 means a single batch
+func splitIntoBatches(operations []orchestration.RuntimeOperation, size int) [][]orchestration.RuntimeOperation {
+	if size < 1 || size >= len(operations) {
+		return [][]orchestration.RuntimeOperation{operations}
 	}
 
-	// Send operations to workers
+	batches := make([][]orchestration.RuntimeOperation, 0, (len(operations)+size-1)/size)
+	for size < len(operations) {
+		batches = append(batches, operations[:size])
+		operations = operations[size:]
+	}
+
+	return append(batches, operations)
+}
+
+// executeInBatches starts the asynchronous execution of the batches and returns the execution ID
+func (p *ParallelOrchestrationStrategy) executeInBatches(batches [][]orchestration.RuntimeOperation, strategySpec orchestration.StrategySpec, stop stopCondition) string {
+	execID := uuid.New().String()
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	wg := &sync.WaitGroup{}
+	dq := workqueue.NewDelayingQueue()
+	p.wg[execID] = wg
+	p.dq[execID] = dq
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.executeBatches(execID, dq, batches, strategySpec, stop)
+	}()
+
+	return execID
+}
+
+// executeBatches executes the batches one after another and stops when the execution is canceled or the stop condition is met
+func (p *ParallelOrchestrationStrategy) executeBatches(execID string, dq workqueue.DelayingInterface, batches [][]orchestration.RuntimeOperation, strategy orchestration.StrategySpec, stop stopCondition) {
+	for i, batch := range batches {
+		if dq.ShuttingDown() {
+			return
+		}
+		if len(batches) > 1 {
+			p.log.Infof("Executing batch %d/%d with %d operations", i+1, len(batches), len(batch))
+		}
+
+		failed := p.executeBatch(execID, batch, strategy)
+		if i < len(batches)-1 && stop(i, failed) {
+			p.log.Errorf("Batch %d/%d has %d failed operations, the remaining batches are not executed", i+1, len(batches), failed)
+			return
+		}
+	}
+}
+
+// executeBatch passes the operations to the workers and blocks until all of them are processed, it returns the number of failed operations
+func (p *ParallelOrchestrationStrategy) executeBatch(execID string, operations []orchestration.RuntimeOperation, strategy orchestration.StrategySpec) int {
+	ops := make(chan orchestration.RuntimeOperation, len(operations))
 	for _, op := range operations {
 		ops <- op
 	}
 	close(ops)
 
-	return execID, nil
+	var failed int32
+	wg := sync.WaitGroup{}
+	for i := 0; i < strategy.Parallel.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for op := range ops {
+				if err := p.processOperation(op, strategy, execID); err != nil {
+					atomic.AddInt32(&failed, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return int(atomic.LoadInt32(&failed))
+}
+
+// roundRobinByPlan reorders the operations so that the plans take turns, keeping the order of the operations within each plan.
+// The k-th operation of every plan is passed to the workers within the first k*len(plans) operations.
+func roundRobinByPlan(operations []orchestration.RuntimeOperation) []orchestration.RuntimeOperation {
+	var plans []string
+	byPlan := map[string][]orchestration.RuntimeOperation{}
+	for _, op := range operations {
+		if _, found := byPlan[op.PlanName]; !found {
+			plans = append(plans, op.PlanName)
+		}
+		byPlan[op.PlanName] = append(byPlan[op.PlanName], op)
+	}
+
+	result := make([]orchestration.RuntimeOperation, 0, len(operations))
+	for len(result) < len(operations) {
+		for _, plan := range plans {
+			if len(byPlan[plan]) == 0 {
+				continue
+			}
+			result = append(result, byPlan[plan][0])
+			byPlan[plan] = byPlan[plan][1:]
+		}
+	}
+
+	return result
 }
 
 func (p *ParallelOrchestrationStrategy) Wait(executionID string) {
@@ -83,32 +193,17 @@ func (p *ParallelOrchestrationStrategy) Cancel(executionID string) {
 	p.dq[executionID].ShutDown()
 }
 
-func (p *ParallelOrchestrationStrategy) createWorker(execID string, ops <-chan orchestration.RuntimeOperation, strategy orchestration.StrategySpec) {
-	p.wg[execID].Add(1)
-	go func() {
-		for op := range ops {
-			err := p.processOperation(op, strategy, execID)
-			if err != nil {
-				p.log.Errorf("while processing operation %s: %v", op.ID, err)
-			}
-		}
-		p.mux.RLock()
-		p.wg[execID].Done()
-		p.mux.RUnlock()
-	}()
-}
-
+// processOperation executes the operation until it is finished and returns the error which finished it
 func (p *ParallelOrchestrationStrategy) processOperation(op orchestration.RuntimeOperation, strategy orchestration.StrategySpec, executionID string) error {
+	var processErr error
 	exit := false
 	id := op.ID
 	log := p.log.WithField("operationID", id)
 
-	switch strategy.Schedule {
-	case orchestration.MaintenanceWindow:
-		until := time.Until(op.MaintenanceWindowBegin)
+	if until := op.Delay(strategy.Schedule, time.Now()); until > 0 {
 		log.Infof("Upgrade operation will be scheduled in %v", until)
 		p.dq[executionID].AddAfter(id, until)
-	case orchestration.Immediate:
+	} else {
 		log.Infof("Upgrade operation is scheduled now")
 		p.dq[executionID].Add(id)
 	}
@@ -136,10 +231,11 @@ func (p *ParallelOrchestrationStrategy) processOperation(op orchestration.Runtim
 			}
 			if err != nil {
 				log.Errorf("Error from process: %v", err)
+				processErr = err
 			}
 			return true
 		}()
 	}
 	log.Info("Finishing processing operation")
-	return nil
+	return processErr
 }
@@ -1,6 +1,7 @@
 package strategies
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -100,3 +101,170 @@ func TestNewParallelOrchestrationStrategy_Canceled(t *testing.T) {
 	assert.NoError(t, err)
 	s.Wait(id)
 }
+
+func TestRoundRobinByPlan(t *testing.T) {
+	// given
+	var ops []orchestration.RuntimeOperation
+	plans := map[string]int{"azure": 50, "trial": 2, "gcp": 1}
+	for _, plan := range []string{"azure", "trial", "gcp"} {
+		for i := 0; i < plans[plan]; i++ {
+			ops = append(ops, orchestration.RuntimeOperation{
+				ID:      fmt.Sprintf("%s-%d", plan, i),
+				Runtime: orchestration.Runtime{PlanName: plan},
+			})
+		}
+	}
+
+	// when
+	ordered := roundRobinByPlan(ops)
+
+	// then
+	assert.Len(t, ordered, len(ops))
+	picked := map[string]int{}
+	for idx, op := range ordered {
+		// the operations of each plan keep their order and the k-th one is picked within the first k*len(plans) picks
+		assert.Equal(t, fmt.Sprintf("%s-%d", op.PlanName, picked[op.PlanName]), op.ID)
+		picked[op.PlanName]++
+		assert.True(t, idx < picked[op.PlanName]*len(plans), "operation %s picked at %d", op.ID, idx)
+	}
+	assert.Equal(t, plans, picked)
+	assert.Equal(t, []string{"azure-0", "trial-0", "gcp-0", "azure-1", "trial-1", "azure-2"}, operationIDs(ordered[:6]))
+}
+
+func operationIDs(ops []orchestration.RuntimeOperation) []string {
+	ids := make([]string, 0, len(ops))
+	for _, op := range ops {
+		ids = append(ids, op.ID)
+	}
+	return ids
+}
+
+type batchExecutor struct {
+	mux      sync.Mutex
+	failing  map[string]bool
+	started  []string
+	finished map[string]time.Time
+	begun    map[string]time.Time
+}
+
+func newBatchExecutor(failing ...string) *batchExecutor {
+	e := &batchExecutor{failing: map[string]bool{}, finished: map[string]time.Time{}, begun: map[string]time.Time{}}
+	for _, id := range failing {
+		e.failing[id] = true
+	}
+	return e
+}
+
+func (e *batchExecutor) Execute(opID string) (time.Duration, error) {
+	e.mux.Lock()
+	e.started = append(e.started, opID)
+	e.begun[opID] = time.Now()
+	e.mux.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	e.finished[opID] = time.Now()
+	if e.failing[opID] {
+		return 0, fmt.Errorf("operation %s failed", opID)
+	}
+	return 0, nil
+}
+
+func TestNewParallelOrchestrationStrategy_Batches(t *testing.T) {
+	ops := make([]orchestration.RuntimeOperation, 6)
+	for i := range ops {
+		ops[i] = orchestration.RuntimeOperation{ID: fmt.Sprintf("op-%d", i)}
+	}
+	spec := func(threshold int) orchestration.StrategySpec {
+		return orchestration.StrategySpec{
+			Schedule: orchestration.Immediate,
+			Parallel: orchestration.ParallelStrategySpec{Workers: 3, BatchSize: 2, FailureThreshold: threshold},
+		}
+	}
+
+	t.Run("should start the next batch when the current one is finished", func(t *testing.T) {
+		// given
+		executor := newBatchExecutor("op-1")
+		s := NewParallelOrchestrationStrategy(executor, logrus.New())
+
+		// when
+		id, err := s.Execute(ops, spec(2))
+		assert.NoError(t, err)
+		s.Wait(id)
+
+		// then
+		assert.Len(t, executor.started, len(ops))
+		for i := 2; i < len(ops); i++ {
+			for j := i - i%2 - 2; j < i-i%2; j++ {
+				assert.False(t, executor.begun[ops[i].ID].Before(executor.finished[ops[j].ID]), "%s started before %s finished", ops[i].ID, ops[j].ID)
+			}
+		}
+	})
+
+	t.Run("should not start the next batches when the failure threshold is reached", func(t *testing.T) {
+		// given
+		executor := newBatchExecutor("op-2", "op-3")
+		s := NewParallelOrchestrationStrategy(executor, logrus.New())
+
+		// when
+		id, err := s.Execute(ops, spec(2))
+		assert.NoError(t, err)
+		s.Wait(id)
+
+		// then
+		assert.ElementsMatch(t, []string{"op-0", "op-1", "op-2", "op-3"}, executor.started)
+	})
+
+	t.Run("should execute all batches without failure threshold", func(t *testing.T) {
+		// given
+		executor := newBatchExecutor("op-0", "op-1")
+		s := NewParallelOrchestrationStrategy(executor, logrus.New())
+
+		// when
+		id, err := s.Execute(ops, spec(0))
+		assert.NoError(t, err)
+		s.Wait(id)
+
+		// then
+		assert.Len(t, executor.started, len(ops))
+	})
+}
+
+func TestSplitIntoBatches(t *testing.T) {
+	ops := make([]orchestration.RuntimeOperation, 5)
+	for i := range ops {
+		ops[i] = orchestration.RuntimeOperation{ID: fmt.Sprintf("op-%d", i)}
+	}
+
+	for name, tc := range map[string]struct {
+		size     int
+		expected [][]string
+	}{
+		"without batch size": {
+			size:     0,
+			expected: [][]string{{"op-0", "op-1", "op-2", "op-3", "op-4"}},
+		},
+		"batch size greater than operations": {
+			size:     10,
+			expected: [][]string{{"op-0", "op-1", "op-2", "op-3", "op-4"}},
+		},
+		"with last batch smaller": {
+			size:     2,
+			expected: [][]string{{"op-0", "op-1"}, {"op-2", "op-3"}, {"op-4"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			batches := splitIntoBatches(ops, tc.size)
+
+			// then
+			ids := make([][]string, 0, len(batches))
+			for _, batch := range batches {
+				ids = append(ids, operationIDs(batch))
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
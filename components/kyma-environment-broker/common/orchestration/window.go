@@ -0,0 +1,132 @@
+package orchestration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const timeWindowClockFormat = "15:04"
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// TimeWindow describes a weekly recurring time range in which operations on a runtime are permitted.
+type TimeWindow struct {
+	// Days on which the window opens, every day if empty
+	Days []time.Weekday `json:"days,omitempty"`
+	// Begin and End are in the "15:04" format. When End is not after Begin, the window ends on the next day, e.g. 22:00-02:00.
+	Begin string `json:"begin"`
+	End   string `json:"end"`
+	// TimeZone is the IANA name of the window's time zone, e.g. Europe/Berlin. UTC is used if empty.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ParseTimeWindow parses a time window from the "[DAY[,DAY...]] BEGIN-END [TIMEZONE]" format, e.g. "Mon,Thu 22:00-02:00 Europe/Berlin"
+func ParseTimeWindow(value string) (TimeWindow, error) {
+	window := TimeWindow{}
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return window, errors.New("time window is empty")
+	}
+
+	if !strings.Contains(fields[0], "-") {
+		for _, day := range strings.Split(fields[0], ",") {
+			weekday, ok := weekdayNames[strings.ToLower(day)]
+			if !ok {
+				return window, fmt.Errorf("invalid weekday %q in time window %q", day, value)
+			}
+			window.Days = append(window.Days, weekday)
+		}
+		fields = fields[1:]
+	}
+	if len(fields) == 0 || len(fields) > 2 {
+		return window, fmt.Errorf("invalid time window %q", value)
+	}
+
+	timeRange := strings.Split(fields[0], "-")
+	if len(timeRange) != 2 {
+		return window, fmt.Errorf("invalid time range %q in time window %q", fields[0], value)
+	}
+	window.Begin, window.End = timeRange[0], timeRange[1]
+	if len(fields) == 2 {
+		window.TimeZone = fields[1]
+	}
+
+	return window, window.Validate()
+}
+
+// Validate checks that the time window can be evaluated
+func (w TimeWindow) Validate() error {
+	for _, day := range w.Days {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("invalid weekday %d", day)
+		}
+	}
+	if _, err := time.Parse(timeWindowClockFormat, w.Begin); err != nil {
+		return errors.Wrapf(err, "invalid time window begin %q", w.Begin)
+	}
+	if _, err := time.Parse(timeWindowClockFormat, w.End); err != nil {
+		return errors.Wrapf(err, "invalid time window end %q", w.End)
+	}
+	if _, err := time.LoadLocation(w.TimeZone); err != nil {
+		return errors.Wrapf(err, "invalid time window time zone %q", w.TimeZone)
+	}
+
+	return nil
+}
+
+// Until returns the duration from the given time until the window opens, or zero if the time is within the window
+func (w TimeWindow) Until(now time.Time) (time.Duration, error) {
+	if err := w.Validate(); err != nil {
+		return 0, err
+	}
+	location, _ := time.LoadLocation(w.TimeZone)
+	begin, _ := time.Parse(timeWindowClockFormat, w.Begin)
+	end, _ := time.Parse(timeWindowClockFormat, w.End)
+	now = now.In(location)
+
+	// Start from the previous day, because its window may last past midnight
+	for offset := -1; offset <= 7; offset++ {
+		day := time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, location)
+		if !w.opensOn(day.Weekday()) {
+			continue
+		}
+		windowBegin := time.Date(day.Year(), day.Month(), day.Day(), begin.Hour(), begin.Minute(), 0, 0, location)
+		windowEnd := time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, location)
+		if !windowEnd.After(windowBegin) {
+			windowEnd = windowEnd.AddDate(0, 0, 1)
+		}
+
+		if !now.Before(windowBegin) && now.Before(windowEnd) {
+			return 0, nil
+		}
+		if windowBegin.After(now) {
+			return windowBegin.Sub(now), nil
+		}
+	}
+
+	return 0, fmt.Errorf("time window %s-%s does not open within a week", w.Begin, w.End)
+}
+
+func (w TimeWindow) opensOn(weekday time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if day == weekday {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+package orchestration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeWindow(t *testing.T) {
+	t.Run("should parse time window", func(t *testing.T) {
+		// when
+		window, err := ParseTimeWindow("Mon,thu 22:00-02:00 Europe/Berlin")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, TimeWindow{
+			Days:     []time.Weekday{time.Monday, time.Thursday},
+			Begin:    "22:00",
+			End:      "02:00",
+			TimeZone: "Europe/Berlin",
+		}, window)
+	})
+
+	t.Run("should parse time window without days and time zone", func(t *testing.T) {
+		// when
+		window, err := ParseTimeWindow("01:00-05:00")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, TimeWindow{Begin: "01:00", End: "05:00"}, window)
+	})
+
+	for name, value := range map[string]string{
+		"empty":             "",
+		"invalid weekday":   "Mon,Xyz 01:00-05:00",
+		"missing range":     "Mon",
+		"invalid range":     "Mon 01:00",
+		"invalid begin":     "Mon 25:00-05:00",
+		"invalid time zone": "Mon 01:00-05:00 Europe/Nowhere",
+		"too many fields":   "Mon 01:00-05:00 UTC extra",
+	} {
+		t.Run("should fail for "+name+" time window", func(t *testing.T) {
+			// when
+			_, err := ParseTimeWindow(value)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTimeWindow_Until(t *testing.T) {
+	// 2020-11-10 is Tuesday
+	for name, tc := range map[string]struct {
+		window   string
+		now      time.Time
+		expected time.Duration
+	}{
+		"inside window": {
+			window:   "Tue 10:00-12:00 Europe/Berlin",
+			now:      time.Date(2020, 11, 10, 9, 30, 0, 0, time.UTC),
+			expected: 0,
+		},
+		"before window on the same day": {
+			window:   "Tue 10:00-12:00 Europe/Berlin",
+			now:      time.Date(2020, 11, 10, 8, 30, 0, 0, time.UTC),
+			expected: 30 * time.Minute,
+		},
+		"after window": {
+			window:   "Tue 10:00-12:00 Europe/Berlin",
+			now:      time.Date(2020, 11, 10, 11, 0, 0, 0, time.UTC),
+			expected: 7*24*time.Hour - 2*time.Hour,
+		},
+		"inside window on the next day in window time zone": {
+			window:   "Wed 00:00-02:00 Europe/Berlin",
+			now:      time.Date(2020, 11, 10, 23, 30, 0, 0, time.UTC),
+			expected: 0,
+		},
+		"before window at midnight in window time zone": {
+			window:   "Wed 00:00-02:00 Europe/Berlin",
+			now:      time.Date(2020, 11, 10, 22, 30, 0, 0, time.UTC),
+			expected: 30 * time.Minute,
+		},
+		"inside window lasting past midnight": {
+			window:   "Mon 22:00-02:00",
+			now:      time.Date(2020, 11, 10, 1, 0, 0, 0, time.UTC),
+			expected: 0,
+		},
+		"after window lasting past midnight": {
+			window:   "Mon 22:00-02:00",
+			now:      time.Date(2020, 11, 10, 3, 0, 0, 0, time.UTC),
+			expected: 6*24*time.Hour + 19*time.Hour,
+		},
+		"daily window": {
+			window:   "22:00-23:00 UTC",
+			now:      time.Date(2020, 11, 10, 23, 30, 0, 0, time.UTC),
+			expected: 22*time.Hour + 30*time.Minute,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			window, err := ParseTimeWindow(tc.window)
+			require.NoError(t, err)
+
+			// when
+			until, err := window.Until(tc.now)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, until)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// Checksum returns the hex encoded SHA-256 checksum of the runtimes.
+// The checksum covers the canonical attributes compared by RuntimeEqual and does not depend on the order of the runtimes.
+func Checksum(runtimes []RuntimeDTO) (string, error) {
+	canonical := make([]canonicalRuntime, 0, len(runtimes))
+	for _, rt := range runtimes {
+		canonical = append(canonical, canonicalize(rt))
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		if canonical[i].InstanceID != canonical[j].InstanceID {
+			return canonical[i].InstanceID < canonical[j].InstanceID
+		}
+		return canonical[i].RuntimeID < canonical[j].RuntimeID
+	})
+
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
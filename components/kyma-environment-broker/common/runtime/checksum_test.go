@@ -0,0 +1,102 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksum(t *testing.T) {
+	// given
+	now := time.Now()
+	fixRuntimes := func() []RuntimeDTO {
+		rt1 := fixRuntimeDTO("runtime1")
+		rt1.Status.CreatedAt = now
+		rt1.Status.ModifiedAt = now
+		rt1.Status.UpgradingKyma = OperationsData{
+			Data: []Operation{
+				{OperationID: "upgrade1", State: "succeeded", CreatedAt: now.Add(-time.Hour)},
+				{OperationID: "upgrade2", State: "in progress", CreatedAt: now},
+			},
+			Count:      2,
+			TotalCount: 2,
+		}
+		rt2 := fixRuntimeDTO("runtime2")
+		rt2.Status.CreatedAt = now
+		rt2.Status.ModifiedAt = now
+		return []RuntimeDTO{rt1, rt2}
+	}
+	expected, err := Checksum(fixRuntimes())
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		modify      func(runtimes []RuntimeDTO) []RuntimeDTO
+		sameAsFirst bool
+	}{
+		"same runtimes": {
+			modify:      func(runtimes []RuntimeDTO) []RuntimeDTO { return runtimes },
+			sameAsFirst: true,
+		},
+		"reordered runtimes": {
+			modify: func(runtimes []RuntimeDTO) []RuntimeDTO {
+				return []RuntimeDTO{runtimes[1], runtimes[0]}
+			},
+			sameAsFirst: true,
+		},
+		"reordered operations": {
+			modify: func(runtimes []RuntimeDTO) []RuntimeDTO {
+				ops := runtimes[0].Status.UpgradingKyma.Data
+				runtimes[0].Status.UpgradingKyma.Data = []Operation{ops[1], ops[0]}
+				return runtimes
+			},
+			sameAsFirst: true,
+		},
+		"same time in another location": {
+			modify: func(runtimes []RuntimeDTO) []RuntimeDTO {
+				runtimes[0].Status.CreatedAt = runtimes[0].Status.CreatedAt.In(time.FixedZone("CET", 3600))
+				return runtimes
+			},
+			sameAsFirst: true,
+		},
+		"different volatile fields": {
+			modify: func(runtimes []RuntimeDTO) []RuntimeDTO {
+				runtimes[0].KymaVersion = "1.20.0"
+				runtimes[0].Status.Provisioning.UpdatedAt = now.Add(time.Minute)
+				return runtimes
+			},
+			sameAsFirst: true,
+		},
+		"different plan": {
+			modify: func(runtimes []RuntimeDTO) []RuntimeDTO {
+				runtimes[1].ServicePlanName = "trial"
+				return runtimes
+			},
+			sameAsFirst: false,
+		},
+		"different operation state": {
+			modify: func(runtimes []RuntimeDTO) []RuntimeDTO {
+				runtimes[0].Status.UpgradingKyma.Data[1].State = "succeeded"
+				return runtimes
+			},
+			sameAsFirst: false,
+		},
+		"missing runtime": {
+			modify: func(runtimes []RuntimeDTO) []RuntimeDTO {
+				return runtimes[:1]
+			},
+			sameAsFirst: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			checksum, err := Checksum(tc.modify(fixRuntimes()))
+
+			// then
+			require.NoError(t, err)
+			assert.Len(t, checksum, 64)
+			assert.Equal(t, tc.sameAsFirst, checksum == expected)
+		})
+	}
+}
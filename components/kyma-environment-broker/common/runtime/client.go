@@ -6,41 +6,117 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
+	"time"
 
-	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
 )
 
 const defaultPageSize = 100
 
+const (
+	// DefaultTimeout is the time limit of a single HTTP request to KEB
+	DefaultTimeout = 30 * time.Second
+	// DefaultAttempts is the number of attempts to fetch a page of runtimes before ListRuntimes fails on transient errors
+	DefaultAttempts = 3
+	// DefaultBackoff is the wait time after the first failed attempt, which doubles after each subsequent failure
+	DefaultBackoff = time.Second
+)
+
 // Client is the interface to interact with the KEB /runtimes API as an HTTP client using OIDC ID token in JWT format.
 type Client interface {
 	ListRuntimes(params ListParameters) (RuntimesPage, error)
+	GetAccountQuota(globalAccountID string) (AccountQuotaDTO, error)
+	ListFailureGroups(limit int) ([]FailureGroupDTO, error)
+	GetSLAReport() ([]SLAReportDTO, error)
+	ListRuntimeOperations(runtimeID string) ([]Operation, error)
+	GetOperation(operationID string) (Operation, error)
+	ListOperationArtifacts(operationID string) ([]ArtifactDTO, error)
+	GetOperationArtifact(operationID, name string) ([]byte, error)
 }
 
 type client struct {
 	url        string
 	httpClient *http.Client
+	attempts   int
+	backoff    time.Duration
+	limiter    *rate.Limiter
+	log        logrus.FieldLogger
+	pages      map[string]cachedPage
+	pagesMux   sync.Mutex
+}
+
+// cachedPage is the page of runtimes fetched with the query, stored together with its ETag to send it in the If-None-Match header of the next request
+type cachedPage struct {
+	etag string
+	page RuntimesPage
+}
+
+// ClientOptions holds the optional settings of the Client
+type ClientOptions struct {
+	// Timeout is the time limit of a single HTTP request, zero means no timeout
+	Timeout time.Duration
+	// QPS is the maximum number of requests per second fetching the runtimes, zero means no limit
+	QPS float64
+	// Log receives the debug messages of the client, e.g. about the requests delayed by the QPS limit
+	Log logrus.FieldLogger
 }
 
-// NewClient constructs and returns new Client for KEB /runtimes API
+// DefaultClientOptions returns the options of the Client constructed with NewClient
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		Timeout: DefaultTimeout,
+	}
+}
+
+// NewClient constructs and returns new Client for KEB /runtimes API with the DefaultClientOptions
 // It takes the following arguments:
 //   - ctx  : context in which the http request will be executed
 //   - url  : base url of all KEB APIs, e.g. https://kyma-env-broker.kyma.local
 //   - auth : TokenSource object which provides the ID token for the HTTP request
 func NewClient(ctx context.Context, url string, auth oauth2.TokenSource) Client {
-	return &client{
+	return NewClientWithOptions(ctx, url, auth, DefaultClientOptions())
+}
+
+// NewClientWithOptions constructs and returns new Client for KEB /runtimes API with the given timeout of the HTTP requests and QPS limit of fetching the runtimes.
+// The limit is a token bucket with the burst of one request, so the requests are evenly paced.
+func NewClientWithOptions(ctx context.Context, url string, auth oauth2.TokenSource, opts ClientOptions) Client {
+	httpClient := oauth2.NewClient(ctx, auth)
+	httpClient.Timeout = opts.Timeout
+	c := &client{
 		url:        url,
-		httpClient: oauth2.NewClient(ctx, auth),
+		httpClient: httpClient,
+		attempts:   DefaultAttempts,
+		backoff:    DefaultBackoff,
+		log:        opts.Log,
+		pages:      make(map[string]cachedPage),
+	}
+	if opts.QPS > 0 {
+		c.limiter = rate.NewLimiter(rate.Limit(opts.QPS), 1)
+	}
+	if c.log == nil {
+		log := logrus.New()
+		log.Out = ioutil.Discard
+		c.log = log
 	}
+	return c
 }
 
 // ListRuntimes fetches the runtimes from KEB according to the given parameters.
 // If params.Page or params.PageSize is not set (zero), the client will fetch and return all runtimes.
+// When fetching all runtimes, a non-zero params.PageSize is used as the size of each fetched page,
+// and a non-zero params.MaxResults stops the fetching as soon as the given number of runtimes is collected.
+// The request of a page which fails with a network error or a 5xx status is retried with exponential backoff.
+// Each request of a page, including the retries, waits for the QPS limit of the client.
+// The pages are fetched with the ETag of their previous version, and if KEB reports that none of them changed,
+// the previous versions are returned with the NotModified set.
 func (c *client) ListRuntimes(params ListParameters) (RuntimesPage, error) {
 	runtimes := RuntimesPage{}
 	getAll := false
@@ -48,43 +124,18 @@ func (c *client) ListRuntimes(params ListParameters) (RuntimesPage, error) {
 	if params.Page == 0 || params.PageSize == 0 {
 		getAll = true
 		params.Page = 1
-		params.PageSize = defaultPageSize
+		if params.PageSize == 0 {
+			params.PageSize = defaultPageSize
+		}
 	}
 
+	notModified := true
 	for !fetchedAll {
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s/runtimes", c.url), nil)
-		if err != nil {
-			return runtimes, errors.Wrap(err, "while creating request")
-		}
-		setQuery(req.URL, params)
-
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return runtimes, errors.Wrapf(err, "while calling %s", req.URL.String())
-		}
-
-		// Drain response body and close, return error to context if there isn't any.
-		defer func() {
-			derr := drainResponseBody(resp.Body)
-			if err == nil {
-				err = derr
-			}
-			cerr := resp.Body.Close()
-			if err == nil {
-				err = cerr
-			}
-		}()
-
-		if resp.StatusCode != http.StatusOK {
-			return runtimes, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
-		}
-
-		var rp RuntimesPage
-		decoder := json.NewDecoder(resp.Body)
-		err = decoder.Decode(&rp)
+		rp, err := c.fetchRuntimesPageWithRetry(params)
 		if err != nil {
-			return runtimes, errors.Wrap(err, "while decoding response body")
+			return runtimes, err
 		}
+		notModified = notModified && rp.NotModified
 
 		runtimes.TotalCount = rp.TotalCount
 		runtimes.Count += rp.Count
@@ -92,32 +143,387 @@ func (c *client) ListRuntimes(params ListParameters) (RuntimesPage, error) {
 		if getAll {
 			params.Page++
 			fetchedAll = runtimes.Count >= runtimes.TotalCount
+			if params.MaxResults > 0 && len(runtimes.Data) >= params.MaxResults {
+				runtimes.Data = runtimes.Data[:params.MaxResults]
+				runtimes.Count = params.MaxResults
+				fetchedAll = true
+			}
 		} else {
 			fetchedAll = true
 		}
 	}
+	runtimes.NotModified = notModified
 
 	return runtimes, nil
 }
 
-func setQuery(url *url.URL, params ListParameters) {
-	query := url.Query()
-	query.Add(pagination.PageParam, strconv.Itoa(params.Page))
-	query.Add(pagination.PageSizeParam, strconv.Itoa(params.PageSize))
-	setParamList(query, GlobalAccountIDParam, params.GlobalAccountIDs)
-	setParamList(query, SubAccountIDParam, params.SubAccountIDs)
-	setParamList(query, InstanceIDParam, params.InstanceIDs)
-	setParamList(query, RuntimeIDParam, params.RuntimeIDs)
-	setParamList(query, RegionParam, params.Regions)
-	setParamList(query, ShootParam, params.Shoots)
-	setParamList(query, PlanParam, params.Plans)
-	url.RawQuery = query.Encode()
+// fetchRuntimesPageWithRetry fetches a page of runtimes, retrying the transient failures until the attempts are exhausted
+func (c *client) fetchRuntimesPageWithRetry(params ListParameters) (RuntimesPage, error) {
+	wait := c.backoff
+	var err error
+	for attempt := 1; attempt <= c.attempts; attempt++ {
+		var rp RuntimesPage
+		var transient bool
+		c.waitForRateLimit()
+		rp, transient, err = c.fetchRuntimesPage(params)
+		if err == nil {
+			return rp, nil
+		}
+		if !transient {
+			return RuntimesPage{}, err
+		}
+		if attempt < c.attempts {
+			time.Sleep(wait)
+			wait *= 2
+		}
+	}
+
+	return RuntimesPage{}, errors.Wrapf(err, "while fetching runtimes, all %d attempts failed", c.attempts)
 }
 
-func setParamList(query url.Values, key string, values []string) {
-	for _, value := range values {
-		query.Add(key, value)
+// waitForRateLimit blocks until the QPS limit of the client allows the next request
+func (c *client) waitForRateLimit() {
+	if c.limiter == nil {
+		return
+	}
+	delay := c.limiter.Reserve().Delay()
+	if delay > 0 {
+		c.log.Debugf("request to %s/runtimes delayed by %v due to the limit of %v requests per second", c.url, delay, c.limiter.Limit())
+		time.Sleep(delay)
+	}
+}
+
+// fetchRuntimesPage fetches a single page of runtimes, and reports whether the failure is transient, i.e. a network error or a 5xx status
+func (c *client) fetchRuntimesPage(params ListParameters) (rp RuntimesPage, transient bool, err error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/runtimes", c.url), nil)
+	if err != nil {
+		return rp, false, errors.Wrap(err, "while creating request")
+	}
+	req.URL.RawQuery = params.EncodeQuery().Encode()
+	cached, found := c.cachedPage(req.URL.RawQuery)
+	if found {
+		req.Header.Set("If-None-Match", cached.etag)
 	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return rp, isNetworkError(err), errors.Wrapf(err, "while calling %s", req.URL.String())
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotModified && found {
+		rp = cached.page
+		rp.NotModified = true
+		return rp, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return rp, resp.StatusCode >= http.StatusInternalServerError, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&rp)
+	if err != nil {
+		return rp, false, errors.Wrap(err, "while decoding response body")
+	}
+	c.storePage(req.URL.RawQuery, resp.Header.Get("ETag"), rp)
+
+	return rp, false, nil
+}
+
+func (c *client) cachedPage(query string) (cachedPage, bool) {
+	c.pagesMux.Lock()
+	defer c.pagesMux.Unlock()
+	cached, found := c.pages[query]
+	return cached, found
+}
+
+// storePage stores the page fetched with the query for the next requests, the pages without the ETag are not stored
+func (c *client) storePage(query, etag string, page RuntimesPage) {
+	c.pagesMux.Lock()
+	defer c.pagesMux.Unlock()
+	if etag == "" {
+		delete(c.pages, query)
+		return
+	}
+	c.pages[query] = cachedPage{etag: etag, page: page}
+}
+
+// isNetworkError returns true if the request failed on the connection or timed out, and not e.g. on obtaining the token
+func isNetworkError(err error) bool {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(urlErr.Err, &netErr)
+}
+
+// GetAccountQuota fetches the number of runtimes per service plan of the given global account, together with the plan limits
+func (c *client) GetAccountQuota(globalAccountID string) (quota AccountQuotaDTO, err error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/quotas/%s", c.url, url.PathEscape(globalAccountID)), nil)
+	if err != nil {
+		return quota, errors.Wrap(err, "while creating request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return quota, errors.Wrapf(err, "while calling %s", req.URL.String())
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return quota, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&quota)
+	if err != nil {
+		return quota, errors.Wrap(err, "while decoding response body")
+	}
+
+	return quota, nil
+}
+
+// ListFailureGroups fetches the failed operations grouped by normalized description, the most frequent first.
+// A zero limit returns all groups.
+func (c *client) ListFailureGroups(limit int) (groups []FailureGroupDTO, err error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/failures", c.url), nil)
+	if err != nil {
+		return groups, errors.Wrap(err, "while creating request")
+	}
+	if limit > 0 {
+		query := req.URL.Query()
+		query.Add(LimitParam, strconv.Itoa(limit))
+		req.URL.RawQuery = query.Encode()
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return groups, errors.Wrapf(err, "while calling %s", req.URL.String())
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return groups, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&groups)
+	if err != nil {
+		return groups, errors.Wrap(err, "while decoding response body")
+	}
+
+	return groups, nil
+}
+
+// GetSLAReport fetches the number of completed operations and the number of operations which breached the SLA per operation type.
+func (c *client) GetSLAReport() (report []SLAReportDTO, err error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/sla", c.url), nil)
+	if err != nil {
+		return report, errors.Wrap(err, "while creating request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return report, errors.Wrapf(err, "while calling %s", req.URL.String())
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return report, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&report)
+	if err != nil {
+		return report, errors.Wrap(err, "while decoding response body")
+	}
+
+	return report, nil
+}
+
+// ListRuntimeOperations fetches all operations of the runtime with a given ID in chronological order.
+func (c *client) ListRuntimeOperations(runtimeID string) (operations []Operation, err error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/runtimes/%s/operations", c.url, url.PathEscape(runtimeID)), nil)
+	if err != nil {
+		return operations, errors.Wrap(err, "while creating request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return operations, errors.Wrapf(err, "while calling %s", req.URL.String())
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return operations, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&operations)
+	if err != nil {
+		return operations, errors.Wrap(err, "while decoding response body")
+	}
+
+	return operations, nil
+}
+
+// GetOperation fetches the operation with a given ID.
+func (c *client) GetOperation(operationID string) (operation Operation, err error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/operations/%s", c.url, url.PathEscape(operationID)), nil)
+	if err != nil {
+		return operation, errors.Wrap(err, "while creating request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return operation, errors.Wrapf(err, "while calling %s", req.URL.String())
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return operation, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&operation)
+	if err != nil {
+		return operation, errors.Wrap(err, "while decoding response body")
+	}
+
+	return operation, nil
+}
+
+// ListOperationArtifacts fetches the artifacts attached to the operation with a given ID, sorted by name.
+func (c *client) ListOperationArtifacts(operationID string) (artifacts []ArtifactDTO, err error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/operations/%s/artifacts", c.url, url.PathEscape(operationID)), nil)
+	if err != nil {
+		return artifacts, errors.Wrap(err, "while creating request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return artifacts, errors.Wrapf(err, "while calling %s", req.URL.String())
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return artifacts, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&artifacts)
+	if err != nil {
+		return artifacts, errors.Wrap(err, "while decoding response body")
+	}
+
+	return artifacts, nil
+}
+
+// GetOperationArtifact fetches the content of the artifact with a given name attached to the operation.
+func (c *client) GetOperationArtifact(operationID, name string) (data []byte, err error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/operations/%s/artifacts/%s", c.url, url.PathEscape(operationID), url.PathEscape(name)), nil)
+	if err != nil {
+		return data, errors.Wrap(err, "while creating request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return data, errors.Wrapf(err, "while calling %s", req.URL.String())
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return data, fmt.Errorf("calling %s returned %d (%s) status", req.URL.String(), resp.StatusCode, resp.Status)
+	}
+
+	data, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return data, errors.Wrap(err, "while reading response body")
+	}
+
+	return data, nil
 }
 
 func drainResponseBody(body io.Reader) error {
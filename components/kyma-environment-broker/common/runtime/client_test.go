@@ -1,16 +1,20 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
@@ -105,6 +109,461 @@ func TestClient_ListRuntimes(t *testing.T) {
 		assert.Equal(t, 4, rp.TotalCount)
 		assert.Len(t, rp.Data, 4)
 	})
+
+	t.Run("test max results", func(t *testing.T) {
+		called := 0
+		params := ListParameters{
+			PageSize:   2,
+			MaxResults: 3,
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			assert.ElementsMatch(t, []string{strconv.Itoa(params.PageSize)}, r.URL.Query()[pagination.PageSizeParam])
+
+			err := respondRuntimes(w, []RuntimeDTO{runtime1, runtime2}, 6)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		//when
+		rp, err := client.ListRuntimes(params)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 2, called)
+		assert.Equal(t, 3, rp.Count)
+		assert.Equal(t, 6, rp.TotalCount)
+		assert.Len(t, rp.Data, 3)
+	})
+}
+
+func TestClient_ListRuntimesRetry(t *testing.T) {
+	t.Run("test transient error is retried", func(t *testing.T) {
+		//given
+		called := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			if called == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			err := respondRuntimes(w, []RuntimeDTO{runtime1, runtime2}, 2)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := fixRetryingClient(ts.URL, DefaultTimeout)
+
+		//when
+		rp, err := client.ListRuntimes(ListParameters{})
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 2, called)
+		assert.Equal(t, 2, rp.Count)
+		assert.Len(t, rp.Data, 2)
+	})
+
+	t.Run("test error when all attempts fail", func(t *testing.T) {
+		//given
+		called := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+		client := fixRetryingClient(ts.URL, DefaultTimeout)
+
+		//when
+		_, err := client.ListRuntimes(ListParameters{})
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, DefaultAttempts, called)
+		assert.Contains(t, err.Error(), fmt.Sprintf("all %d attempts failed", DefaultAttempts))
+		assert.Contains(t, err.Error(), "returned 503")
+	})
+
+	t.Run("test client error is not retried", func(t *testing.T) {
+		//given
+		called := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer ts.Close()
+		client := fixRetryingClient(ts.URL, DefaultTimeout)
+
+		//when
+		_, err := client.ListRuntimes(ListParameters{})
+
+		//then
+		require.Error(t, err)
+		assert.Equal(t, 1, called)
+		assert.NotContains(t, err.Error(), "attempts failed")
+	})
+
+	t.Run("test hung request times out", func(t *testing.T) {
+		//given
+		var called int32
+		release := make(chan struct{})
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&called, 1)
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer ts.Close()
+		defer close(release)
+		client := fixRetryingClient(ts.URL, 10*time.Millisecond)
+
+		//when
+		_, err := client.ListRuntimes(ListParameters{})
+
+		//then
+		require.Error(t, err)
+		assert.EqualValues(t, DefaultAttempts, atomic.LoadInt32(&called))
+		assert.Contains(t, err.Error(), "Timeout")
+	})
+}
+
+func TestClient_ListRuntimesRateLimit(t *testing.T) {
+	t.Run("test requests are paced by the QPS limit", func(t *testing.T) {
+		//given
+		called := 0
+		var times []time.Time
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			times = append(times, time.Now())
+
+			err := respondRuntimes(w, []RuntimeDTO{runtime1}, 4)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		log := logrus.New()
+		buf := &bytes.Buffer{}
+		log.Out = buf
+		log.Level = logrus.DebugLevel
+		client := NewClientWithOptions(context.TODO(), ts.URL, fixToken, ClientOptions{QPS: 20, Log: log})
+
+		//when
+		rp, err := client.ListRuntimes(ListParameters{PageSize: 1})
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 4, called)
+		assert.Len(t, rp.Data, 4)
+		for i := 1; i < len(times); i++ {
+			assert.True(t, times[i].Sub(times[i-1]) >= 40*time.Millisecond, "request %d sent %v after the previous one", i+1, times[i].Sub(times[i-1]))
+		}
+		assert.Equal(t, 3, strings.Count(buf.String(), "due to the limit of 20 requests per second"))
+	})
+
+	t.Run("test requests are not delayed without the QPS limit", func(t *testing.T) {
+		//given
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := respondRuntimes(w, []RuntimeDTO{runtime1}, 4)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+		start := time.Now()
+
+		//when
+		rp, err := client.ListRuntimes(ListParameters{PageSize: 1})
+
+		//then
+		require.NoError(t, err)
+		assert.Len(t, rp.Data, 4)
+		assert.True(t, time.Since(start) < 150*time.Millisecond)
+	})
+}
+
+func TestClient_ListRuntimesNotModified(t *testing.T) {
+	t.Run("test not modified page is returned from the previous response", func(t *testing.T) {
+		//given
+		called := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			switch called {
+			case 1:
+				assert.Empty(t, r.Header.Get("If-None-Match"))
+				w.Header().Set("ETag", `"v1"`)
+				err := respondRuntimes(w, []RuntimeDTO{runtime1, runtime2}, 2)
+				require.NoError(t, err)
+			case 2:
+				assert.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+				w.WriteHeader(http.StatusNotModified)
+			default:
+				assert.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+				w.Header().Set("ETag", `"v2"`)
+				err := respondRuntimes(w, []RuntimeDTO{runtime3}, 1)
+				require.NoError(t, err)
+			}
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		//when
+		first, err := client.ListRuntimes(ListParameters{})
+		require.NoError(t, err)
+		second, err := client.ListRuntimes(ListParameters{})
+		require.NoError(t, err)
+		third, err := client.ListRuntimes(ListParameters{})
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, 3, called)
+		assert.False(t, first.NotModified)
+		assert.True(t, second.NotModified)
+		assert.Equal(t, first.Data, second.Data)
+		assert.Equal(t, 2, second.Count)
+		assert.Equal(t, 2, second.TotalCount)
+		assert.False(t, third.NotModified)
+		require.Len(t, third.Data, 1)
+		assert.Equal(t, runtime3.InstanceID, third.Data[0].InstanceID)
+	})
+
+	t.Run("test runtimes are modified if any page changed", func(t *testing.T) {
+		//given
+		called := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			page := r.URL.Query().Get(pagination.PageParam)
+			if r.Header.Get("If-None-Match") != "" && page == "1" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", fmt.Sprintf(`"page-%s-%d"`, page, called))
+			err := respondRuntimes(w, []RuntimeDTO{fixRuntimeDTO(page)}, 2)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+		_, err := client.ListRuntimes(ListParameters{PageSize: 1})
+		require.NoError(t, err)
+
+		//when
+		rp, err := client.ListRuntimes(ListParameters{PageSize: 1})
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 4, called)
+		assert.False(t, rp.NotModified)
+		assert.Len(t, rp.Data, 2)
+	})
+
+	t.Run("test page without etag is not cached", func(t *testing.T) {
+		//given
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("If-None-Match"))
+			err := respondRuntimes(w, []RuntimeDTO{runtime1}, 1)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+		_, err := client.ListRuntimes(ListParameters{})
+		require.NoError(t, err)
+
+		//when
+		rp, err := client.ListRuntimes(ListParameters{})
+
+		//then
+		require.NoError(t, err)
+		assert.False(t, rp.NotModified)
+		assert.Len(t, rp.Data, 1)
+	})
+}
+
+func TestClient_GetAccountQuota(t *testing.T) {
+	t.Run("test request URL and response are correct", func(t *testing.T) {
+		// given
+		quota := AccountQuotaDTO{
+			GlobalAccountID: "ga1",
+			Plans: []PlanQuotaDTO{
+				{ServicePlanID: "trial-id", ServicePlanName: "trial", Used: 1, Limit: 1},
+				{ServicePlanID: "azure-id", ServicePlanName: "azure", Used: 3},
+			},
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/quotas/ga1", r.URL.Path)
+			assert.Equal(t, r.Header.Get("Authorization"), fmt.Sprintf("Bearer %s", fixToken))
+
+			w.Header().Set("Content-Type", "application/json")
+			err := json.NewEncoder(w).Encode(quota)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		got, err := client.GetAccountQuota("ga1")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, quota, got)
+	})
+
+	t.Run("test error status", func(t *testing.T) {
+		// given
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		_, err := client.GetAccountQuota("ga1")
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_ListFailureGroups(t *testing.T) {
+	// given
+	groups := []FailureGroupDTO{
+		{Message: "provisioner operation <id> failed", Count: 3, RuntimeIDs: []string{"rid1", "rid2"}},
+		{Message: "timeout", Count: 1, RuntimeIDs: []string{}},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/failures", r.URL.Path)
+		assert.Equal(t, "5", r.URL.Query().Get(LimitParam))
+		assert.Equal(t, r.Header.Get("Authorization"), fmt.Sprintf("Bearer %s", fixToken))
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(groups)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	got, err := client.ListFailureGroups(5)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, groups, got)
+}
+
+func TestClient_GetSLAReport(t *testing.T) {
+	// given
+	report := []SLAReportDTO{
+		{Type: "provision", Total: 10, Breached: 2},
+		{Type: "upgradeKyma", Total: 4, Breached: 0},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/sla", r.URL.Path)
+		assert.Equal(t, r.Header.Get("Authorization"), fmt.Sprintf("Bearer %s", fixToken))
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(report)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	got, err := client.GetSLAReport()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, report, got)
+}
+
+func TestClient_ListRuntimeOperations(t *testing.T) {
+	// given
+	operations := []Operation{
+		{OperationID: "op-1", Type: OperationTypeProvision, State: "succeeded"},
+		{OperationID: "op-2", Type: OperationTypeUpgradeKyma, State: "failed", Description: "upgrade failed"},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/runtimes/runtime-1/operations", r.URL.Path)
+		assert.Equal(t, r.Header.Get("Authorization"), fmt.Sprintf("Bearer %s", fixToken))
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(operations)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	got, err := client.ListRuntimeOperations("runtime-1")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, operations, got)
+}
+
+func TestClient_ListOperationArtifacts(t *testing.T) {
+	// given
+	artifacts := []ArtifactDTO{
+		{OperationID: "op-1", Name: "Create_Runtime.log", Size: 128, CreatedAt: time.Date(2020, 11, 10, 9, 8, 7, 0, time.UTC)},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/operations/op-1/artifacts", r.URL.Path)
+		assert.Equal(t, r.Header.Get("Authorization"), fmt.Sprintf("Bearer %s", fixToken))
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(artifacts)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	got, err := client.ListOperationArtifacts("op-1")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, artifacts, got)
+}
+
+func TestClient_GetOperationArtifact(t *testing.T) {
+	t.Run("should return artifact content", func(t *testing.T) {
+		// given
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/operations/op-1/artifacts/Create_Runtime.log", r.URL.Path)
+			assert.Equal(t, r.Header.Get("Authorization"), fmt.Sprintf("Bearer %s", fixToken))
+
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, err := w.Write([]byte("step failed"))
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		got, err := client.GetOperationArtifact("op-1", "Create_Runtime.log")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "step failed", string(got))
+	})
+
+	t.Run("should return error when artifact is not found", func(t *testing.T) {
+		// given
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		_, err := client.GetOperationArtifact("op-1", "Create_Runtime.log")
+
+		// then
+		assert.Error(t, err)
+	})
 }
 
 func fixRuntimeDTO(id string) RuntimeDTO {
@@ -148,3 +607,9 @@ func respondRuntimes(w http.ResponseWriter, runtimes []RuntimeDTO, totalCount in
 	_, err = w.Write(data)
 	return err
 }
+
+func fixRetryingClient(url string, timeout time.Duration) Client {
+	c := NewClientWithOptions(context.TODO(), url, fixToken, ClientOptions{Timeout: timeout}).(*client)
+	c.backoff = time.Millisecond
+	return c
+}
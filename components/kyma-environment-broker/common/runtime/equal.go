@@ -0,0 +1,107 @@
+package runtime
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// RuntimeEqual reports whether both runtimes have the same canonical attributes and the same state of all operations
+func RuntimeEqual(a, b RuntimeDTO) bool {
+	return reflect.DeepEqual(canonicalize(a), canonicalize(b))
+}
+
+// canonicalRuntime holds the attributes of a runtime compared by RuntimeEqual and covered by Checksum, the remaining attributes are considered volatile.
+// The times are in UTC and the operations are sorted, so the location of the times and the order of the operations do not matter.
+type canonicalRuntime struct {
+	InstanceID       string
+	RuntimeID        string
+	GlobalAccountID  string
+	SubAccountID     string
+	ProviderRegion   string
+	SubAccountRegion string
+	ShootName        string
+	ServiceClassID   string
+	ServiceClassName string
+	ServicePlanID    string
+	ServicePlanName  string
+	UserID           string
+	CreatedAt        time.Time
+	ModifiedAt       time.Time
+	Provisioning     *canonicalOperation
+	Deprovisioning   *canonicalOperation
+	UpgradingKyma    canonicalOperations
+	Suspension       canonicalOperations
+	Unsuspension     canonicalOperations
+}
+
+type canonicalOperations struct {
+	Data       []canonicalOperation
+	TotalCount int
+	Count      int
+}
+
+type canonicalOperation struct {
+	State           string
+	Description     string
+	CreatedAt       time.Time
+	OperationID     string
+	OrchestrationID string
+}
+
+func canonicalize(rt RuntimeDTO) canonicalRuntime {
+	return canonicalRuntime{
+		InstanceID:       rt.InstanceID,
+		RuntimeID:        rt.RuntimeID,
+		GlobalAccountID:  rt.GlobalAccountID,
+		SubAccountID:     rt.SubAccountID,
+		ProviderRegion:   rt.ProviderRegion,
+		SubAccountRegion: rt.SubAccountRegion,
+		ShootName:        rt.ShootName,
+		ServiceClassID:   rt.ServiceClassID,
+		ServiceClassName: rt.ServiceClassName,
+		ServicePlanID:    rt.ServicePlanID,
+		ServicePlanName:  rt.ServicePlanName,
+		UserID:           rt.UserID,
+		CreatedAt:        rt.Status.CreatedAt.UTC(),
+		ModifiedAt:       rt.Status.ModifiedAt.UTC(),
+		Provisioning:     canonicalizeOperationPtr(rt.Status.Provisioning),
+		Deprovisioning:   canonicalizeOperationPtr(rt.Status.Deprovisioning),
+		UpgradingKyma:    canonicalizeOperations(rt.Status.UpgradingKyma),
+		Suspension:       canonicalizeOperations(rt.Status.Suspension),
+		Unsuspension:     canonicalizeOperations(rt.Status.Unsuspension),
+	}
+}
+
+func canonicalizeOperations(ops OperationsData) canonicalOperations {
+	data := make([]canonicalOperation, 0, len(ops.Data))
+	for _, op := range ops.Data {
+		data = append(data, canonicalizeOperation(op))
+	}
+	sort.Slice(data, func(i, j int) bool {
+		if !data[i].CreatedAt.Equal(data[j].CreatedAt) {
+			return data[i].CreatedAt.Before(data[j].CreatedAt)
+		}
+		return data[i].OperationID < data[j].OperationID
+	})
+
+	return canonicalOperations{Data: data, TotalCount: ops.TotalCount, Count: ops.Count}
+}
+
+func canonicalizeOperationPtr(op *Operation) *canonicalOperation {
+	if op == nil {
+		return nil
+	}
+	canonical := canonicalizeOperation(*op)
+	return &canonical
+}
+
+func canonicalizeOperation(op Operation) canonicalOperation {
+	return canonicalOperation{
+		State:           op.State,
+		Description:     op.Description,
+		CreatedAt:       op.CreatedAt.UTC(),
+		OperationID:     op.OperationID,
+		OrchestrationID: op.OrchestrationID,
+	}
+}
@@ -0,0 +1,64 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeEqual(t *testing.T) {
+	for name, tc := range map[string]struct {
+		modify   func(rt *RuntimeDTO)
+		expected bool
+	}{
+		"same runtime": {
+			modify:   func(rt *RuntimeDTO) {},
+			expected: true,
+		},
+		"same time in another location": {
+			modify:   func(rt *RuntimeDTO) { rt.Status.CreatedAt = rt.Status.CreatedAt.In(time.FixedZone("CET", 3600)) },
+			expected: true,
+		},
+		"different plan": {
+			modify:   func(rt *RuntimeDTO) { rt.ServicePlanName = "trial" },
+			expected: false,
+		},
+		"different provisioning state": {
+			modify:   func(rt *RuntimeDTO) { rt.Status.Provisioning = &Operation{State: "succeeded"} },
+			expected: false,
+		},
+		"deprovisioning started": {
+			modify:   func(rt *RuntimeDTO) { rt.Status.Deprovisioning = &Operation{State: "in progress"} },
+			expected: false,
+		},
+		"operations in another order": {
+			modify: func(rt *RuntimeDTO) {
+				rt.Status.UpgradingKyma = OperationsData{Data: []Operation{{OperationID: "op2"}, {OperationID: "op1"}}, Count: 2, TotalCount: 2}
+			},
+			expected: true,
+		},
+		"new upgrade operation": {
+			modify: func(rt *RuntimeDTO) {
+				rt.Status.UpgradingKyma = OperationsData{Data: []Operation{{State: "in progress"}}, Count: 1, TotalCount: 1}
+			},
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			a := fixRuntimeDTO("runtime1")
+			a.Status.UpgradingKyma = OperationsData{Data: []Operation{{OperationID: "op1"}, {OperationID: "op2"}}, Count: 2, TotalCount: 2}
+			b := fixRuntimeDTO("runtime1")
+			b.Status = a.Status
+			b.Status.Provisioning = &Operation{State: a.Status.Provisioning.State, Description: a.Status.Provisioning.Description}
+			tc.modify(&b)
+
+			// when
+			equal := RuntimeEqual(a, b)
+
+			// then
+			assert.Equal(t, tc.expected, equal)
+		})
+	}
+}
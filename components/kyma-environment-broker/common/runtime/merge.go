@@ -0,0 +1,31 @@
+package runtime
+
+// MergePages combines runtime pages fetched from several sources into a single page.
+// A runtime present in more than one page is returned once, in the position of its first occurrence,
+// and it is counted only once in the total count. The runtimes are identified by the instance ID,
+// the runtimes without it are never merged.
+func MergePages(pages ...RuntimesPage) RuntimesPage {
+	merged := RuntimesPage{Data: []RuntimeDTO{}}
+	seen := make(map[string]struct{})
+	duplicates := 0
+	for _, page := range pages {
+		merged.TotalCount += page.TotalCount
+		for _, runtime := range page.Data {
+			if runtime.InstanceID != "" {
+				if _, found := seen[runtime.InstanceID]; found {
+					duplicates++
+					continue
+				}
+				seen[runtime.InstanceID] = struct{}{}
+			}
+			merged.Data = append(merged.Data, runtime)
+		}
+	}
+	merged.Count = len(merged.Data)
+	merged.TotalCount -= duplicates
+	if merged.TotalCount < merged.Count {
+		merged.TotalCount = merged.Count
+	}
+
+	return merged
+}
@@ -0,0 +1,111 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePages(t *testing.T) {
+	t.Run("should merge overlapping pages", func(t *testing.T) {
+		// given
+		first := RuntimesPage{
+			Data:       []RuntimeDTO{fixRuntimeDTO("runtime1"), fixRuntimeDTO("runtime2")},
+			Count:      2,
+			TotalCount: 2,
+		}
+		second := RuntimesPage{
+			Data:       []RuntimeDTO{fixRuntimeDTO("runtime2"), fixRuntimeDTO("runtime3")},
+			Count:      2,
+			TotalCount: 2,
+		}
+
+		// when
+		merged := MergePages(first, second)
+
+		// then
+		assert.Equal(t, 3, merged.Count)
+		assert.Equal(t, 3, merged.TotalCount)
+		var ids []string
+		for _, runtime := range merged.Data {
+			ids = append(ids, runtime.RuntimeID)
+		}
+		assert.Equal(t, []string{"runtime1", "runtime2", "runtime3"}, ids)
+	})
+
+	t.Run("should sum total counts of partial pages", func(t *testing.T) {
+		// given
+		first := RuntimesPage{
+			Data:       []RuntimeDTO{fixRuntimeDTO("runtime1")},
+			Count:      1,
+			TotalCount: 10,
+		}
+		second := RuntimesPage{
+			Data:       []RuntimeDTO{fixRuntimeDTO("runtime1"), fixRuntimeDTO("runtime2")},
+			Count:      2,
+			TotalCount: 5,
+		}
+
+		// when
+		merged := MergePages(first, second)
+
+		// then
+		assert.Equal(t, 2, merged.Count)
+		assert.Equal(t, 14, merged.TotalCount)
+	})
+
+	t.Run("should keep runtimes without runtime ID", func(t *testing.T) {
+		// given
+		provisioning := fixRuntimeDTO("instance1")
+		provisioning.RuntimeID = ""
+		failed := fixRuntimeDTO("instance2")
+		failed.RuntimeID = ""
+		first := RuntimesPage{
+			Data:       []RuntimeDTO{provisioning, failed},
+			Count:      2,
+			TotalCount: 2,
+		}
+		second := RuntimesPage{
+			Data:       []RuntimeDTO{provisioning},
+			Count:      1,
+			TotalCount: 1,
+		}
+
+		// when
+		merged := MergePages(first, second)
+
+		// then
+		assert.Equal(t, 2, merged.Count)
+		assert.Equal(t, 2, merged.TotalCount)
+		assert.Equal(t, []RuntimeDTO{provisioning, failed}, merged.Data)
+	})
+
+	t.Run("should not merge runtimes without instance ID", func(t *testing.T) {
+		// given
+		first := RuntimesPage{
+			Data:       []RuntimeDTO{{}},
+			Count:      1,
+			TotalCount: 1,
+		}
+		second := RuntimesPage{
+			Data:       []RuntimeDTO{{}},
+			Count:      1,
+			TotalCount: 1,
+		}
+
+		// when
+		merged := MergePages(first, second)
+
+		// then
+		assert.Equal(t, 2, merged.Count)
+		assert.Equal(t, 2, merged.TotalCount)
+	})
+
+	t.Run("should return empty page", func(t *testing.T) {
+		// when
+		merged := MergePages()
+
+		// then
+		assert.Equal(t, RuntimesPage{Data: []RuntimeDTO{}}, merged)
+	})
+}
@@ -18,6 +18,10 @@ type RuntimeDTO struct {
 	ServicePlanName  string        `json:"servicePlanName"`
 	Status           RuntimeStatus `json:"status"`
 	UserID           string        `json:"userID"`
+	// KymaVersion is the version of Kyma installed by the last succeeded provisioning or upgrade operation
+	KymaVersion string `json:"kymaVersion,omitempty"`
+	// ZoneCount is the number of availability zones of the Runtime cluster
+	ZoneCount int `json:"zoneCount,omitempty"`
 }
 
 type RuntimeStatus struct {
@@ -41,14 +45,78 @@ type Operation struct {
 	State           string    `json:"state"`
 	Description     string    `json:"description"`
 	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
 	OperationID     string    `json:"operationID"`
 	OrchestrationID string    `json:"orchestrationID,omitempty"`
+	// Type is set only in the operation history of a runtime, in the runtime status the type follows from the field holding the operation
+	Type OperationType `json:"type,omitempty"`
+	// RetryHistory is set only in the details of a single Kyma upgrade operation, it holds the latest retries from the oldest one
+	RetryHistory []RetryEntry `json:"retryHistory,omitempty"`
+	// ProgressPercent is set only in the details of a single Kyma upgrade operation, it is the estimated progress from 0 to 100
+	ProgressPercent int `json:"progressPercent,omitempty"`
+}
+
+// RetryEntry records why a retry of an operation was scheduled
+type RetryEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// OperationType describes the kind of an operation in the history of a runtime
+type OperationType string
+
+const (
+	OperationTypeProvision    OperationType = "provision"
+	OperationTypeDeprovision  OperationType = "deprovision"
+	OperationTypeUpgradeKyma  OperationType = "upgradeKyma"
+	OperationTypeSuspension   OperationType = "suspension"
+	OperationTypeUnsuspension OperationType = "unsuspension"
+)
+
+// AccountQuotaDTO describes the number of runtimes of a global account compared to the limits of the service plans
+type AccountQuotaDTO struct {
+	GlobalAccountID string         `json:"globalAccountID"`
+	Plans           []PlanQuotaDTO `json:"plans"`
+}
+
+type PlanQuotaDTO struct {
+	ServicePlanID   string `json:"servicePlanID"`
+	ServicePlanName string `json:"servicePlanName"`
+	Used            int    `json:"used"`
+	// Limit is the maximum number of runtimes of the plan in the global account, it is zero for the unlimited plans
+	Limit int `json:"limit"`
+	// Unlimited is true if Kyma Environment Broker does not limit the number of runtimes of the plan in the global account
+	Unlimited bool `json:"unlimited"`
+}
+
+// FailureGroupDTO describes failed operations whose descriptions differ only by identifiers or numbers
+type FailureGroupDTO struct {
+	Message    string   `json:"message"`
+	Count      int      `json:"count"`
+	RuntimeIDs []string `json:"runtimeIDs"`
+}
+
+// SLAReportDTO describes how many completed operations of a given type breached their SLA
+type SLAReportDTO struct {
+	Type     string `json:"type"`
+	Total    int    `json:"total"`
+	Breached int    `json:"breached"`
+}
+
+// ArtifactDTO describes a named blob attached to an operation, e.g. the logs of the failed step
+type ArtifactDTO struct {
+	OperationID string    `json:"operationID"`
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"createdAt"`
 }
 
 type RuntimesPage struct {
 	Data       []RuntimeDTO `json:"data"`
 	Count      int          `json:"count"`
 	TotalCount int          `json:"totalCount"`
+	// NotModified is set by the Client if KEB reported that none of the fetched pages changed since the Client fetched them before
+	NotModified bool `json:"-"`
 }
 
 const (
@@ -59,11 +127,16 @@ const (
 	RegionParam          = "region"
 	ShootParam           = "shoot"
 	PlanParam            = "plan"
+
+	// LimitParam limits the number of returned failure groups
+	LimitParam = "limit"
 )
 
 type ListParameters struct {
-	Page             int
-	PageSize         int
+	Page     int
+	PageSize int
+	// MaxResults limits the number of runtimes the client fetches when all pages are requested.
+	MaxResults       int
 	GlobalAccountIDs []string
 	SubAccountIDs    []string
 	InstanceIDs      []string
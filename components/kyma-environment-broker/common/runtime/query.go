@@ -0,0 +1,40 @@
+package runtime
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
+)
+
+// EncodeQuery returns the query parameters of the KEB /runtimes API representing the list parameters.
+// Every value of a slice is sent as a repeated parameter, zero values are omitted.
+// MaxResults is handled by the client and it is not encoded.
+func (p ListParameters) EncodeQuery() url.Values {
+	query := url.Values{}
+	addParamInt(query, pagination.PageParam, p.Page)
+	addParamInt(query, pagination.PageSizeParam, p.PageSize)
+	addParamList(query, GlobalAccountIDParam, p.GlobalAccountIDs)
+	addParamList(query, SubAccountIDParam, p.SubAccountIDs)
+	addParamList(query, InstanceIDParam, p.InstanceIDs)
+	addParamList(query, RuntimeIDParam, p.RuntimeIDs)
+	addParamList(query, RegionParam, p.Regions)
+	addParamList(query, ShootParam, p.Shoots)
+	addParamList(query, PlanParam, p.Plans)
+
+	return query
+}
+
+func addParamInt(query url.Values, key string, value int) {
+	if value != 0 {
+		query.Add(key, strconv.Itoa(value))
+	}
+}
+
+func addParamList(query url.Values, key string, values []string) {
+	for _, value := range values {
+		if value != "" {
+			query.Add(key, value)
+		}
+	}
+}
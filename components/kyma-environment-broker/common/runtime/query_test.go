@@ -0,0 +1,93 @@
+package runtime
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListParameters_EncodeQuery(t *testing.T) {
+	t.Run("should not encode zero values", func(t *testing.T) {
+		// when
+		query := ListParameters{MaxResults: 10, Regions: []string{""}}.EncodeQuery()
+
+		// then
+		assert.Empty(t, query)
+	})
+
+	t.Run("should encode slices as repeated parameters", func(t *testing.T) {
+		// given
+		params := ListParameters{
+			Page:     2,
+			PageSize: 50,
+			Shoots:   []string{"shoot1", "shoot2"},
+			Plans:    []string{"azure"},
+		}
+
+		// when
+		query := params.EncodeQuery()
+
+		// then
+		assert.Equal(t, url.Values{
+			pagination.PageParam:     {"2"},
+			pagination.PageSizeParam: {"50"},
+			ShootParam:               {"shoot1", "shoot2"},
+			PlanParam:                {"azure"},
+		}, query)
+		assert.Equal(t, "page=2&page_size=50&plan=azure&shoot=shoot1&shoot=shoot2", query.Encode())
+	})
+
+	t.Run("should encode every field", func(t *testing.T) {
+		// given
+		fieldParams := map[string]string{
+			"Page":             pagination.PageParam,
+			"PageSize":         pagination.PageSizeParam,
+			"GlobalAccountIDs": GlobalAccountIDParam,
+			"SubAccountIDs":    SubAccountIDParam,
+			"InstanceIDs":      InstanceIDParam,
+			"RuntimeIDs":       RuntimeIDParam,
+			"Regions":          RegionParam,
+			"Shoots":           ShootParam,
+			"Plans":            PlanParam,
+		}
+		notEncoded := map[string]bool{"MaxResults": true}
+
+		params := ListParameters{}
+		expected := url.Values{}
+		value := reflect.ValueOf(&params).Elem()
+		for i := 0; i < value.NumField(); i++ {
+			name := value.Type().Field(i).Name
+			field := value.Field(i)
+			var values []string
+			switch field.Kind() {
+			case reflect.Int:
+				field.SetInt(int64(i + 1))
+				values = []string{strconv.Itoa(i + 1)}
+			case reflect.Slice:
+				values = []string{fmt.Sprintf("%s-1", name), fmt.Sprintf("%s-2", name)}
+				field.Set(reflect.ValueOf(values))
+			default:
+				t.Fatalf("field %s of kind %s is not covered by the test", name, field.Kind())
+			}
+			if notEncoded[name] {
+				continue
+			}
+			param, ok := fieldParams[name]
+			if !ok {
+				t.Fatalf("query parameter of field %s is not defined in the test", name)
+			}
+			expected[param] = values
+		}
+
+		// when
+		query := params.EncodeQuery()
+
+		// then
+		assert.Equal(t, expected, query)
+	})
+}
@@ -0,0 +1,190 @@
+// Package runtimefake provides a configurable fake of the runtime.Client for the tests of its consumers.
+package runtimefake
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+)
+
+// Names of the runtime.Client methods, used to inject errors and to inspect the recorded calls
+const (
+	ListRuntimes           = "ListRuntimes"
+	GetAccountQuota        = "GetAccountQuota"
+	ListFailureGroups      = "ListFailureGroups"
+	GetSLAReport           = "GetSLAReport"
+	ListRuntimeOperations  = "ListRuntimeOperations"
+	GetOperation           = "GetOperation"
+	ListOperationArtifacts = "ListOperationArtifacts"
+	GetOperationArtifact   = "GetOperationArtifact"
+)
+
+// Call is a recorded call of a runtime.Client method
+type Call struct {
+	Method string
+	// Args holds the arguments of the call, e.g. runtime.ListParameters for ListRuntimes
+	Args []interface{}
+}
+
+// Client is a fake runtime.Client which returns the preset results and records all calls.
+// The exported fields must be set before the client is used.
+type Client struct {
+	// RuntimesPages are returned by the subsequent ListRuntimes calls. The last page is returned repeatedly once all pages were returned.
+	RuntimesPages []runtime.RuntimesPage
+	// AccountQuotas are returned by GetAccountQuota for the given global account IDs
+	AccountQuotas map[string]runtime.AccountQuotaDTO
+	FailureGroups []runtime.FailureGroupDTO
+	SLAReport     []runtime.SLAReportDTO
+	// Operations are returned by ListRuntimeOperations for the given runtime IDs
+	Operations map[string][]runtime.Operation
+	// OperationsByID are returned by GetOperation for the given operation IDs
+	OperationsByID map[string]runtime.Operation
+	// Artifacts are returned by ListOperationArtifacts for the given operation IDs
+	Artifacts map[string][]runtime.ArtifactDTO
+	// ArtifactContents are returned by GetOperationArtifact for the given "{operation ID}/{name}" keys
+	ArtifactContents map[string][]byte
+	// Errors are returned by the methods with the given names instead of the results
+	Errors map[string]error
+
+	mu         sync.Mutex
+	calls      []Call
+	pagesTaken int
+}
+
+var _ runtime.Client = &Client{}
+
+// NewClient returns a fake runtime.Client which returns the given pages in the subsequent ListRuntimes calls
+func NewClient(pages ...runtime.RuntimesPage) *Client {
+	return &Client{
+		RuntimesPages:    pages,
+		AccountQuotas:    map[string]runtime.AccountQuotaDTO{},
+		Operations:       map[string][]runtime.Operation{},
+		OperationsByID:   map[string]runtime.Operation{},
+		Artifacts:        map[string][]runtime.ArtifactDTO{},
+		ArtifactContents: map[string][]byte{},
+		Errors:           map[string]error{},
+	}
+}
+
+// WithError configures the method with the given name to return the given error
+func (c *Client) WithError(method string, err error) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Errors == nil {
+		c.Errors = map[string]error{}
+	}
+	c.Errors[method] = err
+	return c
+}
+
+// Calls returns the recorded calls of the method with the given name, or all recorded calls if the name is empty
+func (c *Client) Calls(method string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var calls []Call
+	for _, call := range c.calls {
+		if method == "" || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (c *Client) ListRuntimes(params runtime.ListParameters) (runtime.RuntimesPage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record(ListRuntimes, params); err != nil {
+		return runtime.RuntimesPage{}, err
+	}
+	if len(c.RuntimesPages) == 0 {
+		return runtime.RuntimesPage{}, nil
+	}
+
+	idx := c.pagesTaken
+	if idx >= len(c.RuntimesPages) {
+		idx = len(c.RuntimesPages) - 1
+	}
+	c.pagesTaken++
+	return c.RuntimesPages[idx], nil
+}
+
+func (c *Client) GetAccountQuota(globalAccountID string) (runtime.AccountQuotaDTO, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record(GetAccountQuota, globalAccountID); err != nil {
+		return runtime.AccountQuotaDTO{}, err
+	}
+	return c.AccountQuotas[globalAccountID], nil
+}
+
+func (c *Client) ListFailureGroups(limit int) ([]runtime.FailureGroupDTO, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record(ListFailureGroups, limit); err != nil {
+		return nil, err
+	}
+	if limit > 0 && limit < len(c.FailureGroups) {
+		return c.FailureGroups[:limit], nil
+	}
+	return c.FailureGroups, nil
+}
+
+func (c *Client) GetSLAReport() ([]runtime.SLAReportDTO, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record(GetSLAReport); err != nil {
+		return nil, err
+	}
+	return c.SLAReport, nil
+}
+
+func (c *Client) ListRuntimeOperations(runtimeID string) ([]runtime.Operation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record(ListRuntimeOperations, runtimeID); err != nil {
+		return nil, err
+	}
+	return c.Operations[runtimeID], nil
+}
+
+func (c *Client) GetOperation(operationID string) (runtime.Operation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record(GetOperation, operationID); err != nil {
+		return runtime.Operation{}, err
+	}
+	op, found := c.OperationsByID[operationID]
+	if !found {
+		return runtime.Operation{}, fmt.Errorf("operation %s not found", operationID)
+	}
+	return op, nil
+}
+
+func (c *Client) ListOperationArtifacts(operationID string) ([]runtime.ArtifactDTO, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record(ListOperationArtifacts, operationID); err != nil {
+		return nil, err
+	}
+	return c.Artifacts[operationID], nil
+}
+
+func (c *Client) GetOperationArtifact(operationID, name string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.record(GetOperationArtifact, operationID, name); err != nil {
+		return nil, err
+	}
+	data, found := c.ArtifactContents[operationID+"/"+name]
+	if !found {
+		return nil, fmt.Errorf("artifact %s of operation %s not found", name, operationID)
+	}
+	return data, nil
+}
+
+// record saves the call and returns the error injected for the method, the lock must be held by the caller
+func (c *Client) record(method string, args ...interface{}) error {
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+	return c.Errors[method]
+}
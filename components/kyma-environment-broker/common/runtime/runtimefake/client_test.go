@@ -0,0 +1,79 @@
+package runtimefake
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListRuntimes(t *testing.T) {
+	t.Run("should return preset pages in order and repeat the last one", func(t *testing.T) {
+		// given
+		first := runtime.RuntimesPage{Data: []runtime.RuntimeDTO{{InstanceID: "id-1"}}, Count: 1, TotalCount: 1}
+		second := runtime.RuntimesPage{Data: []runtime.RuntimeDTO{{InstanceID: "id-2"}}, Count: 1, TotalCount: 1}
+		client := NewClient(first, second)
+
+		// when
+		var pages []runtime.RuntimesPage
+		for i := 0; i < 3; i++ {
+			page, err := client.ListRuntimes(runtime.ListParameters{})
+			require.NoError(t, err)
+			pages = append(pages, page)
+		}
+
+		// then
+		assert.Equal(t, []runtime.RuntimesPage{first, second, second}, pages)
+	})
+
+	t.Run("should return empty page when no page is preset", func(t *testing.T) {
+		// when
+		page, err := NewClient().ListRuntimes(runtime.ListParameters{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, runtime.RuntimesPage{}, page)
+	})
+}
+
+func TestClient_WithError(t *testing.T) {
+	// given
+	expected := errors.New("service unavailable")
+	client := NewClient(runtime.RuntimesPage{Count: 1}).WithError(ListRuntimes, expected)
+
+	// when
+	_, listErr := client.ListRuntimes(runtime.ListParameters{})
+	_, slaErr := client.GetSLAReport()
+
+	// then
+	assert.Equal(t, expected, listErr)
+	assert.NoError(t, slaErr)
+}
+
+func TestClient_Calls(t *testing.T) {
+	// given
+	client := NewClient()
+	client.AccountQuotas["ga-1"] = runtime.AccountQuotaDTO{GlobalAccountID: "ga-1"}
+	client.Operations["rt-1"] = []runtime.Operation{{OperationID: "op-1"}}
+	params := runtime.ListParameters{GlobalAccountIDs: []string{"ga-1"}}
+
+	// when
+	_, err := client.ListRuntimes(params)
+	require.NoError(t, err)
+	quota, err := client.GetAccountQuota("ga-1")
+	require.NoError(t, err)
+	ops, err := client.ListRuntimeOperations("rt-1")
+	require.NoError(t, err)
+	_, err = client.ListFailureGroups(5)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, "ga-1", quota.GlobalAccountID)
+	assert.Equal(t, []runtime.Operation{{OperationID: "op-1"}}, ops)
+	assert.Len(t, client.Calls(""), 4)
+	assert.Equal(t, []Call{{Method: ListRuntimes, Args: []interface{}{params}}}, client.Calls(ListRuntimes))
+	assert.Equal(t, []Call{{Method: ListFailureGroups, Args: []interface{}{5}}}, client.Calls(ListFailureGroups))
+	assert.Empty(t, client.Calls(GetSLAReport))
+}
@@ -0,0 +1,101 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// Collector saves the step log of every step which failed an operation as the artifact of the operation.
+// It is driven by the step processed events, so saving the artifacts does not block the operation processing.
+type Collector struct {
+	store Store
+	log   logrus.FieldLogger
+}
+
+func NewCollector(store Store, log logrus.FieldLogger) *Collector {
+	return &Collector{
+		store: store,
+		log:   log,
+	}
+}
+
+// Register subscribes the collector to the step processed events of all operation managers
+func (c *Collector) Register(sub event.Subscriber) {
+	sub.Subscribe(process.ProvisioningStepProcessed{}, c.OnProvisioningStepProcessed)
+	sub.Subscribe(process.DeprovisioningStepProcessed{}, c.OnDeprovisioningStepProcessed)
+	sub.Subscribe(process.UpgradeKymaStepProcessed{}, c.OnUpgradeKymaStepProcessed)
+}
+
+func (c *Collector) OnProvisioningStepProcessed(_ context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.ProvisioningStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected process.ProvisioningStepProcessed but got %+v", ev)
+	}
+
+	return c.collect(stepProcessed.StepProcessed, stepProcessed.OldOperation.Operation, stepProcessed.Operation.Operation)
+}
+
+func (c *Collector) OnDeprovisioningStepProcessed(_ context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.DeprovisioningStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected process.DeprovisioningStepProcessed but got %+v", ev)
+	}
+
+	return c.collect(stepProcessed.StepProcessed, stepProcessed.OldOperation.Operation, stepProcessed.Operation.Operation)
+}
+
+func (c *Collector) OnUpgradeKymaStepProcessed(_ context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.UpgradeKymaStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected process.UpgradeKymaStepProcessed but got %+v", ev)
+	}
+
+	return c.collect(stepProcessed.StepProcessed, stepProcessed.OldOperation.Operation, stepProcessed.Operation.Operation)
+}
+
+func (c *Collector) collect(step process.StepProcessed, oldOperation, operation internal.Operation) error {
+	if step.Error == nil && (oldOperation.State == operation.State || operation.State != domain.Failed) {
+		return nil
+	}
+
+	name := StepLogName(step.StepName)
+	if err := c.store.Save(operation.ID, name, stepLog(step, operation)); err != nil {
+		return errors.Wrapf(err, "while saving step log of operation %s", operation.ID)
+	}
+	c.log.WithField("operation", operation.ID).Infof("Saved artifact %s of the failed step", name)
+
+	return nil
+}
+
+// StepLogName returns the name of the artifact with the log of the given step
+func StepLogName(stepName string) string {
+	return invalidNameCharacters.ReplaceAllString(stepName, "_") + ".log"
+}
+
+func stepLog(step process.StepProcessed, operation internal.Operation) []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "Time:        %s\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(buf, "Step:        %s\n", step.StepName)
+	fmt.Fprintf(buf, "Duration:    %s\n", step.Duration)
+	fmt.Fprintf(buf, "Operation:   %s\n", operation.ID)
+	fmt.Fprintf(buf, "Instance:    %s\n", operation.InstanceID)
+	fmt.Fprintf(buf, "State:       %s\n", operation.State)
+	fmt.Fprintf(buf, "Description: %s\n", operation.Description)
+	if step.Error != nil {
+		fmt.Fprintf(buf, "Error:       %s\n", step.Error)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,92 @@
+package artifact_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/artifact"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector_OnProvisioningStepProcessed(t *testing.T) {
+	t.Run("should save log of the step which failed operation", func(t *testing.T) {
+		// given
+		store := newFilesystemStore(t)
+		collector := artifact.NewCollector(store, logrus.New())
+
+		// when
+		err := collector.OnProvisioningStepProcessed(context.Background(), fixProvisioningStepProcessed(domain.InProgress, domain.Failed, nil))
+
+		// then
+		require.NoError(t, err)
+		data, err := store.Fetch("op-id", "Create_Runtime.log")
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "Step:        Create Runtime")
+		assert.Contains(t, string(data), "State:       failed")
+		assert.Contains(t, string(data), "Description: description")
+	})
+
+	t.Run("should save log of the step which returned error", func(t *testing.T) {
+		// given
+		store := newFilesystemStore(t)
+		collector := artifact.NewCollector(store, logrus.New())
+
+		// when
+		err := collector.OnProvisioningStepProcessed(context.Background(), fixProvisioningStepProcessed(domain.InProgress, domain.InProgress, errors.New("provisioner is not available")))
+
+		// then
+		require.NoError(t, err)
+		data, err := store.Fetch("op-id", "Create_Runtime.log")
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "Error:       provisioner is not available")
+	})
+
+	t.Run("should not save log of the successful step", func(t *testing.T) {
+		// given
+		store := newFilesystemStore(t)
+		collector := artifact.NewCollector(store, logrus.New())
+
+		// when
+		err := collector.OnProvisioningStepProcessed(context.Background(), fixProvisioningStepProcessed(domain.InProgress, domain.Succeeded, nil))
+
+		// then
+		require.NoError(t, err)
+		artifacts, err := store.List("op-id")
+		require.NoError(t, err)
+		assert.Empty(t, artifacts)
+	})
+}
+
+func TestStepLogName(t *testing.T) {
+	assert.Equal(t, "Create_Runtime.log", artifact.StepLogName("Create Runtime"))
+	assert.Equal(t, "Upgrade_Kyma_on_Runtime__2_.log", artifact.StepLogName("Upgrade Kyma on Runtime (2)"))
+}
+
+func fixProvisioningStepProcessed(oldState, state domain.LastOperationState, err error) process.ProvisioningStepProcessed {
+	return process.ProvisioningStepProcessed{
+		StepProcessed: process.StepProcessed{
+			StepName: "Create Runtime",
+			Duration: time.Second,
+			Error:    err,
+		},
+		OldOperation: internal.ProvisioningOperation{Operation: fixOperation(oldState)},
+		Operation:    internal.ProvisioningOperation{Operation: fixOperation(state)},
+	}
+}
+
+func fixOperation(state domain.LastOperationState) internal.Operation {
+	return internal.Operation{
+		ID:          "op-id",
+		InstanceID:  "instance-id",
+		State:       state,
+		Description: "description",
+	}
+}
@@ -0,0 +1,7 @@
+package artifact
+
+type Config struct {
+	Disabled bool `envconfig:"default=false"`
+	// Directory is the root directory of the filesystem store
+	Directory string `envconfig:"default=/tmp/operation-artifacts"`
+}
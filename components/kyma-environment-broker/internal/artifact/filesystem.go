@@ -0,0 +1,118 @@
+package artifact
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/pkg/errors"
+)
+
+// FilesystemStore keeps the artifacts of every operation in a separate directory under the root directory
+type FilesystemStore struct {
+	root string
+}
+
+// NewFilesystemStore returns the store which keeps the artifacts under the given directory, the directory is created when the first artifact is saved
+func NewFilesystemStore(root string) *FilesystemStore {
+	return &FilesystemStore{root: root}
+}
+
+var _ Store = &FilesystemStore{}
+
+// Save writes the artifact to a temporary file first, so the artifact being replaced can be fetched until the new one is complete
+func (s *FilesystemStore) Save(operationID, name string, data []byte) error {
+	dir, err := s.operationDir(operationID)
+	if err != nil {
+		return err
+	}
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrapf(err, "while creating artifacts directory of operation %s", operationID)
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return errors.Wrapf(err, "while creating artifact %s of operation %s", name, operationID)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "while writing artifact %s of operation %s", name, operationID)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "while writing artifact %s of operation %s", name, operationID)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return errors.Wrapf(err, "while saving artifact %s of operation %s", name, operationID)
+	}
+
+	return nil
+}
+
+// List returns the artifacts of the operation sorted by name, an operation without artifacts has an empty list
+func (s *FilesystemStore) List(operationID string) ([]Artifact, error) {
+	dir, err := s.operationDir(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	switch {
+	case os.IsNotExist(err):
+		return []Artifact{}, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "while listing artifacts of operation %s", operationID)
+	}
+
+	artifacts := make([]Artifact, 0, len(files))
+	for _, file := range files {
+		// the temporary files of the artifacts being saved are skipped
+		if !file.Mode().IsRegular() || ValidateName(file.Name()) != nil {
+			continue
+		}
+		artifacts = append(artifacts, Artifact{
+			OperationID: operationID,
+			Name:        file.Name(),
+			Size:        file.Size(),
+			CreatedAt:   file.ModTime(),
+		})
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Name < artifacts[j].Name
+	})
+
+	return artifacts, nil
+}
+
+// Fetch returns the content of the artifact
+func (s *FilesystemStore) Fetch(operationID, name string) ([]byte, error) {
+	dir, err := s.operationDir(operationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, name))
+	switch {
+	case os.IsNotExist(err):
+		return nil, dberr.NotFound("artifact %s of operation %s not found", name, operationID)
+	case err != nil:
+		return nil, errors.Wrapf(err, "while reading artifact %s of operation %s", name, operationID)
+	}
+
+	return data, nil
+}
+
+func (s *FilesystemStore) operationDir(operationID string) (string, error) {
+	if err := ValidateName(operationID); err != nil {
+		return "", errors.Wrap(err, "invalid operation ID")
+	}
+	return filepath.Join(s.root, operationID), nil
+}
@@ -0,0 +1,106 @@
+package artifact_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/artifact"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemStore(t *testing.T) {
+	t.Run("should save, list and fetch artifacts", func(t *testing.T) {
+		// given
+		store := newFilesystemStore(t)
+
+		// when
+		require.NoError(t, store.Save("op-1", "step.log", []byte("step failed")))
+		require.NoError(t, store.Save("op-1", "kubeconfig.yaml", []byte("apiVersion: v1")))
+		require.NoError(t, store.Save("op-2", "step.log", []byte("other operation")))
+
+		// then
+		artifacts, err := store.List("op-1")
+		require.NoError(t, err)
+		require.Len(t, artifacts, 2)
+		assert.Equal(t, "kubeconfig.yaml", artifacts[0].Name)
+		assert.Equal(t, "step.log", artifacts[1].Name)
+		assert.Equal(t, "op-1", artifacts[1].OperationID)
+		assert.Equal(t, int64(len("step failed")), artifacts[1].Size)
+		assert.False(t, artifacts[1].CreatedAt.IsZero())
+
+		data, err := store.Fetch("op-1", "step.log")
+		require.NoError(t, err)
+		assert.Equal(t, "step failed", string(data))
+	})
+
+	t.Run("should replace artifact with the same name", func(t *testing.T) {
+		// given
+		store := newFilesystemStore(t)
+		require.NoError(t, store.Save("op-1", "step.log", []byte("first attempt")))
+
+		// when
+		err := store.Save("op-1", "step.log", []byte("second attempt"))
+
+		// then
+		require.NoError(t, err)
+		artifacts, err := store.List("op-1")
+		require.NoError(t, err)
+		assert.Len(t, artifacts, 1)
+
+		data, err := store.Fetch("op-1", "step.log")
+		require.NoError(t, err)
+		assert.Equal(t, "second attempt", string(data))
+	})
+
+	t.Run("should return empty list for operation without artifacts", func(t *testing.T) {
+		// given
+		store := newFilesystemStore(t)
+
+		// when
+		artifacts, err := store.List("op-1")
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, artifacts)
+	})
+
+	t.Run("should return not found error for missing artifact", func(t *testing.T) {
+		// given
+		store := newFilesystemStore(t)
+
+		// when
+		_, err := store.Fetch("op-1", "step.log")
+
+		// then
+		assert.True(t, dberr.IsNotFound(err))
+	})
+
+	t.Run("should reject invalid names", func(t *testing.T) {
+		// given
+		store := newFilesystemStore(t)
+
+		// when
+		errSave := store.Save("op-1", "../step.log", []byte("data"))
+		_, errFetch := store.Fetch("../op-1", "step.log")
+		_, errList := store.List("")
+
+		// then
+		assert.Error(t, errSave)
+		assert.Error(t, errFetch)
+		assert.Error(t, errList)
+	})
+}
+
+func newFilesystemStore(t *testing.T) *artifact.FilesystemStore {
+	dir, err := ioutil.TempDir("", "artifacts")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	return artifact.NewFilesystemStore(dir)
+}
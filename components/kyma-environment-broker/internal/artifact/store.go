@@ -0,0 +1,35 @@
+package artifact
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Artifact describes a named blob attached to an operation, e.g. the logs of the failed step
+type Artifact struct {
+	OperationID string
+	Name        string
+	Size        int64
+	CreatedAt   time.Time
+}
+
+// Store saves the artifacts of operations and allows to list and fetch them.
+// Saving an artifact with the name of an existing artifact of the operation replaces it.
+// Fetching an artifact which does not exist returns the dberr.NotFound error.
+type Store interface {
+	Save(operationID, name string, data []byte) error
+	List(operationID string) ([]Artifact, error)
+	Fetch(operationID, name string) ([]byte, error)
+}
+
+// namePattern restricts the operation IDs and artifact names, so they can be used as file names
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// ValidateName checks if the operation ID or the artifact name can be used in the store
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid name %q, only letters, digits, dots, dashes and underscores are allowed", name)
+	}
+	return nil
+}
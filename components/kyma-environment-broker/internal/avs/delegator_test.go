@@ -41,6 +41,7 @@ type testParams struct {
 
 func newDelOpsParams(params testParams) (*Delegator, internal.UpgradeKymaOperation) {
 	operation := internal.UpgradeKymaOperation{}
+	operation.ID = "operation-id"
 
 	*params.internalMonitor, *params.externalMonitor = createMonitors(params.client)
 
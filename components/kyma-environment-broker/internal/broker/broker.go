@@ -2,6 +2,7 @@ package broker
 
 import (
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -29,6 +30,10 @@ type Config struct {
 	Service
 	EnablePlans          EnablePlans `envconfig:"default=azure"`
 	OnlySingleTrialPerGA bool        `envconfig:"default=true"`
+
+	// ProvisioningDeduplicationWindow is the time in which a provisioning request with the same parameters as
+	// an already created operation of the instance returns the existing operation. Zero disables the deduplication.
+	ProvisioningDeduplicationWindow time.Duration `envconfig:"default=0s"`
 }
 
 type Service struct {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -43,6 +44,7 @@ type ProvisionEndpoint struct {
 	onlySingleTrialPerGA bool
 	plansSchemaValidator PlansSchemaValidator
 	kymaVerOnDemand      bool
+	dedupWindow          time.Duration
 
 	shootDomain  string
 	shootProject string
@@ -75,6 +77,7 @@ func NewProvision(cfg Config,
 		enabledPlanIDs:       enabledPlanIDs,
 		onlySingleTrialPerGA: cfg.OnlySingleTrialPerGA,
 		kymaVerOnDemand:      kvod,
+		dedupWindow:          cfg.ProvisioningDeduplicationWindow,
 		shootDomain:          gardenerConfig.ShootDomain,
 		shootProject:         gardenerConfig.Project,
 	}
@@ -110,6 +113,28 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 	logger.Infof("Starting provisioning runtime: Name=%s, GlobalAccountID=%s, SubAccountID=%s PlatformRegion=%s", parameters.Name, ersContext.GlobalAccountID, ersContext.SubAccountID, region)
 	logger.Infof("Runtime parameters: %+v", parameters)
 
+	// check if a recent operation of the instance has the same fingerprint, which ignores the parameters not defining the runtime
+	fingerprint, err := provisioningParameters.Fingerprint()
+	if err != nil {
+		logger.Errorf("cannot compute provisioning parameters fingerprint: %s", err)
+		return domain.ProvisionedServiceSpec{}, errors.New("cannot compute provisioning parameters fingerprint")
+	}
+	if b.dedupWindow > 0 {
+		duplicatedOperation, errStorage := b.operationsStorage.GetProvisioningOperationByFingerprint(instanceID, fingerprint, time.Now().Add(-b.dedupWindow))
+		switch {
+		case errStorage != nil && !dberr.IsNotFound(errStorage):
+			logger.Errorf("cannot get operation with the same fingerprint from storage %s", errStorage)
+			return domain.ProvisionedServiceSpec{}, errors.New("cannot get existing operation from storage")
+		case duplicatedOperation != nil && !dberr.IsNotFound(errStorage):
+			logger.Infof("Provisioning request duplicates operation %s, returning the existing operation", duplicatedOperation.ID)
+			return domain.ProvisionedServiceSpec{
+				IsAsync:       true,
+				AlreadyExists: true,
+				OperationData: duplicatedOperation.ID,
+			}, nil
+		}
+	}
+
 	// check if operation with instance ID already created
 	existingOperation, errStorage := b.operationsStorage.GetProvisioningOperationByInstanceID(instanceID)
 	switch {
@@ -131,6 +156,7 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 		return domain.ProvisionedServiceSpec{}, errors.New("cannot create new operation")
 	}
 	operation.ShootName = shootName
+	operation.Fingerprint = fingerprint
 	operation.ShootDomain = fmt.Sprintf("%s.%s.%s", shootName, b.shootProject, strings.Trim(b.shootDomain, "."))
 
 	err = b.operationsStorage.InsertProvisioningOperation(operation)
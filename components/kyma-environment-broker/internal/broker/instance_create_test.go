@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -131,6 +132,65 @@ func TestProvision_Provision(t *testing.T) {
 		assert.True(t, response.AlreadyExists)
 	})
 
+	t.Run("duplicated request within deduplication window returns existing operation", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+
+		queue := &automock.Queue{}
+		queue.On("Add", mock.AnythingOfType("string"))
+
+		factoryBuilder := &automock.PlanValidator{}
+		factoryBuilder.On("IsPlanSupport", planID).Return(true)
+
+		provisionEndpoint := broker.NewProvision(
+			broker.Config{EnablePlans: []string{"gcp", "azure"}, ProvisioningDeduplicationWindow: time.Hour},
+			gardener.Config{Project: "test", ShootDomain: "example.com"},
+			memoryStorage.Operations(),
+			memoryStorage.Instances(),
+			queue,
+			factoryBuilder,
+			fixAlwaysPassJSONValidator(),
+			false,
+			logrus.StandardLogger(),
+		)
+		details := domain.ProvisionDetails{
+			ServiceID:     serviceID,
+			PlanID:        planID,
+			RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s"}`, clusterName)),
+			RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s"}`, globalAccountID, subAccountID)),
+		}
+		firstResponse, err := provisionEndpoint.Provision(fixReqCtxWithRegion(t, region), instanceID, details, true)
+		require.NoError(t, err)
+
+		// when
+		duplicated := details
+		duplicated.RawContext = json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "other-user"}`, globalAccountID, subAccountID))
+		response, err := provisionEndpoint.Provision(fixReqCtxWithRegion(t, region), instanceID, duplicated, true)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, firstResponse.OperationData, response.OperationData)
+		assert.True(t, response.AlreadyExists)
+		queue.AssertNumberOfCalls(t, "Add", 1)
+
+		// when
+		response, err = provisionEndpoint.Provision(fixReqCtxWithRegion(t, region), otherInstanceID, details, true)
+
+		// then
+		require.NoError(t, err)
+		assert.NotEqual(t, firstResponse.OperationData, response.OperationData)
+		assert.False(t, response.AlreadyExists)
+		queue.AssertNumberOfCalls(t, "Add", 2)
+
+		// when
+		details.RawParameters = json.RawMessage(`{"name": "other-cluster"}`)
+		_, err = provisionEndpoint.Provision(fixReqCtxWithRegion(t, region), instanceID, details, true)
+
+		// then
+		assert.Error(t, err)
+		queue.AssertNumberOfCalls(t, "Add", 2)
+	})
+
 	t.Run("more than one trial is not allowed", func(t *testing.T) {
 		// given
 		memoryStorage := storage.NewMemoryStorage()
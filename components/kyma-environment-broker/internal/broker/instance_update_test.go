@@ -208,6 +208,7 @@ func fixProvisioningOperation(id string) internal.ProvisioningOperation {
 func fixSuspensionOperation() internal.DeprovisioningOperation {
 	return internal.DeprovisioningOperation{
 		Operation: internal.Operation{
+			ID:         "suspension-operation-id",
 			CreatedAt:  time.Now(),
 			InstanceID: instanceID,
 			ProvisioningParameters: internal.ProvisioningParameters{
@@ -1,6 +1,9 @@
 package internal
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"reflect"
 )
 
@@ -41,6 +44,35 @@ func (p ProvisioningParameters) IsEqual(input ProvisioningParameters) bool {
 	return true
 }
 
+// Fingerprint returns the hash of the normalized provisioning parameters.
+// Parameters which do not define the provisioned runtime, such as Service Manager credentials, are not taken into account.
+func (p ProvisioningParameters) Fingerprint() (string, error) {
+	normalized := struct {
+		PlanID          string                    `json:"plan_id"`
+		ServiceID       string                    `json:"service_id"`
+		PlatformRegion  string                    `json:"platform_region"`
+		TenantID        string                    `json:"tenant_id"`
+		GlobalAccountID string                    `json:"globalaccount_id"`
+		SubAccountID    string                    `json:"subaccount_id"`
+		Parameters      ProvisioningParametersDTO `json:"parameters"`
+	}{
+		PlanID:          p.PlanID,
+		ServiceID:       p.ServiceID,
+		PlatformRegion:  p.PlatformRegion,
+		TenantID:        p.ErsContext.TenantID,
+		GlobalAccountID: p.ErsContext.GlobalAccountID,
+		SubAccountID:    p.ErsContext.SubAccountID,
+		Parameters:      p.Parameters,
+	}
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
 type TrialCloudProvider string
 
 const Gcp TrialCloudProvider = "GCP"
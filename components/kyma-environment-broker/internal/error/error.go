@@ -31,3 +31,39 @@ func IsTemporaryError(err error) bool {
 	})
 	return ok && nfe.Temporary()
 }
+
+// FailureCategory is a machine-readable category of the reason why an operation failed
+type FailureCategory string
+
+const (
+	FailureCategoryTimeout     FailureCategory = "timeout"
+	FailureCategoryQuota       FailureCategory = "quota"
+	FailureCategoryProvisioner FailureCategory = "provisioner"
+	FailureCategoryUnknown     FailureCategory = "unknown"
+)
+
+// Categorizable is implemented by the errors which know the category of the failure they cause
+type Categorizable interface {
+	Category() FailureCategory
+}
+
+type CategorizedError struct {
+	message  string
+	category FailureCategory
+}
+
+func NewCategorizedError(category FailureCategory, msg string, args ...interface{}) *CategorizedError {
+	return &CategorizedError{message: fmt.Sprintf(msg, args...), category: category}
+}
+
+func (ce CategorizedError) Error() string             { return ce.message }
+func (ce CategorizedError) Category() FailureCategory { return ce.category }
+
+// CategoryOf returns the failure category of the error, or FailureCategoryUnknown if the cause of the error is not Categorizable
+func CategoryOf(err error) FailureCategory {
+	cause := errors.Cause(err)
+	if c, ok := cause.(Categorizable); ok && c.Category() != "" {
+		return c.Category()
+	}
+	return FailureCategoryUnknown
+}
@@ -28,3 +28,16 @@ func TestWrapf(t *testing.T) {
 	assert.Equal(t, "wrap err arg1: some error: argErr", e2.Error())
 	assert.Equal(t, "wrap err arg1: some error: argErr", e3.Error())
 }
+
+func TestCategoryOf(t *testing.T) {
+	// given
+	err1 := fmt.Errorf("some error")
+	err2 := NewCategorizedError(FailureCategoryQuota, "quota %s exceeded", "cpu")
+	err3 := errors.Wrap(NewCategorizedError(FailureCategoryTimeout, "timeout"), "wrap err")
+
+	// then
+	assert.Equal(t, FailureCategoryUnknown, CategoryOf(err1))
+	assert.Equal(t, FailureCategoryQuota, CategoryOf(err2))
+	assert.Equal(t, FailureCategoryTimeout, CategoryOf(err3))
+	assert.Equal(t, "quota cpu exceeded", err2.Error())
+}
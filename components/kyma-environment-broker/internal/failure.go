@@ -0,0 +1,46 @@
+package internal
+
+import "regexp"
+
+// FailureGroupExamples is the maximum number of example runtime IDs returned for a group of failed operations
+const FailureGroupExamples = 3
+
+// FailurePattern describes a part of failed operation descriptions which varies between operations failing for the same reason
+type FailurePattern struct {
+	Expression  string
+	Replacement string
+}
+
+// FailurePatterns are replaced in the descriptions of failed operations before grouping them.
+// The expressions are compatible with both Go and PostgreSQL regular expressions, so the grouping gives the same results in every storage.
+var FailurePatterns = []FailurePattern{
+	{Expression: "[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}", Replacement: "<id>"},
+	{Expression: "[0-9]+", Replacement: "<n>"},
+}
+
+var failureExpressions = compileFailurePatterns()
+
+// FailureGroup describes failed operations with the same normalized description
+type FailureGroup struct {
+	Message    string
+	Count      int
+	RuntimeIDs []string
+}
+
+// NormalizeFailureDescription replaces the identifiers and numbers in the description of a failed operation
+func NormalizeFailureDescription(description string) string {
+	for i, expression := range failureExpressions {
+		description = expression.ReplaceAllLiteralString(description, FailurePatterns[i].Replacement)
+	}
+
+	return description
+}
+
+func compileFailurePatterns() []*regexp.Regexp {
+	expressions := make([]*regexp.Regexp, 0, len(FailurePatterns))
+	for _, pattern := range FailurePatterns {
+		expressions = append(expressions, regexp.MustCompile(pattern.Expression))
+	}
+
+	return expressions
+}
@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeFailureDescription(t *testing.T) {
+	for name, tc := range map[string]struct {
+		descriptions []string
+		expected     string
+	}{
+		"operation IDs": {
+			descriptions: []string{
+				"provisioner operation 9d75a545-2e1e-4786-abd8-a37b14e185b9 failed",
+				"provisioner operation EF4E3210-652C-453E-8015-BBA1C1CD1E1C failed",
+			},
+			expected: "provisioner operation <id> failed",
+		},
+		"numbers": {
+			descriptions: []string{
+				"call to provisioner failed after 3 retries: status 500",
+				"call to provisioner failed after 10 retries: status 503",
+			},
+			expected: "call to provisioner failed after <n> retries: status <n>",
+		},
+		"without identifiers": {
+			descriptions: []string{"operation has reached the time limit"},
+			expected:     "operation has reached the time limit",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, description := range tc.descriptions {
+				assert.Equal(t, tc.expected, NormalizeFailureDescription(description))
+			}
+		})
+	}
+}
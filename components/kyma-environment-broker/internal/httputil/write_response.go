@@ -1,8 +1,11 @@
 package httputil
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -22,6 +25,41 @@ func WriteResponse(w http.ResponseWriter, code int, object interface{}) {
 	}
 }
 
+// WriteResponseWithETag writes the object with the 200 status like WriteResponse, together with the ETag header holding the SHA-256 checksum of its JSON encoding.
+// If the If-None-Match header of the request contains the same ETag, only the 304 status is written, so that the client can reuse the object it fetched before.
+func WriteResponseWithETag(w http.ResponseWriter, req *http.Request, object interface{}) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(data))
+	w.Header().Set("ETag", etag)
+	if etagMatches(req.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	if err != nil {
+		logrus.Warnf("could not write response %s", string(data))
+	}
+}
+
+// etagMatches returns true if the comma-separated list of the If-None-Match header contains the ETag, also as a weak one, or is *
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
 type errObj struct {
 	Error string `json:"error"`
 }
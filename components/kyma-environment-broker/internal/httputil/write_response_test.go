@@ -0,0 +1,77 @@
+package httputil_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteResponseWithETag(t *testing.T) {
+	// given
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/runtimes", nil)
+
+	// when
+	httputil.WriteResponseWithETag(rw, req, fixData())
+
+	// then
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		  "field_int": 1,
+		  "field_string": "andrzej"
+		}`, rw.Body.String())
+	etag := rw.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	for name, tc := range map[string]struct {
+		ifNoneMatch string
+		data        interface{}
+		status      int
+	}{
+		"same data": {
+			ifNoneMatch: etag,
+			data:        fixData(),
+			status:      http.StatusNotModified,
+		},
+		"weak etag in the list": {
+			ifNoneMatch: `"other", W/` + etag,
+			data:        fixData(),
+			status:      http.StatusNotModified,
+		},
+		"changed data": {
+			ifNoneMatch: etag,
+			data:        dataToEncode{FieldInt: 2},
+			status:      http.StatusOK,
+		},
+		"other etag": {
+			ifNoneMatch: `"other"`,
+			data:        fixData(),
+			status:      http.StatusOK,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			rw := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/runtimes", nil)
+			req.Header.Set("If-None-Match", tc.ifNoneMatch)
+
+			// when
+			httputil.WriteResponseWithETag(rw, req, tc.data)
+
+			// then
+			assert.Equal(t, tc.status, rw.Code)
+			assert.NotEmpty(t, rw.Header().Get("ETag"))
+			if tc.status == http.StatusNotModified {
+				assert.Empty(t, rw.Body.String())
+			} else {
+				assert.NotEmpty(t, rw.Body.String())
+			}
+		})
+	}
+}
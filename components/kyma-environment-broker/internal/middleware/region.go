@@ -14,6 +14,8 @@ type key int
 const (
 	// requestRegionKey is the context key for the region from the request path.
 	requestRegionKey key = iota + 1
+	// requestUserKey is the context key for the authenticated user of the request.
+	requestUserKey
 )
 
 func AddRegionToContext(defaultRegion string) mux.MiddlewareFunc {
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AuthenticatedUserHeader is the header in which the API gateway passes the subject of the authenticated request.
+// The gateway overwrites the header, so its value cannot be set by the client.
+const AuthenticatedUserHeader = "X-Authenticated-User"
+
+func AddUserToContext() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			user := req.Header.Get(AuthenticatedUserHeader)
+			if user == "" {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			newCtx := context.WithValue(req.Context(), requestUserKey, user)
+			next.ServeHTTP(w, req.WithContext(newCtx))
+		})
+	}
+}
+
+// UserFromContext returns the authenticated user of the request associated with the context if possible.
+func UserFromContext(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(requestUserKey).(string)
+	return user, ok
+}
@@ -0,0 +1,63 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestUserKey(t *testing.T) {
+	// given
+	const fixUser = "admin@kyma.cx"
+
+	req, err := http.NewRequest(http.MethodGet, "http://url.dev/endpoint", nil)
+	require.NoError(t, err)
+	req.Header.Set(middleware.AuthenticatedUserHeader, fixUser)
+
+	var gotCtx context.Context
+	spyHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotCtx = req.Context()
+	})
+
+	router := mux.NewRouter()
+	router.Use(middleware.AddUserToContext())
+	router.Path("/endpoint").Handler(spyHandler)
+
+	// when
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	gotUser, foundUser := middleware.UserFromContext(gotCtx)
+
+	// then
+	assert.True(t, foundUser)
+	assert.Equal(t, fixUser, gotUser)
+}
+
+func TestRequestUserKeyNotAuthenticated(t *testing.T) {
+	// given
+	req, err := http.NewRequest(http.MethodGet, "http://url.dev/endpoint", nil)
+	require.NoError(t, err)
+
+	var gotCtx context.Context
+	spyHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotCtx = req.Context()
+	})
+
+	router := mux.NewRouter()
+	router.Use(middleware.AddUserToContext())
+	router.Path("/endpoint").Handler(spyHandler)
+
+	// when
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	gotUser, foundUser := middleware.UserFromContext(gotCtx)
+
+	// then
+	assert.Empty(t, gotUser)
+	assert.False(t, foundUser)
+}
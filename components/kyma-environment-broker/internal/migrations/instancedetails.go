@@ -3,12 +3,15 @@ package migrations
 import (
 	"reflect"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
+const migrationPageSize = 100
+
 type InstanceDetailsMigration struct {
 	operations storage.Operations
 	log        logrus.FieldLogger
@@ -22,7 +25,7 @@ func NewInstanceDetailsMigration(operations storage.Operations, log logrus.Field
 }
 
 func (m *InstanceDetailsMigration) Migrate() error {
-	upgradeOperations, err := m.operations.ListUpgradeKymaOperations()
+	upgradeOperations, err := m.listUpgradeKymaOperations()
 	if err != nil {
 		return errors.Wrap(err, "while listing operations")
 	}
@@ -56,3 +59,17 @@ func (m *InstanceDetailsMigration) Migrate() error {
 	m.log.Info("Instance details migration end up successfully")
 	return nil
 }
+
+func (m *InstanceDetailsMigration) listUpgradeKymaOperations() ([]internal.UpgradeKymaOperation, error) {
+	var result []internal.UpgradeKymaOperation
+	for page := 1; ; page++ {
+		operations, totalCount, err := m.operations.ListUpgradeKymaOperations(migrationPageSize, page)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while listing page %d of operations", page)
+		}
+		result = append(result, operations...)
+		if len(operations) == 0 || len(result) >= totalCount {
+			return result, nil
+		}
+	}
+}
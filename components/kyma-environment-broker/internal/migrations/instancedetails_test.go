@@ -46,6 +46,7 @@ func TestInstanceDetailsMigration_Migrate(t *testing.T) {
 func fixProvisioningOperation() internal.ProvisioningOperation {
 	return internal.ProvisioningOperation{
 		Operation: internal.Operation{
+			ID: "provisioning-op-id",
 			InstanceDetails: internal.InstanceDetails{
 				Lms: internal.LMS{
 					TenantID: "lms-tenant",
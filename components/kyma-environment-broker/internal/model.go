@@ -2,6 +2,7 @@ package internal
 
 import (
 	"database/sql"
+	"sort"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
@@ -132,6 +134,54 @@ type Operation struct {
 
 	// OrchestrationID specifies the origin orchestration which triggers the operation, empty for OSB operations (provisioning/deprovisioning)
 	OrchestrationID string `json:"-"`
+
+	// SLABreached is set when the operation is completed and it took longer than the SLA of its type
+	SLABreached bool `json:"sla_breached"`
+
+	// Priority orders the pending and in progress operations, the operations with a higher priority are processed first
+	Priority int `json:"priority,omitempty"`
+
+	// ScheduledRetry is the retry scheduled by the operation manager, it is stored so the retry is resumed after a restart
+	ScheduledRetry ScheduledRetry `json:"scheduled_retry"`
+}
+
+// ScheduledRetry describes the next retry of an operation and the start of its retries. It holds only as long as the operation
+// is not updated again, which is detected by the version of the operation stored together with the retry.
+type ScheduledRetry struct {
+	At      time.Time `json:"at"`
+	Since   time.Time `json:"since"`
+	Version int       `json:"version"`
+}
+
+// NextRetry returns the time of the retry scheduled by the last update of the operation, false if no retry is scheduled
+func (o Operation) NextRetry() (time.Time, bool) {
+	if o.ScheduledRetry.At.IsZero() || o.ScheduledRetry.Version != o.Version {
+		return time.Time{}, false
+	}
+	return o.ScheduledRetry.At, true
+}
+
+// RetriedSince returns the time from which the retries of the operation are limited, the start of the retries
+// if the last update of the operation scheduled a retry, otherwise the last update
+func (o Operation) RetriedSince() time.Time {
+	if _, scheduled := o.NextRetry(); scheduled {
+		return o.ScheduledRetry.Since
+	}
+	return o.UpdatedAt
+}
+
+// SortOperationsByPriority sorts the operations by priority, the highest first, and the operations with the same priority by creation time, the oldest first
+func SortOperationsByPriority(operations []Operation) {
+	sort.SliceStable(operations, func(i, j int) bool {
+		a, b := operations[i], operations[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
 }
 
 func (o *Operation) IsFinished() bool {
@@ -154,6 +204,11 @@ func (o *Orchestration) IsFinished() bool {
 	return o.State == orchestration.Succeeded || o.State == orchestration.Failed || o.State == orchestration.Canceled
 }
 
+// IsPaused returns true if orchestration was paused and not resumed yet
+func (o *Orchestration) IsPaused() bool {
+	return o.State == orchestration.Paused
+}
+
 // IsCanceled returns true if orchestration's cancellation endpoint was ever triggered
 func (o *Orchestration) IsCanceled() bool {
 	return o.State == orchestration.Canceling || o.State == orchestration.Canceled
@@ -192,6 +247,9 @@ type ProvisioningOperation struct {
 
 	RuntimeVersion RuntimeVersionData `json:"runtime_version"`
 
+	// Fingerprint is the hash of the normalized provisioning parameters, used to detect duplicated provisioning requests
+	Fingerprint string `json:"fingerprint,omitempty"`
+
 	// following fields are not stored in the storage
 	InputCreator ProvisionerInputCreator `json:"-"`
 
@@ -248,8 +306,87 @@ type UpgradeKymaOperation struct {
 	InputCreator                   ProvisionerInputCreator `json:"-"`
 
 	RuntimeVersion RuntimeVersionData `json:"runtime_version"`
+
+	// DeferralReason explains why the processing of the operation was postponed the last time, empty if the operation was not deferred
+	DeferralReason DeferralReason `json:"deferral_reason,omitempty"`
+
+	// RetryBackoff holds the retries of the step which is currently retried
+	RetryBackoff RetryBackoff `json:"retry_backoff"`
+
+	// FailureCategory is the category of the reason why the operation failed, empty if the operation did not fail
+	FailureCategory kebError.FailureCategory `json:"failure_category,omitempty"`
+
+	// RetryCount is the number of retries scheduled with the limited number of attempts
+	RetryCount int `json:"retry_count,omitempty"`
+
+	// RetryHistory holds the latest retries of the operation, at most MaxRetryHistory entries from the oldest one
+	RetryHistory []RetryEntry `json:"retry_history,omitempty"`
+
+	// ForcedFailure is set when the operation was marked as failed by an administrator
+	ForcedFailure *ForcedFailure `json:"forced_failure,omitempty"`
+
+	// ProgressPercent is the estimated progress of the operation from 0 to 100, it is 100 once the operation succeeded and keeps the last value if it failed
+	ProgressPercent int `json:"progress_percent,omitempty"`
+}
+
+// ForcedFailure records who marked an operation as failed, why, and in which state the operation was before
+type ForcedFailure struct {
+	Reason        string                    `json:"reason"`
+	Initiator     string                    `json:"initiator"`
+	PreviousState domain.LastOperationState `json:"previous_state"`
+	FailedAt      time.Time                 `json:"failed_at"`
+}
+
+// MaxRetryHistory is the maximum number of retries recorded in the RetryHistory of an operation, the oldest entries are dropped first
+const MaxRetryHistory = 10
+
+// RetryEntry records why a retry of an operation was scheduled
+type RetryEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// AppendRetryEntry returns the history with the given entry appended, keeping at most MaxRetryHistory latest entries
+func AppendRetryEntry(history []RetryEntry, entry RetryEntry) []RetryEntry {
+	history = append(history, entry)
+	if len(history) > MaxRetryHistory {
+		history = append([]RetryEntry(nil), history[len(history)-MaxRetryHistory:]...)
+	}
+	return history
+}
+
+// RetryBackoff describes the retries of an operation step, they are counted from Since and the interval can grow with every retry until the step succeeds
+type RetryBackoff struct {
+	Step     string        `json:"step,omitempty"`
+	Interval time.Duration `json:"interval,omitempty"`
+	Since    time.Time     `json:"since"`
+}
+
+// IsEmpty returns true if no step is retried
+func (b RetryBackoff) IsEmpty() bool {
+	return b.Step == ""
 }
 
+// DeferralReason describes why the processing of an operation was postponed without failing it
+type DeferralReason string
+
+const (
+	// DeferralReasonProvisioning is set when the operation waits for the provisioning of the runtime to finish
+	DeferralReasonProvisioning DeferralReason = "waiting for provisioning to finish"
+	// DeferralReasonMaintenanceWindow is set when the maintenance window of the operation has ended and the operation is rescheduled
+	DeferralReasonMaintenanceWindow DeferralReason = "rescheduled to the next maintenance window"
+	// DeferralReasonTimeWindow is set when the operation is outside of the weekly maintenance time window of the runtime
+	DeferralReasonTimeWindow DeferralReason = "outside of the maintenance time window"
+	// DeferralReasonScheduled is set when the operation is scheduled to start at a later time
+	DeferralReasonScheduled DeferralReason = "scheduled to start later"
+	// DeferralReasonRetry is set when the operation is retried after a temporary error
+	DeferralReasonRetry DeferralReason = "retrying after a temporary error"
+	// DeferralReasonOrchestrationPaused is set when the operation is not started because its orchestration is paused
+	DeferralReasonOrchestrationPaused DeferralReason = "orchestration is paused"
+	// DeferralReasonStepInProgress is set when a step is repeated without giving a more specific reason
+	DeferralReasonStepInProgress DeferralReason = "waiting for a step to finish"
+)
+
 func NewRuntimeState(runtimeID, operationID string, kymaConfig *gqlschema.KymaConfigInput, clusterConfig *gqlschema.GardenerConfigInput) RuntimeState {
 	var (
 		kymaConfigInput    gqlschema.KymaConfigInput
@@ -0,0 +1,19 @@
+package orchestration
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeversion"
+	"github.com/sirupsen/logrus"
+)
+
+// UpgradeEligibility selects the runtimes with the default eligibility, which are not already on the Kyma version they would be upgraded to
+func UpgradeEligibility(versions *runtimeversion.RuntimeVersionConfigurator, log logrus.FieldLogger) orchestration.EligibilityPredicate {
+	return orchestration.AllOf(orchestration.DefaultEligibility, orchestration.NotOnVersion(func(rt runtime.RuntimeDTO) string {
+		version, err := versions.ForAccount(rt.GlobalAccountID, rt.SubAccountID)
+		if err != nil {
+			log.Errorf("while getting Kyma version for runtime %s: %v", rt.RuntimeID, err)
+		}
+		return version
+	}))
+}
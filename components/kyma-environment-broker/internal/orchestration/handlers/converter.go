@@ -59,6 +59,8 @@ func (c *Converter) UpgradeKymaOperationToDTO(op internal.UpgradeKymaOperation)
 		MaintenanceWindowEnd:   op.MaintenanceWindowEnd,
 		State:                  string(op.Operation.State),
 		Description:            op.Operation.Description,
+		DeferralReason:         string(op.DeferralReason),
+		ProgressPercent:        op.ProgressPercent,
 	}, nil
 }
 
@@ -53,6 +53,7 @@ func TestConverter_UpgradeKymaOperationToDTO(t *testing.T) {
 
 	id := "id"
 	givenOperation := fixOperation(id)
+	givenOperation.DeferralReason = internal.DeferralReasonTimeWindow
 
 	// when
 	resp, err := c.UpgradeKymaOperationToDTO(givenOperation)
@@ -60,6 +61,7 @@ func TestConverter_UpgradeKymaOperationToDTO(t *testing.T) {
 	// then
 	require.NoError(t, err)
 	assert.Equal(t, id, resp.OrchestrationID)
+	assert.Equal(t, string(internal.DeferralReasonTimeWindow), resp.DeferralReason)
 }
 
 func TestConverter_UpgradeKymaOperationListToDTO(t *testing.T) {
@@ -57,6 +57,13 @@ func (h *kymaHandler) createOrchestration(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	err = h.validateStrategy(params.Strategy)
+	if err != nil {
+		h.log.Errorf("while validating strategy: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while validating strategy"))
+		return
+	}
+
 	// defaults strategy if not specified to Parallel with Immediate schedule
 	h.defaultOrchestrationStrategy(&params.Strategy)
 
@@ -101,6 +108,25 @@ func (h *kymaHandler) validateTarget(spec orchestration.TargetSpec) error {
 	return nil
 }
 
+func (h *kymaHandler) validateStrategy(spec orchestration.StrategySpec) error {
+	if spec.Parallel.BatchSize < 0 {
+		return errors.New("strategy.parallel.batchSize must not be negative")
+	}
+	if spec.Parallel.FailureThreshold < 0 {
+		return errors.New("strategy.parallel.failureThreshold must not be negative")
+	}
+	if spec.Canary.Size < 0 {
+		return errors.New("strategy.canary.size must not be negative")
+	}
+	if spec.Canary.Percentage < 0 || spec.Canary.Percentage > 100 {
+		return errors.New("strategy.canary.percentage must be between 0 and 100")
+	}
+	if spec.Canary.MaxFailures < 0 {
+		return errors.New("strategy.canary.maxFailures must not be negative")
+	}
+	return nil
+}
+
 func (h *kymaHandler) defaultOrchestrationStrategy(spec *orchestration.StrategySpec) {
 	if spec.Parallel.Workers == 0 {
 		spec.Parallel.Workers = 1
@@ -108,6 +134,7 @@ func (h *kymaHandler) defaultOrchestrationStrategy(spec *orchestration.StrategyS
 
 	switch spec.Type {
 	case orchestration.ParallelStrategy:
+	case orchestration.CanaryStrategy:
 	default:
 		spec.Type = orchestration.ParallelStrategy
 	}
@@ -118,4 +145,10 @@ func (h *kymaHandler) defaultOrchestrationStrategy(spec *orchestration.StrategyS
 	default:
 		spec.Schedule = orchestration.Immediate
 	}
+
+	switch spec.Parallel.Fairness {
+	case orchestration.FairnessRoundRobin:
+	default:
+		spec.Parallel.Fairness = orchestration.FairnessNone
+	}
 }
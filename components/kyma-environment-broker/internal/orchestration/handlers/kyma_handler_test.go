@@ -59,6 +59,42 @@ func TestHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotEmpty(t, out.OrchestrationID)
 	})
+
+	t.Run("upgrade with negative batch size", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		logs := logrus.New()
+		q := process.NewQueue(&testExecutor{}, logs)
+		kymaHandler := handlers.NewKymaHandler(db.Orchestrations(), q, logs)
+
+		params := orchestration.Parameters{
+			Targets: orchestration.TargetSpec{
+				Include: []orchestration.RuntimeTarget{
+					{
+						RuntimeID: "test",
+					},
+				},
+			},
+			Strategy: orchestration.StrategySpec{
+				Parallel: orchestration.ParallelStrategySpec{BatchSize: -1},
+			},
+		}
+		p, err := json.Marshal(&params)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", "/upgrade/kyma", bytes.NewBuffer(p))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		kymaHandler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
 }
 
 type testExecutor struct{}
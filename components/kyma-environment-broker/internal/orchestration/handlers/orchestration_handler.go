@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -8,6 +10,8 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -27,21 +31,27 @@ type orchestrationHandler struct {
 	converter Converter
 	log       logrus.FieldLogger
 
-	canceler *Canceler
+	canceler         *Canceler
+	pauser           *Pauser
+	kymaQueue        *process.Queue
+	operationManager *process.UpgradeKymaOperationManager
 
 	defaultMaxPage int
 }
 
 // NewOrchestrationStatusHandler exposes data about orchestrations and allows to manage them
-func NewOrchestrationStatusHandler(operations storage.Operations, orchestrations storage.Orchestrations, runtimeStates storage.RuntimeStates, defaultMaxPage int, log logrus.FieldLogger) *orchestrationHandler {
+func NewOrchestrationStatusHandler(operations storage.Operations, orchestrations storage.Orchestrations, runtimeStates storage.RuntimeStates, kymaQueue *process.Queue, defaultMaxPage int, log logrus.FieldLogger) *orchestrationHandler {
 	return &orchestrationHandler{
-		operations:     operations,
-		orchestrations: orchestrations,
-		runtimeStates:  runtimeStates,
-		log:            log,
-		defaultMaxPage: defaultMaxPage,
-		converter:      Converter{},
-		canceler:       NewCanceler(orchestrations, log),
+		operations:       operations,
+		orchestrations:   orchestrations,
+		runtimeStates:    runtimeStates,
+		log:              log,
+		defaultMaxPage:   defaultMaxPage,
+		converter:        Converter{},
+		canceler:         NewCanceler(orchestrations, log),
+		pauser:           NewPauser(orchestrations, operations, kymaQueue, log),
+		kymaQueue:        kymaQueue,
+		operationManager: process.NewUpgradeKymaOperationManager(operations),
 	}
 }
 
@@ -49,8 +59,11 @@ func (h *orchestrationHandler) AttachRoutes(router *mux.Router) {
 	router.HandleFunc("/orchestrations", h.listOrchestration).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}", h.getOrchestration).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}/cancel", h.cancelOrchestrationByID).Methods(http.MethodPut)
+	router.HandleFunc("/orchestrations/{orchestration_id}/pause", h.pauseOrchestrationByID).Methods(http.MethodPut)
+	router.HandleFunc("/orchestrations/{orchestration_id}/resume", h.resumeOrchestrationByID).Methods(http.MethodPut)
 	router.HandleFunc("/orchestrations/{orchestration_id}/operations", h.listOperations).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}/operations/{operation_id}", h.getOperation).Methods(http.MethodGet)
+	router.HandleFunc("/orchestrations/{orchestration_id}/operations/{operation_id}/fail", h.forceFailOperation).Methods(http.MethodPut)
 }
 
 func (h *orchestrationHandler) getOrchestration(w http.ResponseWriter, r *http.Request) {
@@ -83,12 +96,53 @@ func (h *orchestrationHandler) getOrchestration(w http.ResponseWriter, r *http.R
 func (h *orchestrationHandler) cancelOrchestrationByID(w http.ResponseWriter, r *http.Request) {
 	orchestrationID := mux.Vars(r)["orchestration_id"]
 
-	err := h.canceler.CancelForID(orchestrationID)
+	o, err := h.orchestrations.GetByID(orchestrationID)
+	if err != nil {
+		h.log.Errorf("while getting orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while getting orchestration %s", orchestrationID))
+		return
+	}
+
+	err = h.canceler.CancelForID(orchestrationID)
 	if err != nil {
 		h.log.Errorf("while canceling orchestration %s: %v", orchestrationID, err)
 		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while canceling orchestration %s", orchestrationID))
 		return
 	}
+	// a paused orchestration is not processed, so it is queued to cancel its pending operations
+	if o.IsPaused() {
+		h.kymaQueue.Add(orchestrationID)
+	}
+
+	response := commonOrchestration.UpgradeResponse{OrchestrationID: orchestrationID}
+
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
+
+func (h *orchestrationHandler) pauseOrchestrationByID(w http.ResponseWriter, r *http.Request) {
+	orchestrationID := mux.Vars(r)["orchestration_id"]
+
+	err := h.pauser.PauseOrchestration(orchestrationID)
+	if err != nil {
+		h.log.Errorf("while pausing orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while pausing orchestration %s", orchestrationID))
+		return
+	}
+
+	response := commonOrchestration.UpgradeResponse{OrchestrationID: orchestrationID}
+
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
+
+func (h *orchestrationHandler) resumeOrchestrationByID(w http.ResponseWriter, r *http.Request) {
+	orchestrationID := mux.Vars(r)["orchestration_id"]
+
+	err := h.pauser.ResumeOrchestration(orchestrationID)
+	if err != nil {
+		h.log.Errorf("while resuming orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while resuming orchestration %s", orchestrationID))
+		return
+	}
 
 	response := commonOrchestration.UpgradeResponse{OrchestrationID: orchestrationID}
 
@@ -193,6 +247,62 @@ func (h *orchestrationHandler) getOperation(w http.ResponseWriter, r *http.Reque
 	httputil.WriteResponse(w, http.StatusOK, response)
 }
 
+func (h *orchestrationHandler) forceFailOperation(w http.ResponseWriter, r *http.Request) {
+	orchestrationID := mux.Vars(r)["orchestration_id"]
+	operationID := mux.Vars(r)["operation_id"]
+
+	request := commonOrchestration.ForceFailRequest{}
+	if r.Body != nil {
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			h.log.Errorf("while decoding request body: %v", err)
+			httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while decoding request body"))
+			return
+		}
+	}
+	if request.Reason == "" {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.New("reason must be provided"))
+		return
+	}
+	// the initiator given in the request cannot be trusted, it is recorded only if the request is not authenticated
+	initiator, authenticated := middleware.UserFromContext(r.Context())
+	if !authenticated {
+		if request.Initiator == "" {
+			httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.New("initiator must be provided for a request which is not authenticated"))
+			return
+		}
+		initiator = fmt.Sprintf("%s (unverified)", request.Initiator)
+	}
+
+	operation, err := h.operations.GetUpgradeKymaOperationByID(operationID)
+	if err != nil {
+		h.log.Errorf("while getting upgrade operation %s: %v", operationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while getting operation %s", operationID))
+		return
+	}
+	if operation.OrchestrationID != orchestrationID {
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("operation %s does not belong to orchestration %s", operationID, orchestrationID))
+		return
+	}
+
+	failed, err := h.operationManager.ForceFail(*operation, request.Reason, initiator)
+	if err != nil {
+		h.log.Errorf("while force-failing operation %s: %v", operationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while force-failing operation %s", operationID))
+		return
+	}
+	h.log.Infof("Operation %s was force-failed by %s (previous state %s): %s", operationID, initiator, failed.ForcedFailure.PreviousState, request.Reason)
+
+	response, err := h.converter.UpgradeKymaOperationToDTO(failed)
+	if err != nil {
+		h.log.Errorf("while converting operation: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while converting operation"))
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
+
 func (h *orchestrationHandler) resolveErrorStatus(err error) int {
 	cause := errors.Cause(err)
 	switch {
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/stretchr/testify/assert"
@@ -30,7 +32,7 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 
 		logs := logrus.New()
-		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), 100, logs)
+		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), nil, 100, logs)
 
 		req, err := http.NewRequest("GET", "/orchestrations?page_size=1", nil)
 		require.NoError(t, err)
@@ -146,7 +148,7 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 
 		logs := logrus.New()
-		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), 100, logs)
+		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), nil, 100, logs)
 
 		urlPath := fmt.Sprintf("/orchestrations/%s/operations", fixID)
 		req, err := http.NewRequest("GET", urlPath, nil)
@@ -198,7 +200,7 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 
 		logs := logrus.New()
-		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), 100, logs)
+		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), nil, 100, logs)
 
 		req, err := http.NewRequest("PUT", fmt.Sprintf("/orchestrations/%s/cancel", fixID), nil)
 		require.NoError(t, err)
@@ -223,4 +225,81 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, orchestration.Canceling, o.State)
 	})
+
+	t.Run("force fail operation", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+
+		err := db.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+			Operation: internal.Operation{
+				ID:              fixID,
+				InstanceID:      fixID,
+				OrchestrationID: fixID,
+				State:           orchestration.InProgress,
+				ProvisioningParameters: internal.ProvisioningParameters{
+					PlanID: "4deee563-e5ec-4731-b9b1-53b42d855f0c",
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		logs := logrus.New()
+		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), nil, 100, logs)
+		router := mux.NewRouter()
+		router.Use(middleware.AddUserToContext())
+		kymaHandler.AttachRoutes(router)
+
+		body, err := json.Marshal(orchestration.ForceFailRequest{Reason: "shoot is gone", Initiator: "someone@kyma.cx"})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/orchestrations/%s/operations/%s/fail", fixID, fixID), bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set(middleware.AuthenticatedUserHeader, "admin@kyma.cx")
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out orchestration.OperationResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Failed, out.State)
+
+		op, err := db.Operations().GetUpgradeKymaOperationByID(fixID)
+		require.NoError(t, err)
+		require.NotNil(t, op.ForcedFailure)
+		assert.Equal(t, "admin@kyma.cx", op.ForcedFailure.Initiator)
+
+		// given
+		body, err = json.Marshal(orchestration.ForceFailRequest{Reason: "shoot is gone", Initiator: "someone@kyma.cx"})
+		require.NoError(t, err)
+		req, err = http.NewRequest(http.MethodPut, fmt.Sprintf("/orchestrations/%s/operations/%s/fail", fixID, fixID), bytes.NewBuffer(body))
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		op, err = db.Operations().GetUpgradeKymaOperationByID(fixID)
+		require.NoError(t, err)
+		require.NotNil(t, op.ForcedFailure)
+		assert.Equal(t, "someone@kyma.cx (unverified)", op.ForcedFailure.Initiator)
+
+		// given
+		body, err = json.Marshal(orchestration.ForceFailRequest{Initiator: "admin@kyma.cx"})
+		require.NoError(t, err)
+		req, err = http.NewRequest(http.MethodPut, fmt.Sprintf("/orchestrations/%s/operations/%s/fail", fixID, fixID), bytes.NewBuffer(body))
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
 }
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	orchestrationExt "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type Pauser struct {
+	orchestrations storage.Orchestrations
+	operations     storage.Operations
+	queue          *process.Queue
+	log            logrus.FieldLogger
+}
+
+func NewPauser(orchestrations storage.Orchestrations, operations storage.Operations, queue *process.Queue, logger logrus.FieldLogger) *Pauser {
+	return &Pauser{
+		orchestrations: orchestrations,
+		operations:     operations,
+		queue:          queue,
+		log:            logger,
+	}
+}
+
+// PauseOrchestration stops scheduling new operations of the pending or in progress orchestration, the operations in progress are finished
+func (p *Pauser) PauseOrchestration(orchestrationID string) error {
+	o, err := p.orchestrations.GetByID(orchestrationID)
+	if err != nil {
+		return errors.Wrap(err, "while getting orchestration")
+	}
+	if o.IsPaused() {
+		return nil
+	}
+	if o.State != orchestrationExt.Pending && o.State != orchestrationExt.InProgress {
+		return apiErrors.NewBadRequest(fmt.Sprintf("orchestration in state %s cannot be paused", o.State))
+	}
+
+	o.UpdatedAt = time.Now()
+	o.Description = "Orchestration was paused"
+	o.State = orchestrationExt.Paused
+	err = p.orchestrations.Update(*o)
+	if err != nil {
+		return errors.Wrap(err, "while updating orchestration")
+	}
+	return nil
+}
+
+// ResumeOrchestration continues the processing of the paused orchestration with the operations which were not started
+func (p *Pauser) ResumeOrchestration(orchestrationID string) error {
+	o, err := p.orchestrations.GetByID(orchestrationID)
+	if err != nil {
+		return errors.Wrap(err, "while getting orchestration")
+	}
+	if !o.IsPaused() {
+		return apiErrors.NewBadRequest(fmt.Sprintf("orchestration in state %s is not paused", o.State))
+	}
+
+	// the targets of the orchestration paused before scheduling the operations are resolved on resume
+	stats, err := p.operations.GetOperationStatsForOrchestration(orchestrationID)
+	if err != nil {
+		return errors.Wrap(err, "while getting orchestration operation stats")
+	}
+	state := orchestrationExt.Pending
+	for _, count := range stats {
+		if count > 0 {
+			state = orchestrationExt.InProgress
+			break
+		}
+	}
+
+	o.UpdatedAt = time.Now()
+	o.Description = "Orchestration was resumed"
+	o.State = state
+	err = p.orchestrations.Update(*o)
+	if err != nil {
+		return errors.Wrap(err, "while updating orchestration")
+	}
+
+	p.queue.Add(orchestrationID)
+	p.log.Infof("Resuming the processing of paused orchestration ID: %s", orchestrationID)
+	return nil
+}
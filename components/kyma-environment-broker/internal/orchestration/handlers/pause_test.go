@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauser_PauseOrchestration(t *testing.T) {
+	for name, state := range map[string]string{
+		"should pause in progress orchestration": orchestration.InProgress,
+		"should pause pending orchestration":     orchestration.Pending,
+		"already paused":                         orchestration.Paused,
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := storage.NewMemoryStorage()
+			o := fixOrchestration()
+			o.State = state
+			err := s.Orchestrations().Insert(o)
+			require.NoError(t, err)
+
+			p := NewPauser(s.Orchestrations(), s.Operations(), nil, logrus.New())
+
+			err = p.PauseOrchestration(fixOrchestrationID)
+			require.NoError(t, err)
+
+			stored, err := s.Orchestrations().GetByID(fixOrchestrationID)
+			require.NoError(t, err)
+			assert.Equal(t, orchestration.Paused, stored.State)
+		})
+	}
+	t.Run("should return error when orchestration is finished", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		o := fixOrchestration()
+		o.State = orchestration.Succeeded
+		err := s.Orchestrations().Insert(o)
+		require.NoError(t, err)
+
+		p := NewPauser(s.Orchestrations(), s.Operations(), nil, logrus.New())
+
+		err = p.PauseOrchestration(fixOrchestrationID)
+		assert.Error(t, err)
+
+		stored, err := s.Orchestrations().GetByID(fixOrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Succeeded, stored.State)
+	})
+	t.Run("should return error when orchestration not found", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		p := NewPauser(s.Orchestrations(), s.Operations(), nil, logrus.New())
+
+		err := p.PauseOrchestration(fixOrchestrationID)
+		assert.Error(t, err)
+	})
+}
+
+func TestPauser_ResumeOrchestration(t *testing.T) {
+	t.Run("should resume orchestration with scheduled operations", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		o := fixOrchestration()
+		o.State = orchestration.Paused
+		err := s.Orchestrations().Insert(o)
+		require.NoError(t, err)
+		err = s.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+			Operation: internal.Operation{
+				ID:              "op-id",
+				OrchestrationID: fixOrchestrationID,
+				State:           orchestration.Pending,
+			},
+		})
+		require.NoError(t, err)
+
+		q, executed := fixRecordingQueue(t)
+		p := NewPauser(s.Orchestrations(), s.Operations(), q, logrus.New())
+
+		err = p.ResumeOrchestration(fixOrchestrationID)
+		require.NoError(t, err)
+
+		stored, err := s.Orchestrations().GetByID(fixOrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.InProgress, stored.State)
+		assertQueued(t, executed, fixOrchestrationID)
+	})
+	t.Run("should resume orchestration paused before scheduling operations", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		o := fixOrchestration()
+		o.State = orchestration.Paused
+		err := s.Orchestrations().Insert(o)
+		require.NoError(t, err)
+
+		q, executed := fixRecordingQueue(t)
+		p := NewPauser(s.Orchestrations(), s.Operations(), q, logrus.New())
+
+		err = p.ResumeOrchestration(fixOrchestrationID)
+		require.NoError(t, err)
+
+		stored, err := s.Orchestrations().GetByID(fixOrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Pending, stored.State)
+		assertQueued(t, executed, fixOrchestrationID)
+	})
+	t.Run("should return error when orchestration is not paused", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		err := s.Orchestrations().Insert(fixOrchestration())
+		require.NoError(t, err)
+
+		p := NewPauser(s.Orchestrations(), s.Operations(), nil, logrus.New())
+
+		err = p.ResumeOrchestration(fixOrchestrationID)
+		assert.Error(t, err)
+	})
+}
+
+type recordingExecutor struct {
+	executed chan string
+}
+
+func (e *recordingExecutor) Execute(id string) (time.Duration, error) {
+	e.executed <- id
+	return 0, nil
+}
+
+func fixRecordingQueue(t *testing.T) (*process.Queue, <-chan string) {
+	executor := &recordingExecutor{executed: make(chan string, 1)}
+	q := process.NewQueue(executor, logrus.New())
+	stop := make(chan struct{})
+	t.Cleanup(func() {
+		q.ShutDown()
+		close(stop)
+	})
+	q.Run(stop, 1)
+
+	return q, executor.executed
+}
+
+func assertQueued(t *testing.T, executed <-chan string, id string) {
+	select {
+	case got := <-executed:
+		assert.Equal(t, id, got)
+	case <-time.After(time.Second):
+		t.Fatalf("orchestration %s was not queued", id)
+	}
+}
@@ -50,17 +50,26 @@ func (u *upgradeKymaManager) Execute(orchestrationID string) (time.Duration, err
 	if err != nil {
 		return u.failOrchestration(o, errors.Wrap(err, "while getting orchestration"))
 	}
+	// do not perform any action until the orchestration is resumed
+	if o.IsPaused() {
+		logger.Infof("Orchestration is paused, skipping the processing")
+		return 0, nil
+	}
 
 	operations, err := u.resolveOperations(o, o.Parameters)
 	if err != nil {
 		return u.failOrchestration(o, errors.Wrap(err, "while resolving operations"))
 	}
 
-	err = u.orchestrationStorage.Update(*o)
+	paused, err := u.updateUnlessPaused(o)
 	if err != nil {
 		logger.Errorf("while updating orchestration: %v", err)
 		return u.pollingInterval, nil
 	}
+	if paused {
+		logger.Infof("Orchestration was paused while resolving the operations, skipping the processing")
+		return 0, nil
+	}
 	// do not perform any action if the orchestration is finished
 	if o.IsFinished() {
 		u.log.Infof("Orchestration was already finished, state: %s", o.State)
@@ -77,13 +86,22 @@ func (u *upgradeKymaManager) Execute(orchestrationID string) (time.Duration, err
 	if err != nil {
 		return 0, errors.Wrap(err, "while waiting for orchestration to finish")
 	}
+	// the paused orchestration is not updated, so the state set by a concurrent resume is not overwritten
+	if o.IsPaused() {
+		logger.Infof("Paused processing orchestration")
+		return 0, nil
+	}
 
 	o.UpdatedAt = time.Now()
-	err = u.orchestrationStorage.Update(*o)
+	paused, err = u.updateUnlessPaused(o)
 	if err != nil {
 		logger.Errorf("while updating orchestration: %v", err)
 		return u.pollingInterval, nil
 	}
+	if paused {
+		logger.Infof("Orchestration was paused while finishing the processing")
+		return 0, nil
+	}
 
 	logger.Infof("Finished processing orchestration, state: %s", o.State)
 	return 0, nil
@@ -135,13 +153,16 @@ func (u *upgradeKymaManager) resolveOperations(o *internal.Orchestration, params
 					ID: id,
 					Runtime: orchestration.Runtime{
 						ShootName:              r.ShootName,
+						PlanName:               r.PlanName,
 						MaintenanceWindowBegin: windowBegin,
 						MaintenanceWindowEnd:   windowEnd,
 						RuntimeID:              r.RuntimeID,
 						GlobalAccountID:        r.GlobalAccountID,
 						SubAccountID:           r.SubAccountID,
+						TimeWindow:             r.TimeWindow,
 					},
-					DryRun: params.DryRun,
+					DryRun:      params.DryRun,
+					ScheduledAt: params.Strategy.ScheduledAt,
 				},
 			}
 			result = append(result, op)
@@ -175,6 +196,8 @@ func (u *upgradeKymaManager) resolveStrategy(sType orchestration.StrategyType, e
 	switch sType {
 	case orchestration.ParallelStrategy:
 		return strategies.NewParallelOrchestrationStrategy(executor, log)
+	case orchestration.CanaryStrategy:
+		return strategies.NewCanaryOrchestrationStrategy(executor, log)
 	}
 	return nil
 }
@@ -194,12 +217,32 @@ func (u *upgradeKymaManager) filterNotFinishedOperations(ops []internal.UpgradeK
 	return append(inProgress, pending...)
 }
 
-// waitForCompletion waits until processing of given orchestration ends or if it's canceled
+// waitForCompletion waits until processing of given orchestration ends or if it's canceled or paused
 func (u *upgradeKymaManager) waitForCompletion(o *internal.Orchestration, strategy orchestration.Strategy, execID string, log logrus.FieldLogger) (*internal.Orchestration, error) {
 	canceled := false
+	paused := false
+	stopped := false
 	var err error
 	var stats map[string]int
+
+	// the batches of the strategy can stop before the pending operations are executed when the failure threshold is reached or the canary fails
+	executed := make(chan struct{})
+	if o.Parameters.Strategy.Parallel.BatchSize > 0 || o.Parameters.Strategy.Type == orchestration.CanaryStrategy {
+		go func() {
+			strategy.Wait(execID)
+			close(executed)
+		}()
+	}
+
 	err = wait.PollImmediateInfinite(u.pollingInterval, func() (bool, error) {
+		// check before getting the stats, so the stats are not older than the end of the execution
+		finished := false
+		select {
+		case <-executed:
+			finished = true
+		default:
+		}
+
 		// check if orchestration wasn't canceled
 		o, err = u.orchestrationStorage.GetByID(o.OrchestrationID)
 		switch {
@@ -208,6 +251,7 @@ func (u *upgradeKymaManager) waitForCompletion(o *internal.Orchestration, strate
 				log.Info("Orchestration was canceled")
 				canceled = true
 			}
+			paused = o.IsPaused()
 		case dberr.IsNotFound(err):
 			log.Errorf("while getting orchestration: %v", err)
 			return false, err
@@ -232,9 +276,13 @@ func (u *upgradeKymaManager) waitForCompletion(o *internal.Orchestration, strate
 			numberOfNotFinished += numberOfPending
 		}
 
-		// don't wait for pending operations if orchestration was canceled
-		if canceled {
+		// don't wait for pending operations if orchestration was canceled or paused
+		if canceled || paused {
 			return numberOfInProgress == 0, nil
+		} else if finished && numberOfInProgress == 0 && numberOfPending > 0 {
+			log.Infof("Strategy execution stopped with %d pending operations", numberOfPending)
+			stopped = true
+			return true, nil
 		} else {
 			return numberOfNotFinished == 0, nil
 		}
@@ -243,11 +291,37 @@ func (u *upgradeKymaManager) waitForCompletion(o *internal.Orchestration, strate
 		return nil, errors.Wrap(err, "while waiting for scheduled operations to finish")
 	}
 
+	// the pending operations are left to be executed when the orchestration is resumed
+	if paused {
+		log.Infof("Orchestration was paused with %d pending operations", stats[orchestration.Pending])
+		if execID != "" {
+			strategy.Cancel(execID)
+		}
+		return o, nil
+	}
+
+	if stopped && o.State != orchestration.Canceling {
+		err = u.resolveCanceledOperations(o, "Operation was canceled because the orchestration was stopped")
+		if err != nil {
+			return nil, errors.Wrap(err, "while resolving operations of not executed batches")
+		}
+		o.Description = u.stoppedDescription(o.Parameters.Strategy)
+	}
+
 	return u.resolveOrchestration(o, strategy, execID, stats)
 }
+
+// stoppedDescription explains why the strategy stopped before executing all operations
+func (u *upgradeKymaManager) stoppedDescription(spec orchestration.StrategySpec) string {
+	if spec.Type == orchestration.CanaryStrategy {
+		return fmt.Sprintf("stopped after more than %d canary operations failed or a batch reached the failure threshold", spec.Canary.MaxFailures)
+	}
+	return fmt.Sprintf("stopped after a batch reached the failure threshold of %d operations", spec.Parallel.FailureThreshold)
+}
+
 func (u *upgradeKymaManager) resolveOrchestration(o *internal.Orchestration, strategy orchestration.Strategy, execID string, stats map[string]int) (*internal.Orchestration, error) {
 	if o.State == orchestration.Canceling {
-		err := u.resolveCanceledOperations(o)
+		err := u.resolveCanceledOperations(o, "Operation was canceled")
 		if err != nil {
 			return nil, errors.Wrap(err, "while resolving canceled operations")
 		}
@@ -263,14 +337,14 @@ func (u *upgradeKymaManager) resolveOrchestration(o *internal.Orchestration, str
 	return o, nil
 }
 
-func (u *upgradeKymaManager) resolveCanceledOperations(o *internal.Orchestration) error {
+func (u *upgradeKymaManager) resolveCanceledOperations(o *internal.Orchestration, description string) error {
 	ops, _, _, err := u.operationStorage.ListUpgradeKymaOperationsByOrchestrationID(o.OrchestrationID, dbmodel.OperationFilter{States: []string{orchestration.Pending}})
 	if err != nil {
 		return errors.Wrap(err, "while listing upgrade operations")
 	}
 	for _, op := range ops {
 		op.State = orchestration.Canceled
-		op.Description = "Operation was canceled"
+		op.Description = description
 		_, err := u.operationStorage.UpdateUpgradeKymaOperation(op)
 		if err != nil {
 			return errors.Wrap(err, "while updating upgrade kyma operation")
@@ -308,7 +382,7 @@ func (u *upgradeKymaManager) updateOrchestration(o *internal.Orchestration, stat
 	o.UpdatedAt = time.Now()
 	o.State = state
 	o.Description = description
-	err := u.orchestrationStorage.Update(*o)
+	_, err := u.updateUnlessPaused(o)
 	if err != nil {
 		if !dberr.IsNotFound(err) {
 			u.log.Errorf("while updating orchestration: %v", err)
@@ -317,3 +391,17 @@ func (u *upgradeKymaManager) updateOrchestration(o *internal.Orchestration, stat
 	}
 	return 0
 }
+
+// updateUnlessPaused stores the orchestration unless it was paused since it was read, so the pause requested
+// while the orchestration is processed is not overwritten. It returns true if the orchestration was not stored because it is paused.
+func (u *upgradeKymaManager) updateUnlessPaused(o *internal.Orchestration) (bool, error) {
+	stored, err := u.orchestrationStorage.GetByID(o.OrchestrationID)
+	if err != nil {
+		return false, err
+	}
+	if stored.IsPaused() && !o.IsPaused() {
+		return true, nil
+	}
+
+	return false, u.orchestrationStorage.Update(*o)
+}
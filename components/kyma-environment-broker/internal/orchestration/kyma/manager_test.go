@@ -1,6 +1,7 @@
 package kyma_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/kyma"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -207,6 +209,222 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 
 		assert.Equal(t, orchestration.Canceled, string(op.State))
 	})
+
+	t.Run("BatchReachingFailureThreshold", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.InProgress,
+			Parameters: orchestration.Parameters{Strategy: orchestration.StrategySpec{
+				Type:     orchestration.ParallelStrategy,
+				Schedule: orchestration.Immediate,
+				Parallel: orchestration.ParallelStrategySpec{Workers: 1, BatchSize: 1, FailureThreshold: 1},
+			}},
+		})
+		require.NoError(t, err)
+
+		opIDs := []string{"op-1", "op-2", "op-3"}
+		for i, opID := range opIDs {
+			err = store.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+				Operation: internal.Operation{
+					ID:              opID,
+					OrchestrationID: id,
+					CreatedAt:       time.Now().Add(time.Duration(i) * time.Second),
+					State:           orchestration.Pending,
+				},
+				RuntimeOperation: orchestration.RuntimeOperation{ID: opID},
+			})
+			require.NoError(t, err)
+		}
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), &failingExecutor{operations: store.Operations()}, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Failed, o.State)
+
+		states := map[string]int{}
+		for _, opID := range opIDs {
+			op, err := store.Operations().GetUpgradeKymaOperationByID(opID)
+			require.NoError(t, err)
+			states[string(op.State)]++
+		}
+		assert.Equal(t, map[string]int{orchestration.Failed: 1, orchestration.Canceled: 2}, states)
+	})
+
+	t.Run("CanaryFailed", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.InProgress,
+			Parameters: orchestration.Parameters{Strategy: orchestration.StrategySpec{
+				Type:     orchestration.CanaryStrategy,
+				Schedule: orchestration.Immediate,
+				Parallel: orchestration.ParallelStrategySpec{Workers: 1},
+				Canary:   orchestration.CanaryStrategySpec{Size: 1},
+			}},
+		})
+		require.NoError(t, err)
+
+		opIDs := []string{"op-1", "op-2", "op-3"}
+		for i, opID := range opIDs {
+			err = store.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+				Operation: internal.Operation{
+					ID:              opID,
+					OrchestrationID: id,
+					CreatedAt:       time.Now().Add(time.Duration(i) * time.Second),
+					State:           orchestration.Pending,
+				},
+				RuntimeOperation: orchestration.RuntimeOperation{ID: opID},
+			})
+			require.NoError(t, err)
+		}
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), &failingExecutor{operations: store.Operations()}, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Failed, o.State)
+
+		states := map[string]int{}
+		for _, opID := range opIDs {
+			op, err := store.Operations().GetUpgradeKymaOperationByID(opID)
+			require.NoError(t, err)
+			states[string(op.State)]++
+		}
+		assert.Equal(t, map[string]int{orchestration.Failed: 1, orchestration.Canceled: 2}, states)
+	})
+
+	t.Run("PausedWhileResolvingOperations", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{OrchestrationID: id, State: orchestration.Pending})
+		require.NoError(t, err)
+
+		// the orchestration is paused by a concurrent request while its targets are resolved
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+		resolver.On("Resolve", orchestration.TargetSpec{}).Run(func(args mock.Arguments) {
+			o, err := store.Orchestrations().GetByID(id)
+			require.NoError(t, err)
+			o.State = orchestration.Paused
+			require.NoError(t, store.Orchestrations().Update(*o))
+		}).Return([]orchestration.Runtime{}, nil).Once()
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), nil, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Paused, o.State)
+	})
+
+	t.Run("PausedAndResumed", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.InProgress,
+			Parameters: orchestration.Parameters{Strategy: orchestration.StrategySpec{
+				Type:     orchestration.ParallelStrategy,
+				Schedule: orchestration.Immediate,
+				Parallel: orchestration.ParallelStrategySpec{Workers: 1},
+			}},
+		})
+		require.NoError(t, err)
+
+		opIDs := []string{"op-1", "op-2", "op-3"}
+		for i, opID := range opIDs {
+			err = store.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+				Operation: internal.Operation{
+					ID:              opID,
+					OrchestrationID: id,
+					CreatedAt:       time.Now().Add(time.Duration(i) * time.Second),
+					State:           orchestration.Pending,
+				},
+				RuntimeOperation: orchestration.RuntimeOperation{ID: opID},
+			})
+			require.NoError(t, err)
+		}
+
+		// the orchestration is paused while the first operation is in progress
+		executor := &pausingExecutor{operations: store.Operations(), orchestrations: store.Orchestrations(), pauseAfter: "op-1"}
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), executor, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Paused, o.State)
+		assert.Equal(t, map[string]int{orchestration.Succeeded: 1, orchestration.Pending: 2}, operationStates(t, store.Operations(), opIDs))
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, map[string]int{orchestration.Succeeded: 1, orchestration.Pending: 2}, operationStates(t, store.Operations(), opIDs))
+
+		// when
+		o.State = orchestration.InProgress
+		err = store.Orchestrations().Update(*o)
+		require.NoError(t, err)
+
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err = store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Succeeded, o.State)
+		assert.Equal(t, map[string]int{orchestration.Succeeded: 3}, operationStates(t, store.Operations(), opIDs))
+	})
+}
+
+func operationStates(t *testing.T, operations storage.Operations, opIDs []string) map[string]int {
+	states := map[string]int{}
+	for _, opID := range opIDs {
+		op, err := operations.GetUpgradeKymaOperationByID(opID)
+		require.NoError(t, err)
+		states[string(op.State)]++
+	}
+	return states
 }
 
 type testExecutor struct{}
@@ -214,3 +432,51 @@ type testExecutor struct{}
 func (t *testExecutor) Execute(opID string) (time.Duration, error) {
 	return 0, nil
 }
+
+type failingExecutor struct {
+	operations storage.Operations
+}
+
+func (e *failingExecutor) Execute(opID string) (time.Duration, error) {
+	op, err := e.operations.GetUpgradeKymaOperationByID(opID)
+	if err != nil {
+		return 0, err
+	}
+	op.State = orchestration.Failed
+	if _, err := e.operations.UpdateUpgradeKymaOperation(*op); err != nil {
+		return 0, err
+	}
+	return 0, errors.New("upgrade failed")
+}
+
+// pausingExecutor pauses the orchestration when the given operation is executed and does not start the operations of the paused orchestration
+type pausingExecutor struct {
+	operations     storage.Operations
+	orchestrations storage.Orchestrations
+	pauseAfter     string
+}
+
+func (e *pausingExecutor) Execute(opID string) (time.Duration, error) {
+	op, err := e.operations.GetUpgradeKymaOperationByID(opID)
+	if err != nil {
+		return 0, err
+	}
+	o, err := e.orchestrations.GetByID(op.OrchestrationID)
+	if err != nil {
+		return 0, err
+	}
+	if o.IsPaused() {
+		return poolingInterval, nil
+	}
+	if opID == e.pauseAfter {
+		o.State = orchestration.Paused
+		if err := e.orchestrations.Update(*o); err != nil {
+			return 0, err
+		}
+	}
+	op.State = orchestration.Succeeded
+	if _, err := e.operations.UpdateUpgradeKymaOperation(*op); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
@@ -54,6 +54,28 @@ func (rl RuntimeLister) ListAllRuntimes() ([]runtime.RuntimeDTO, error) {
 		}
 		rl.converter.ApplyDeprovisioningOperation(&dto, dOpr)
 
+		ukOprs, err := rl.operationsDb.ListUpgradeKymaOperationsByInstanceID(inst.InstanceID)
+		if err != nil && !dberr.IsNotFound(err) {
+			rl.log.Errorf("while getting upgrade kyma operations for instance %s: %s", inst.InstanceID, err.Error())
+			continue
+		}
+		rl.converter.ApplyKymaVersion(&dto, pOpr, ukOprs)
+
+		// suspension and unsuspension operations decide if the runtime is suspended
+		deprovOprs, err := rl.operationsDb.ListDeprovisioningOperationsByInstanceID(inst.InstanceID)
+		if err != nil && !dberr.IsNotFound(err) {
+			rl.log.Errorf("while getting deprovisioning operations for instance %s: %s", inst.InstanceID, err.Error())
+			continue
+		}
+		rl.converter.ApplySuspensionOperations(&dto, deprovOprs)
+
+		provOprs, err := rl.operationsDb.ListProvisioningOperationsByInstanceID(inst.InstanceID)
+		if err != nil && !dberr.IsNotFound(err) {
+			rl.log.Errorf("while getting provisioning operations for instance %s: %s", inst.InstanceID, err.Error())
+			continue
+		}
+		rl.converter.ApplyUnsuspensionOperations(&dto, provOprs)
+
 		runtimes = append(runtimes, dto)
 	}
 
@@ -70,11 +70,12 @@ func (om *DeprovisionOperationManager) RetryOperationOnce(operation internal.Dep
 
 // RetryOperation retries an operation for at maxTime in retryInterval steps and fails the operation if retrying failed
 func (om *DeprovisionOperationManager) RetryOperation(operation internal.DeprovisioningOperation, errorMessage string, retryInterval time.Duration, maxTime time.Duration, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
-	since := time.Since(operation.UpdatedAt)
+	retriedSince := operation.RetriedSince()
+	since := time.Since(retriedSince)
 
 	log.Infof("Retrying for %s in %s steps, error: %s", maxTime.String(), retryInterval.String(), errorMessage)
 	if since < maxTime {
-		return operation, retryInterval, nil
+		return om.scheduleRetry(operation, retriedSince, retryInterval, log)
 	}
 	log.Errorf("Aborting after %s of failing retries", maxTime.String())
 	return om.OperationFailed(operation, errorMessage)
@@ -82,12 +83,13 @@ func (om *DeprovisionOperationManager) RetryOperation(operation internal.Deprovi
 
 // RetryOperationWithoutFail retries an operation for at maxTime in retryInterval steps and omits the operation if retrying failed
 func (om *DeprovisionOperationManager) RetryOperationWithoutFail(operation internal.DeprovisioningOperation, description string, retryInterval, maxTime time.Duration, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
-	since := time.Since(operation.UpdatedAt)
+	retriedSince := operation.RetriedSince()
+	since := time.Since(retriedSince)
 
 	log.Infof("Retry Operation was triggered with message: %s", description)
 	log.Infof("Retrying for %s in %s steps", maxTime.String(), retryInterval.String())
 	if since < maxTime {
-		return operation, retryInterval, nil
+		return om.scheduleRetry(operation, retriedSince, retryInterval, log)
 	}
 	// update description to track failed steps
 	updatedOperation, repeat := om.update(operation, domain.InProgress, description)
@@ -99,9 +101,25 @@ func (om *DeprovisionOperationManager) RetryOperationWithoutFail(operation inter
 	return updatedOperation, 0, nil
 }
 
+// scheduleRetry stores the retry of the operation in retryInterval, so the retry is resumed after a restart
+func (om *DeprovisionOperationManager) scheduleRetry(operation internal.DeprovisioningOperation, retriedSince time.Time, retryInterval time.Duration, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	// the storage increments the version of the updated operation
+	operation.ScheduledRetry = internal.ScheduledRetry{At: time.Now().Add(retryInterval), Since: retriedSince, Version: operation.Version + 1}
+	updatedOperation, err := om.storage.UpdateDeprovisioningOperation(operation)
+	if err != nil {
+		// the operation is retried anyway, only a restart before the retry resumes it right away
+		log.Warnf("while storing the retry of the operation: %s", err)
+		return operation, retryInterval, nil
+	}
+	return *updatedOperation, retryInterval, nil
+}
+
 func (om *DeprovisionOperationManager) update(operation internal.DeprovisioningOperation, state domain.LastOperationState, description string) (internal.DeprovisioningOperation, time.Duration) {
 	operation.State = state
 	operation.Description = fmt.Sprintf("%s : %s", operation.Description, description)
+	if state == domain.Succeeded || state == domain.Failed {
+		operation.SLABreached = internal.IsSLABreached(operation.CreatedAt, time.Now(), internal.DeprovisioningSLA)
+	}
 
 	updatedOperation, err := om.storage.UpdateDeprovisioningOperation(operation)
 	// repeat if there is a problem with the storage
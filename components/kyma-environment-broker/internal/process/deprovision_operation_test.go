@@ -19,6 +19,7 @@ func Test_Deprovision_RetryOperationOnce(t *testing.T) {
 	operations := memory.Operations()
 	opManager := NewDeprovisionOperationManager(operations)
 	op := internal.DeprovisioningOperation{}
+	op.ID = "operation-id"
 	op.UpdatedAt = time.Now()
 	retryInterval := time.Hour
 	errorMessage := fmt.Sprintf("ups ... ")
@@ -37,6 +38,7 @@ func Test_Deprovision_RetryOperationOnce(t *testing.T) {
 	// then - second call
 	t.Log(op.UpdatedAt.String())
 	op.UpdatedAt = op.UpdatedAt.Add(-retryInterval - time.Second) // simulate wait of first retry
+	op.ScheduledRetry.Since = op.ScheduledRetry.Since.Add(-retryInterval - time.Second)
 	t.Log(op.UpdatedAt.String())
 	op, when, err = opManager.RetryOperationOnce(op, errorMessage, retryInterval, fixLogger())
 
@@ -51,6 +53,7 @@ func Test_Deprovision_RetryOperationWithoutFail(t *testing.T) {
 	operations := memory.Operations()
 	opManager := NewDeprovisionOperationManager(operations)
 	op := internal.DeprovisioningOperation{}
+	op.ID = "operation-id"
 	op.UpdatedAt = time.Now()
 	retryInterval := time.Hour
 	errorMessage := fmt.Sprintf("ups ... ")
@@ -69,6 +72,7 @@ func Test_Deprovision_RetryOperationWithoutFail(t *testing.T) {
 	// then - second call
 	t.Log(op.UpdatedAt.String())
 	op.UpdatedAt = op.UpdatedAt.Add(-retryInterval - time.Second) // simulate wait of first retry
+	op.ScheduledRetry.Since = op.ScheduledRetry.Since.Add(-retryInterval - time.Second)
 	t.Log(op.UpdatedAt.String())
 	op, when, err = opManager.RetryOperationWithoutFail(op, errorMessage, retryInterval, retryInterval+1, fixLogger())
 
@@ -83,6 +87,7 @@ func Test_Deprovision_RetryOperation(t *testing.T) {
 	operations := memory.Operations()
 	opManager := NewDeprovisionOperationManager(operations)
 	op := internal.DeprovisioningOperation{}
+	op.ID = "operation-id"
 	op.UpdatedAt = time.Now()
 	retryInterval := time.Hour
 	errorMessage := fmt.Sprintf("ups ... ")
@@ -102,6 +107,7 @@ func Test_Deprovision_RetryOperation(t *testing.T) {
 	// then - second call
 	t.Log(op.UpdatedAt.String())
 	op.UpdatedAt = op.UpdatedAt.Add(-retryInterval - time.Second) // simulate wait of first retry
+	op.ScheduledRetry.Since = op.ScheduledRetry.Since.Add(-retryInterval - time.Second)
 	t.Log(op.UpdatedAt.String())
 	op, when, err = opManager.RetryOperation(op, errorMessage, retryInterval, maxtime, fixLogger())
 
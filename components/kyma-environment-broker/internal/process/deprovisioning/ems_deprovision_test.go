@@ -20,6 +20,7 @@ func TestEmsDeprovisionStep_Run(t *testing.T) {
 
 	operation := internal.DeprovisioningOperation{
 		Operation: internal.Operation{
+			ID: "operation-id",
 			InstanceDetails: internal.InstanceDetails{
 				Ems: internal.EmsData{
 					Instance: internal.ServiceManagerInstanceInfo{
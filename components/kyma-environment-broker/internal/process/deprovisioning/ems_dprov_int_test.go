@@ -51,7 +51,7 @@ func TestEmsDeprovisioningSteps(t *testing.T) {
 		},
 	}
 	operation := internal.DeprovisioningOperation{
-		Operation:       internal.Operation{ProvisioningParameters: pp},
+		Operation:       internal.Operation{ID: "operation-id", ProvisioningParameters: pp},
 		SMClientFactory: cliFactory,
 		Ems: internal.EmsData{
 			Instance: internal.ServiceManagerInstanceInfo{
@@ -21,6 +21,7 @@ func TestEmsUnbindStep_Run(t *testing.T) {
 
 	operation := internal.DeprovisioningOperation{
 		Operation: internal.Operation{
+			ID: "operation-id",
 			InstanceDetails: internal.InstanceDetails{
 				Ems: internal.EmsData{
 					Instance: internal.ServiceManagerInstanceInfo{
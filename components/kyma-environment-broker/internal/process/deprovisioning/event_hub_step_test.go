@@ -369,7 +369,7 @@ func fixDeprovisioningOperationWithParameters() internal.DeprovisioningOperation
 
 func fixDeprovisioningOperationWithDeletedEventHub() internal.DeprovisioningOperation {
 	return internal.DeprovisioningOperation{
-		Operation: internal.Operation{InstanceDetails: internal.InstanceDetails{
+		Operation: internal.Operation{ID: fixOperationID, InstanceDetails: internal.InstanceDetails{
 			EventHub: internal.EventHub{
 				Deleted: true,
 			},
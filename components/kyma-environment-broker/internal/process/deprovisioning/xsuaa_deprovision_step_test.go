@@ -21,6 +21,7 @@ func TestXSUAADeprovisionStep_Run(t *testing.T) {
 
 	operation := internal.DeprovisioningOperation{
 		Operation: internal.Operation{
+			ID:                     "operation-id",
 			ProvisioningParameters: internal.ProvisioningParameters{},
 			InstanceDetails: internal.InstanceDetails{
 				XSUAA: internal.XSUAAData{
@@ -50,7 +50,7 @@ func TestDeprovisioningSteps(t *testing.T) {
 		},
 	}
 	operation := internal.DeprovisioningOperation{
-		Operation:       internal.Operation{ProvisioningParameters: pp},
+		Operation:       internal.Operation{ID: "operation-id", ProvisioningParameters: pp},
 		SMClientFactory: cliFactory,
 		XSUAA: internal.XSUAAData{
 			Instance: internal.ServiceManagerInstanceInfo{
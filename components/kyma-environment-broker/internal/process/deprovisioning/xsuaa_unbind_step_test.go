@@ -21,6 +21,7 @@ func TestXSUAAUnbindStep_Run(t *testing.T) {
 
 	operation := internal.DeprovisioningOperation{
 		Operation: internal.Operation{
+			ID:                     "operation-id",
 			ProvisioningParameters: internal.ProvisioningParameters{},
 			InstanceDetails: internal.InstanceDetails{XSUAA: internal.XSUAAData{
 				Instance: internal.ServiceManagerInstanceInfo{
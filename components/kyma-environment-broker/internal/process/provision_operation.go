@@ -59,20 +59,37 @@ func (om *ProvisionOperationManager) RetryOperationOnce(operation internal.Provi
 
 // RetryOperation retries an operation for at maxTime in retryInterval steps and fails the operation if retrying failed
 func (om *ProvisionOperationManager) RetryOperation(operation internal.ProvisioningOperation, errorMessage string, retryInterval time.Duration, maxTime time.Duration, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
-	since := time.Since(operation.UpdatedAt)
+	retriedSince := operation.RetriedSince()
+	since := time.Since(retriedSince)
 
 	log.Infof("Retry Operation was triggered with message: %s", errorMessage)
 	log.Infof("Retrying for %s in %s steps", maxTime.String(), retryInterval.String())
 	if since < maxTime {
-		return operation, retryInterval, nil
+		return om.scheduleRetry(operation, retriedSince, retryInterval, log)
 	}
 	log.Errorf("Aborting after %s of failing retries", maxTime.String())
 	return om.OperationFailed(operation, errorMessage)
 }
 
+// scheduleRetry stores the retry of the operation in retryInterval, so the retry is resumed after a restart
+func (om *ProvisionOperationManager) scheduleRetry(operation internal.ProvisioningOperation, retriedSince time.Time, retryInterval time.Duration, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	// the storage increments the version of the updated operation
+	operation.ScheduledRetry = internal.ScheduledRetry{At: time.Now().Add(retryInterval), Since: retriedSince, Version: operation.Version + 1}
+	updatedOperation, err := om.storage.UpdateProvisioningOperation(operation)
+	if err != nil {
+		// the operation is retried anyway, only a restart before the retry resumes it right away
+		log.Warnf("while storing the retry of the operation: %s", err)
+		return operation, retryInterval, nil
+	}
+	return *updatedOperation, retryInterval, nil
+}
+
 func (om *ProvisionOperationManager) update(operation internal.ProvisioningOperation, state domain.LastOperationState, description string) (internal.ProvisioningOperation, time.Duration) {
 	operation.State = state
 	operation.Description = fmt.Sprintf("%s : %s", operation.Description, description)
+	if state == domain.Succeeded || state == domain.Failed {
+		operation.SLABreached = internal.IsSLABreached(operation.CreatedAt, time.Now(), internal.ProvisioningSLA)
+	}
 
 	return om.UpdateOperation(operation)
 }
@@ -18,6 +18,7 @@ func Test_Provision_RetryOperationOnce(t *testing.T) {
 	operations := memory.Operations()
 	opManager := NewProvisionOperationManager(operations)
 	op := internal.ProvisioningOperation{}
+	op.ID = "operation-id"
 	op.UpdatedAt = time.Now()
 	retryInterval := time.Hour
 	errorMessage := fmt.Sprintf("ups ... ")
@@ -36,6 +37,7 @@ func Test_Provision_RetryOperationOnce(t *testing.T) {
 	// then - second call
 	t.Log(op.UpdatedAt.String())
 	op.UpdatedAt = op.UpdatedAt.Add(-retryInterval - time.Second) // simulate wait of first retry
+	op.ScheduledRetry.Since = op.ScheduledRetry.Since.Add(-retryInterval - time.Second)
 	t.Log(op.UpdatedAt.String())
 	op, when, err = opManager.RetryOperationOnce(op, errorMessage, retryInterval, fixLogger())
 
@@ -50,6 +52,7 @@ func Test_Provision_RetryOperation(t *testing.T) {
 	operations := memory.Operations()
 	opManager := NewProvisionOperationManager(operations)
 	op := internal.ProvisioningOperation{}
+	op.ID = "operation-id"
 	op.UpdatedAt = time.Now()
 	retryInterval := time.Hour
 	errorMessage := fmt.Sprintf("ups ... ")
@@ -69,6 +72,7 @@ func Test_Provision_RetryOperation(t *testing.T) {
 	// then - second call
 	t.Log(op.UpdatedAt.String())
 	op.UpdatedAt = op.UpdatedAt.Add(-retryInterval - time.Second) // simulate wait of first retry
+	op.ScheduledRetry.Since = op.ScheduledRetry.Since.Add(-retryInterval - time.Second)
 	t.Log(op.UpdatedAt.String())
 	op, when, err = opManager.RetryOperation(op, errorMessage, retryInterval, maxtime, fixLogger())
 
@@ -76,3 +80,64 @@ func Test_Provision_RetryOperation(t *testing.T) {
 	assert.True(t, when > 0)
 	assert.Nil(t, err)
 }
+
+func Test_Provision_OperationSucceededMarksSLA(t *testing.T) {
+	for name, tc := range map[string]struct {
+		duration time.Duration
+		breached bool
+	}{
+		"within SLA":   {duration: internal.ProvisioningSLA - time.Minute, breached: false},
+		"breached SLA": {duration: internal.ProvisioningSLA + time.Minute, breached: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			operations := storage.NewMemoryStorage().Operations()
+			opManager := NewProvisionOperationManager(operations)
+			op := internal.ProvisioningOperation{}
+			op.ID = "op-id"
+			op.CreatedAt = time.Now().Add(-tc.duration)
+
+			err := operations.InsertProvisioningOperation(op)
+			require.NoError(t, err)
+
+			// when
+			op, when, err := opManager.OperationSucceeded(op, "done")
+
+			// then
+			require.NoError(t, err)
+			assert.Zero(t, when)
+			assert.Equal(t, tc.breached, op.SLABreached)
+		})
+	}
+}
+
+func Test_Provision_RetryOperation_StoresScheduledRetry(t *testing.T) {
+	// given
+	memory := storage.NewMemoryStorage()
+	operations := memory.Operations()
+	opManager := NewProvisionOperationManager(operations)
+	op := internal.ProvisioningOperation{}
+	op.ID = "operation-id"
+	op.UpdatedAt = time.Now().Add(-time.Minute)
+	retryInterval := time.Hour
+	maxtime := time.Hour * 3
+
+	err := operations.InsertProvisioningOperation(op)
+	require.NoError(t, err)
+
+	// when
+	op, when, err := opManager.RetryOperation(op, "ups ... ", retryInterval, maxtime, fixLogger())
+	require.NoError(t, err)
+	require.Equal(t, retryInterval, when)
+	op, _, err = opManager.RetryOperation(op, "ups ... ", retryInterval, maxtime, fixLogger())
+	require.NoError(t, err)
+
+	// then
+	stored, err := operations.GetProvisioningOperationByID(op.ID)
+	require.NoError(t, err)
+	at, scheduled := stored.NextRetry()
+	assert.True(t, scheduled)
+	assert.True(t, at.After(time.Now().Add(retryInterval-time.Minute)))
+	// the retries are limited from the first retry, not from the last update
+	assert.Equal(t, op.UpdatedAt, stored.RetriedSince())
+}
@@ -31,6 +31,7 @@ func TestAuditLog_ScriptFileDoesNotExist(t *testing.T) {
 
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
+			ID:                     "operation-id",
 			ProvisioningParameters: internal.ProvisioningParameters{ErsContext: internal.ERSContext{SubAccountID: "1234567890"}},
 		},
 	}
@@ -147,6 +148,7 @@ return "fooBar"
 	operation := internal.ProvisioningOperation{
 		InputCreator: inputCreatorMock,
 		Operation: internal.Operation{
+			ID:                     "operation-id",
 			ProvisioningParameters: internal.ProvisioningParameters{ErsContext: internal.ERSContext{SubAccountID: "1234567890"}},
 		},
 	}
@@ -261,6 +263,7 @@ return "fooBar"
 	operation := internal.ProvisioningOperation{
 		InputCreator: inputCreatorMock,
 		Operation: internal.Operation{
+			ID:                     "operation-id",
 			ProvisioningParameters: internal.ProvisioningParameters{ErsContext: internal.ERSContext{SubAccountID: "1234567890"}},
 		},
 	}
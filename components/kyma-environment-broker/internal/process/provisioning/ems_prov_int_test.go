@@ -54,7 +54,7 @@ func TestEmsProvisioningSteps(t *testing.T) {
 		},
 	}
 	operation := internal.ProvisioningOperation{
-		Operation:       internal.Operation{ProvisioningParameters: pp},
+		Operation:       internal.Operation{ID: "operation-id", ProvisioningParameters: pp},
 		SMClientFactory: cliFactory,
 	}
 	simpleInputCreator := newInputCreator()
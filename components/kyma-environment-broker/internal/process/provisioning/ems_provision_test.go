@@ -22,6 +22,7 @@ func TestEmsProvisioningStep_Run(t *testing.T) {
 	clientFactory.SynchronousProvisioning()
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
+			ID: "operation-id",
 			InstanceDetails: internal.InstanceDetails{
 				Ems: internal.EmsData{Instance: internal.ServiceManagerInstanceInfo{
 					BrokerID:  "broker-id",
@@ -20,6 +20,7 @@ func TestCertStep_RunFreshOperation(t *testing.T) {
 	// a fresh operation
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
+			ID:              "operation-id",
 			InstanceDetails: internal.InstanceDetails{Lms: internal.LMS{}},
 		},
 	}
@@ -38,6 +39,7 @@ func TestCertStep_Run(t *testing.T) {
 	svc := NewLmsCertificatesStep(cli, repo, false)
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
+			ID:                     "operation-id",
 			ProvisioningParameters: internal.ProvisioningParameters{},
 			InstanceDetails: internal.InstanceDetails{Lms: internal.LMS{
 				TenantID: tID,
@@ -66,6 +68,7 @@ func TestCertStep_TenantNotReady(t *testing.T) {
 		svc := NewLmsCertificatesStep(cli, repo, isMandatory)
 		operation := internal.ProvisioningOperation{
 			Operation: internal.Operation{
+				ID:                     "operation-id",
 				ProvisioningParameters: internal.ProvisioningParameters{},
 				InstanceDetails: internal.InstanceDetails{Lms: internal.LMS{
 					TenantID:    tID,
@@ -96,6 +99,7 @@ func TestCertStep_TenantNotReadyTimeout(t *testing.T) {
 		svc := NewLmsCertificatesStep(cli, repo, isMandatory)
 		operation := internal.ProvisioningOperation{
 			Operation: internal.Operation{
+				ID:                     "operation-id",
 				ProvisioningParameters: internal.ProvisioningParameters{},
 				InstanceDetails: internal.InstanceDetails{Lms: internal.LMS{
 					TenantID:    tID,
@@ -130,6 +134,7 @@ func TestLmsStepsHappyPath(t *testing.T) {
 	inputCreator := newInputCreator()
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
+			ID:                     "operation-id",
 			ProvisioningParameters: internal.ProvisioningParameters{},
 			InstanceDetails:        internal.InstanceDetails{Lms: internal.LMS{}},
 		},
@@ -20,6 +20,7 @@ func TestProvideLmsTenantStep_TenantProviderWithRetry(t *testing.T) {
 	inputCreator := newInputCreator()
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
+			ID:                     "operation-id",
 			UpdatedAt:              now,
 			ProvisioningParameters: internal.ProvisioningParameters{Parameters: internal.ProvisioningParametersDTO{Name: "awesome"}},
 			InstanceDetails:        internal.InstanceDetails{Lms: internal.LMS{}},
@@ -46,6 +47,7 @@ func TestProvideLmsTenantStep_TenantProviderWithError(t *testing.T) {
 		inputCreator := newInputCreator()
 		operation := internal.ProvisioningOperation{
 			Operation: internal.Operation{
+				ID:                     "operation-id",
 				UpdatedAt:              now,
 				ProvisioningParameters: internal.ProvisioningParameters{Parameters: internal.ProvisioningParametersDTO{Name: "awesome"}},
 				InstanceDetails:        internal.InstanceDetails{Lms: internal.LMS{}},
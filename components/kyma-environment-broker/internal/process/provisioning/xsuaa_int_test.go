@@ -57,7 +57,7 @@ func TestProvisioningSteps(t *testing.T) {
 		},
 	}
 	operation := internal.ProvisioningOperation{
-		Operation:       internal.Operation{ProvisioningParameters: pp},
+		Operation:       internal.Operation{ID: "operation-id", ProvisioningParameters: pp},
 		SMClientFactory: cliFactory,
 		ShootDomain:     "uaa-test.kyma-dev.shoot.canary.k8s-hana.ondemand.com",
 	}
@@ -28,6 +28,7 @@ func TestXSUAAProvisioningStep_Run(t *testing.T) {
 	clientFactory.SynchronousProvisioning()
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
+			ID:                     "operation-id",
 			ProvisioningParameters: internal.ProvisioningParameters{},
 			InstanceDetails: internal.InstanceDetails{
 				XSUAA: internal.XSUAAData{Instance: internal.ServiceManagerInstanceInfo{
@@ -1,10 +1,12 @@
 package process
 
 import (
+	"context"
 	"runtime/debug"
 	"sync"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
@@ -19,6 +21,9 @@ type Queue struct {
 	executor  Executor
 	waitGroup sync.WaitGroup
 	log       logrus.FieldLogger
+
+	drain     chan struct{}
+	drainOnce sync.Once
 }
 
 func NewQueue(executor Executor, log logrus.FieldLogger) *Queue {
@@ -27,6 +32,7 @@ func NewQueue(executor Executor, log logrus.FieldLogger) *Queue {
 		executor:  executor,
 		waitGroup: sync.WaitGroup{},
 		log:       log,
+		drain:     make(chan struct{}),
 	}
 }
 
@@ -42,21 +48,54 @@ func (q *Queue) ShutDown() {
 	q.queue.ShutDown()
 }
 
+// Drain stops the workers from taking the queued operations and waits until the operations in progress are processed
+// or the context is done. The queue is shut down, so the added operations and the scheduled retries are dropped,
+// they are resumed from the storage on start.
+func (q *Queue) Drain(ctx context.Context) error {
+	q.drainOnce.Do(func() {
+		close(q.drain)
+	})
+	q.queue.ShutDown()
+
+	drained := make(chan struct{})
+	go func() {
+		q.waitGroup.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "while waiting for the operations in progress")
+	}
+}
+
 func (q *Queue) Run(stop <-chan struct{}, workersAmount int) {
+	// the workers stop when the queue is drained as well
+	stopCh := make(chan struct{})
+	go func() {
+		select {
+		case <-stop:
+		case <-q.drain:
+		}
+		close(stopCh)
+	}()
+
 	for i := 0; i < workersAmount; i++ {
 		q.waitGroup.Add(1)
-		createWorker(q.queue, q.executor.Execute, stop, &q.waitGroup, q.log)
+		createWorker(q.queue, q.executor.Execute, stopCh, q.drain, &q.waitGroup, q.log)
 	}
 }
 
-func createWorker(queue workqueue.RateLimitingInterface, process func(id string) (time.Duration, error), stopCh <-chan struct{}, waitGroup *sync.WaitGroup, log logrus.FieldLogger) {
+func createWorker(queue workqueue.RateLimitingInterface, process func(id string) (time.Duration, error), stopCh, drain <-chan struct{}, waitGroup *sync.WaitGroup, log logrus.FieldLogger) {
 	go func() {
-		wait.Until(worker(queue, process, log), time.Second, stopCh)
+		wait.Until(worker(queue, process, drain, log), time.Second, stopCh)
 		waitGroup.Done()
 	}()
 }
 
-func worker(queue workqueue.RateLimitingInterface, process func(key string) (time.Duration, error), log logrus.FieldLogger) func() {
+func worker(queue workqueue.RateLimitingInterface, process func(key string) (time.Duration, error), drain <-chan struct{}, log logrus.FieldLogger) func() {
 	return func() {
 		exit := false
 		for !exit {
@@ -65,6 +104,13 @@ func worker(queue workqueue.RateLimitingInterface, process func(key string) (tim
 				if quit {
 					return true
 				}
+				// the queued operations are not processed after the drain started
+				select {
+				case <-drain:
+					queue.Done(key)
+					return true
+				default:
+				}
 				id := key.(string)
 				log = log.WithField("operationID", id)
 				defer func() {
@@ -0,0 +1,105 @@
+package process
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingExecutor blocks the execution of every operation until it is released
+type blockingExecutor struct {
+	started  chan string
+	release  chan struct{}
+	mu       sync.Mutex
+	finished []string
+}
+
+func newBlockingExecutor() *blockingExecutor {
+	return &blockingExecutor{
+		started: make(chan string, 10),
+		release: make(chan struct{}),
+	}
+}
+
+func (e *blockingExecutor) Execute(operationID string) (time.Duration, error) {
+	e.started <- operationID
+	<-e.release
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.finished = append(e.finished, operationID)
+	return 0, nil
+}
+
+func (e *blockingExecutor) finishedOperations() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string{}, e.finished...)
+}
+
+func (e *blockingExecutor) waitForStart(t *testing.T, operationID string) {
+	select {
+	case id := <-e.started:
+		require.Equal(t, operationID, id)
+	case <-time.After(time.Second):
+		t.Fatalf("operation %s was not started", operationID)
+	}
+}
+
+func TestQueue_Drain(t *testing.T) {
+	// given
+	executor := newBlockingExecutor()
+	queue := NewQueue(executor, fixLogger())
+	queue.Run(make(chan struct{}), 1)
+
+	queue.Add("op-1")
+	executor.waitForStart(t, "op-1")
+	queue.Add("op-2")
+
+	// when
+	drained := make(chan error)
+	go func() {
+		drained <- queue.Drain(context.Background())
+	}()
+	// the operations added while draining are dropped
+	time.Sleep(10 * time.Millisecond)
+	queue.Add("op-3")
+	close(executor.release)
+
+	// then
+	select {
+	case err := <-drained:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("queue was not drained")
+	}
+	assert.Equal(t, []string{"op-1"}, executor.finishedOperations())
+	assert.Empty(t, executor.started)
+}
+
+func TestQueue_DrainDeadline(t *testing.T) {
+	// given
+	executor := newBlockingExecutor()
+	defer close(executor.release)
+	queue := NewQueue(executor, fixLogger())
+	queue.Run(make(chan struct{}), 1)
+
+	queue.Add("op-1")
+	executor.waitForStart(t, "op-1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// when
+	err := queue.Drain(ctx)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+	assert.Empty(t, executor.finishedOperations())
+}
@@ -0,0 +1,83 @@
+package process
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Enqueuer schedules the processing of operations, it is implemented by Queue
+type Enqueuer interface {
+	Add(processId string)
+	AddAfter(processId string, duration time.Duration)
+}
+
+// OperationsResumer enqueues the operations which were in progress when the process stopped
+type OperationsResumer struct {
+	operations storage.Operations
+	queues     map[dbmodel.OperationType]Enqueuer
+	log        logrus.FieldLogger
+
+	mu      sync.Mutex
+	resumed map[string]struct{}
+}
+
+// NewOperationsResumer creates an OperationsResumer, the queues of the resumed operation types are registered with WithQueue
+func NewOperationsResumer(operations storage.Operations, log logrus.FieldLogger) *OperationsResumer {
+	return &OperationsResumer{
+		operations: operations,
+		queues:     map[dbmodel.OperationType]Enqueuer{},
+		log:        log,
+		resumed:    map[string]struct{}{},
+	}
+}
+
+// WithQueue registers the queue which processes the operations of the given type
+func (r *OperationsResumer) WithQueue(opType dbmodel.OperationType, queue Enqueuer) *OperationsResumer {
+	r.queues[opType] = queue
+	return r
+}
+
+// ResumePendingOperations enqueues the in progress operations of all registered types.
+// Every operation is enqueued only once, so the operations already picked up by a previous sweep are not processed twice.
+// The operations with a retry scheduled in the future are enqueued with the remaining delay of the retry.
+func (r *OperationsResumer) ResumePendingOperations(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, opType := range []dbmodel.OperationType{dbmodel.OperationTypeProvision, dbmodel.OperationTypeDeprovision} {
+		queue, ok := r.queues[opType]
+		if !ok {
+			continue
+		}
+		operations, err := r.operations.GetNotFinishedOperationsByType(opType)
+		if err != nil {
+			return errors.Wrapf(err, "while getting in progress %s operations from storage", opType)
+		}
+		for _, operation := range operations {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if _, found := r.resumed[operation.ID]; found {
+				continue
+			}
+			r.resumed[operation.ID] = struct{}{}
+			if at, scheduled := operation.NextRetry(); scheduled && time.Now().Before(at) {
+				delay := time.Until(at)
+				queue.AddAfter(operation.ID, delay)
+				r.log.Infof("Resuming the processing of %s operation ID: %s in %s", opType, operation.ID, delay)
+				continue
+			}
+			queue.Add(operation.ID)
+			r.log.Infof("Resuming the processing of %s operation ID: %s", opType, operation.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package process
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingQueue struct {
+	ids    []string
+	delays map[string]time.Duration
+}
+
+func (q *recordingQueue) Add(processId string) {
+	q.ids = append(q.ids, processId)
+}
+
+func (q *recordingQueue) AddAfter(processId string, duration time.Duration) {
+	q.ids = append(q.ids, processId)
+	if q.delays == nil {
+		q.delays = map[string]time.Duration{}
+	}
+	q.delays[processId] = duration
+}
+
+func TestOperationsResumer_ResumePendingOperations(t *testing.T) {
+	// given
+	memory := storage.NewMemoryStorage()
+	operations := memory.Operations()
+	for id, state := range map[string]domain.LastOperationState{
+		"prov-in-progress": domain.InProgress,
+		"prov-succeeded":   domain.Succeeded,
+		"prov-failed":      domain.Failed,
+	} {
+		err := operations.InsertProvisioningOperation(internal.ProvisioningOperation{Operation: internal.Operation{ID: id, State: state}})
+		require.NoError(t, err)
+	}
+	err := operations.InsertDeprovisioningOperation(internal.DeprovisioningOperation{Operation: internal.Operation{ID: "deprov-in-progress", State: domain.InProgress}})
+	require.NoError(t, err)
+
+	provisionQueue := &recordingQueue{}
+	deprovisionQueue := &recordingQueue{}
+	resumer := NewOperationsResumer(operations, fixLogger()).
+		WithQueue(dbmodel.OperationTypeProvision, provisionQueue).
+		WithQueue(dbmodel.OperationTypeDeprovision, deprovisionQueue)
+
+	// when
+	err = resumer.ResumePendingOperations(context.Background())
+	require.NoError(t, err)
+	// the sweep is repeated, e.g. when the startup is retried
+	err = resumer.ResumePendingOperations(context.Background())
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, []string{"prov-in-progress"}, provisionQueue.ids)
+	assert.Equal(t, []string{"deprov-in-progress"}, deprovisionQueue.ids)
+}
+
+func TestOperationsResumer_ResumePendingOperationsCanceled(t *testing.T) {
+	// given
+	memory := storage.NewMemoryStorage()
+	operations := memory.Operations()
+	err := operations.InsertProvisioningOperation(internal.ProvisioningOperation{Operation: internal.Operation{ID: "prov-in-progress", State: domain.InProgress}})
+	require.NoError(t, err)
+
+	queue := &recordingQueue{}
+	resumer := NewOperationsResumer(operations, fixLogger()).WithQueue(dbmodel.OperationTypeProvision, queue)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err = resumer.ResumePendingOperations(ctx)
+
+	// then
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, queue.ids)
+}
+
+func TestOperationsResumer_ResumePendingOperationsWithScheduledRetry(t *testing.T) {
+	// given
+	memory := storage.NewMemoryStorage()
+	operations := memory.Operations()
+	for _, op := range []internal.Operation{
+		{ID: "future-retry", State: domain.InProgress, Version: 2, ScheduledRetry: internal.ScheduledRetry{At: time.Now().Add(time.Hour), Version: 2}},
+		{ID: "past-retry", State: domain.InProgress, Version: 2, ScheduledRetry: internal.ScheduledRetry{At: time.Now().Add(-time.Hour), Version: 2}},
+		// the operation was updated after the retry was scheduled
+		{ID: "stale-retry", State: domain.InProgress, Version: 3, ScheduledRetry: internal.ScheduledRetry{At: time.Now().Add(time.Hour), Version: 2}},
+	} {
+		err := operations.InsertProvisioningOperation(internal.ProvisioningOperation{Operation: op})
+		require.NoError(t, err)
+	}
+
+	queue := &recordingQueue{}
+	resumer := NewOperationsResumer(operations, fixLogger()).WithQueue(dbmodel.OperationTypeProvision, queue)
+
+	// when
+	err := resumer.ResumePendingOperations(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"future-retry", "past-retry", "stale-retry"}, queue.ids)
+	require.Len(t, queue.delays, 1)
+	assert.True(t, queue.delays["future-retry"] > 59*time.Minute)
+	assert.True(t, queue.delays["future-retry"] <= time.Hour)
+}
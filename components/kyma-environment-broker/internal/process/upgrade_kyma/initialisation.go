@@ -31,6 +31,13 @@ const (
 	CheckStatusTimeout = 3 * time.Hour
 )
 
+// the progress of the upgrade operation in percent after each of its stages, the operation is at 100 once it succeeded
+const (
+	progressInputCreated     = 25
+	progressUpgradeTriggered = 50
+	progressUpgradeRunning   = 75
+)
+
 type InitialisationStep struct {
 	operationManager       *process.UpgradeKymaOperationManager
 	operationStorage       storage.Operations
@@ -80,6 +87,12 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 		return s.operationManager.OperationCanceled(operation, fmt.Sprintf("orchestration %s was canceled", operation.OrchestrationID))
 	}
 	if operation.State == orchestrationExt.Pending {
+		// the operation is not started until the orchestration is resumed, the operations in progress are continued
+		if orchestration.IsPaused() {
+			log.Infof("Upgrade operation %s is deferred because orchestration %s is paused", operation.Operation.ID, operation.OrchestrationID)
+			operation.DeferralReason = internal.DeferralReasonOrchestrationPaused
+			return operation, s.timeSchedule.Retry, nil
+		}
 		operation.State = orchestrationExt.InProgress
 
 		op, err := s.operationStorage.UpdateUpgradeKymaOperation(operation)
@@ -98,6 +111,7 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 	}
 	if provisioningOperation.State == domain.InProgress {
 		log.Info("waiting for provisioning operation to finish")
+		operation.DeferralReason = internal.DeferralReasonProvisioning
 		return operation, s.timeSchedule.UpgradeKymaTimeout, nil
 	}
 	operation.ProvisioningParameters = provisioningOperation.ProvisioningParameters
@@ -110,6 +124,23 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 			if !operation.MaintenanceWindowEnd.IsZero() && operation.MaintenanceWindowEnd.Before(time.Now()) {
 				return s.rescheduleAtNextMaintenanceWindow(operation, log)
 			}
+			// defer the upgrade until its scheduled time and the weekly time window of the runtime, whichever is later
+			now := time.Now()
+			until, reason := operation.ScheduledAt.Sub(now), internal.DeferralReasonScheduled
+			if operation.TimeWindow != nil {
+				windowUntil, err := operation.TimeWindow.Until(now)
+				if err != nil {
+					return s.operationManager.OperationFailed(operation, fmt.Sprintf("invalid maintenance time window: %s", err))
+				}
+				if windowUntil > until {
+					until, reason = windowUntil, internal.DeferralReasonTimeWindow
+				}
+			}
+			if until > 0 {
+				log.Infof("Upgrade operation %s is deferred by %v: %s", operation.Operation.ID, until, reason)
+				operation.DeferralReason = reason
+				return operation, until, nil
+			}
 			log.Info("provisioner operation ID is empty, initialize upgrade runtime input request")
 			return s.initializeUpgradeRuntimeRequest(operation, log)
 		}
@@ -129,6 +160,7 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 func (s *InitialisationStep) rescheduleAtNextMaintenanceWindow(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
 	operation.MaintenanceWindowBegin = operation.MaintenanceWindowBegin.AddDate(0, 0, 1)
 	operation.MaintenanceWindowEnd = operation.MaintenanceWindowEnd.AddDate(0, 0, 1)
+	operation.DeferralReason = internal.DeferralReasonMaintenanceWindow
 	operation, repeat := s.operationManager.UpdateOperation(operation)
 	if repeat != 0 {
 		log.Errorf("cannot save updated maintenance window to DB")
@@ -149,6 +181,7 @@ func (s *InitialisationStep) initializeUpgradeRuntimeRequest(operation internal.
 	switch {
 	case err == nil:
 		operation.InputCreator = creator
+		operation = s.operationManager.SetProgress(operation, progressInputCreated)
 
 		operation, repeat := s.operationManager.UpdateOperation(operation)
 		if repeat != 0 {
@@ -236,6 +269,11 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeKymaOp
 	// wait for operation completion
 	switch status.State {
 	case gqlschema.OperationStateInProgress, gqlschema.OperationStatePending:
+		if status.State == gqlschema.OperationStateInProgress {
+			if operation, delay = s.operationManager.UpdateProgress(operation, progressUpgradeRunning); delay != 0 {
+				log.Errorf("cannot save the progress of the operation")
+			}
+		}
 		return operation, s.timeSchedule.StatusCheck, nil
 	}
 
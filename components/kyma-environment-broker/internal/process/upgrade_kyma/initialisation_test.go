@@ -22,6 +22,7 @@ import (
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 const (
@@ -206,6 +207,313 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should defer upgrade until maintenance time window opens", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+
+		err := memoryStorage.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixOrchestrationID, State: orchestration.InProgress})
+		require.NoError(t, err)
+
+		provisioningOperation := fixProvisioningOperation()
+		err = memoryStorage.Operations().InsertProvisioningOperation(provisioningOperation)
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		upgradeOperation.ProvisionerOperationID = ""
+		// the window opens only on the day after tomorrow
+		upgradeOperation.TimeWindow = &orchestration.TimeWindow{
+			Days:  []time.Weekday{(time.Now().UTC().Weekday() + 2) % 7},
+			Begin: "00:00",
+			End:   "23:59",
+		}
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		instance := fixInstanceRuntimeStatus()
+		err = memoryStorage.Instances().Insert(instance)
+		require.NoError(t, err)
+
+		inputBuilder := &automock.CreatorForPlan{}
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), &provisionerAutomock.Client{}, inputBuilder, evalManager, nil, nil)
+
+		// when
+		op, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		assert.NoError(t, err)
+		inputBuilder.AssertNotCalled(t, "CreateUpgradeInput")
+		assert.True(t, repeat > 24*time.Hour)
+		assert.True(t, repeat <= 48*time.Hour)
+		assert.Equal(t, orchestration.InProgress, string(op.State))
+		assert.Equal(t, internal.DeferralReasonTimeWindow, op.DeferralReason)
+	})
+
+	t.Run("should defer upgrade until scheduled time", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+
+		err := memoryStorage.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixOrchestrationID, State: orchestration.InProgress})
+		require.NoError(t, err)
+
+		provisioningOperation := fixProvisioningOperation()
+		err = memoryStorage.Operations().InsertProvisioningOperation(provisioningOperation)
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		upgradeOperation.ProvisionerOperationID = ""
+		upgradeOperation.ScheduledAt = time.Now().Add(2 * time.Hour)
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		instance := fixInstanceRuntimeStatus()
+		err = memoryStorage.Instances().Insert(instance)
+		require.NoError(t, err)
+
+		inputBuilder := &automock.CreatorForPlan{}
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), &provisionerAutomock.Client{}, inputBuilder, evalManager, nil, nil)
+
+		// when
+		op, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		assert.NoError(t, err)
+		inputBuilder.AssertNotCalled(t, "CreateUpgradeInput")
+		assert.True(t, repeat > time.Hour)
+		assert.True(t, repeat <= 2*time.Hour)
+		assert.Equal(t, internal.DeferralReasonScheduled, op.DeferralReason)
+	})
+
+	t.Run("should defer scheduled upgrade until maintenance time window opens later", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+
+		err := memoryStorage.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixOrchestrationID, State: orchestration.InProgress})
+		require.NoError(t, err)
+
+		provisioningOperation := fixProvisioningOperation()
+		err = memoryStorage.Operations().InsertProvisioningOperation(provisioningOperation)
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		upgradeOperation.ProvisionerOperationID = ""
+		upgradeOperation.ScheduledAt = time.Now().Add(time.Hour)
+		// the window opens only on the day after tomorrow
+		upgradeOperation.TimeWindow = &orchestration.TimeWindow{
+			Days:  []time.Weekday{(time.Now().UTC().Weekday() + 2) % 7},
+			Begin: "00:00",
+			End:   "23:59",
+		}
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		instance := fixInstanceRuntimeStatus()
+		err = memoryStorage.Instances().Insert(instance)
+		require.NoError(t, err)
+
+		inputBuilder := &automock.CreatorForPlan{}
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), &provisionerAutomock.Client{}, inputBuilder, evalManager, nil, nil)
+
+		// when
+		op, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		assert.NoError(t, err)
+		inputBuilder.AssertNotCalled(t, "CreateUpgradeInput")
+		assert.True(t, repeat > 24*time.Hour)
+		assert.Equal(t, internal.DeferralReasonTimeWindow, op.DeferralReason)
+	})
+
+	t.Run("should initialize UpgradeRuntimeInput request after scheduled time", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+		ver := &internal.RuntimeVersionData{}
+
+		err := memoryStorage.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixOrchestrationID, State: orchestration.InProgress})
+		require.NoError(t, err)
+
+		provisioningOperation := fixProvisioningOperation()
+		err = memoryStorage.Operations().InsertProvisioningOperation(provisioningOperation)
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		upgradeOperation.ProvisionerOperationID = ""
+		upgradeOperation.ScheduledAt = time.Now().Add(-time.Minute)
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		instance := fixInstanceRuntimeStatus()
+		err = memoryStorage.Instances().Insert(instance)
+		require.NoError(t, err)
+
+		inputBuilder := &automock.CreatorForPlan{}
+		inputBuilder.On("CreateUpgradeInput", fixProvisioningParameters(), *ver).Return(&input.RuntimeInput{}, nil)
+
+		rvc := &automock.RuntimeVersionConfiguratorForUpgrade{}
+		rvc.On("ForUpgrade", mock.Anything).Return(ver, nil).Once()
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), &provisionerAutomock.Client{}, inputBuilder, evalManager, nil, rvc)
+
+		// when
+		_, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		assert.NoError(t, err)
+		inputBuilder.AssertNumberOfCalls(t, "CreateUpgradeInput", 1)
+		assert.Equal(t, time.Duration(0), repeat)
+	})
+
+	t.Run("should reschedule upgrade at next maintenance window when window has ended", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+
+		err := memoryStorage.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixOrchestrationID, State: orchestration.InProgress})
+		require.NoError(t, err)
+
+		provisioningOperation := fixProvisioningOperation()
+		err = memoryStorage.Operations().InsertProvisioningOperation(provisioningOperation)
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		upgradeOperation.ProvisionerOperationID = ""
+		upgradeOperation.MaintenanceWindowBegin = time.Now().Add(-2 * time.Hour)
+		upgradeOperation.MaintenanceWindowEnd = time.Now().Add(-time.Hour)
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		instance := fixInstanceRuntimeStatus()
+		err = memoryStorage.Instances().Insert(instance)
+		require.NoError(t, err)
+
+		inputBuilder := &automock.CreatorForPlan{}
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), &provisionerAutomock.Client{}, inputBuilder, evalManager, nil, nil)
+
+		// when
+		op, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		assert.NoError(t, err)
+		inputBuilder.AssertNotCalled(t, "CreateUpgradeInput")
+		assert.True(t, repeat > 0)
+		assert.Equal(t, internal.DeferralReasonMaintenanceWindow, op.DeferralReason)
+
+		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, internal.DeferralReasonMaintenanceWindow, storedOp.DeferralReason)
+	})
+
+	t.Run("should wait for provisioning operation to finish", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+
+		err := memoryStorage.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixOrchestrationID, State: orchestration.InProgress})
+		require.NoError(t, err)
+
+		provisioningOperation := fixProvisioningOperation()
+		provisioningOperation.State = domain.InProgress
+		err = memoryStorage.Operations().InsertProvisioningOperation(provisioningOperation)
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), &provisionerAutomock.Client{}, &automock.CreatorForPlan{}, evalManager, nil, nil)
+
+		// when
+		op, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, repeat)
+		assert.Equal(t, internal.DeferralReasonProvisioning, op.DeferralReason)
+	})
+
+	t.Run("should initialize UpgradeRuntimeInput request inside maintenance time window", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+		ver := &internal.RuntimeVersionData{}
+
+		err := memoryStorage.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixOrchestrationID, State: orchestration.InProgress})
+		require.NoError(t, err)
+
+		provisioningOperation := fixProvisioningOperation()
+		err = memoryStorage.Operations().InsertProvisioningOperation(provisioningOperation)
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		upgradeOperation.ProvisionerOperationID = ""
+		// the window lasts the whole day, every day
+		upgradeOperation.TimeWindow = &orchestration.TimeWindow{
+			Begin: "00:00",
+			End:   "00:00",
+		}
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		instance := fixInstanceRuntimeStatus()
+		err = memoryStorage.Instances().Insert(instance)
+		require.NoError(t, err)
+
+		inputBuilder := &automock.CreatorForPlan{}
+		inputBuilder.On("CreateUpgradeInput", fixProvisioningParameters(), *ver).Return(&input.RuntimeInput{}, nil)
+
+		rvc := &automock.RuntimeVersionConfiguratorForUpgrade{}
+		rvc.On("ForUpgrade", mock.Anything).Return(ver, nil).Once()
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), &provisionerAutomock.Client{}, inputBuilder, evalManager, nil, rvc)
+
+		// when
+		_, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		assert.NoError(t, err)
+		inputBuilder.AssertNumberOfCalls(t, "CreateUpgradeInput", 1)
+		assert.Equal(t, time.Duration(0), repeat)
+	})
+
+	t.Run("should not start pending operation if orchestration was paused", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+
+		err := memoryStorage.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixOrchestrationID, State: orchestration.Paused})
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), nil, nil, evalManager, nil, nil)
+
+		// when
+		op, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, repeat)
+		assert.Equal(t, orchestration.Pending, string(op.State))
+		assert.Equal(t, internal.DeferralReasonOrchestrationPaused, op.DeferralReason)
+
+		storedOp, err := memoryStorage.Operations().GetUpgradeKymaOperationByID(upgradeOperation.Operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Pending, string(storedOp.State))
+	})
+
 	t.Run("should mark finish if orchestration was canceled", func(t *testing.T) {
 		// given
 		log := logrus.New()
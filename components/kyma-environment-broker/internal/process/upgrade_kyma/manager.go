@@ -20,22 +20,32 @@ type Step interface {
 }
 
 type Manager struct {
+	ctx              context.Context
 	log              logrus.FieldLogger
 	steps            map[int][]Step
 	operationStorage storage.Operations
+	operationManager *process.UpgradeKymaOperationManager
 
 	publisher event.Publisher
 }
 
 func NewManager(storage storage.Operations, pub event.Publisher, logger logrus.FieldLogger) *Manager {
 	return &Manager{
+		ctx:              context.Background(),
 		log:              logger,
 		steps:            make(map[int][]Step, 0),
 		operationStorage: storage,
+		operationManager: process.NewUpgradeKymaOperationManager(storage),
 		publisher:        pub,
 	}
 }
 
+// WithContext sets the context of the manager, once it is done the operations whose steps request a retry are canceled instead
+func (m *Manager) WithContext(ctx context.Context) *Manager {
+	m.ctx = ctx
+	return m
+}
+
 func (m *Manager) InitStep(step Step) {
 	m.AddStep(0, step)
 }
@@ -73,6 +83,7 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 	if operation.IsFinished() {
 		return 0, nil
 	}
+	persistedReason := operation.DeferralReason
 
 	var when time.Duration
 	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID})
@@ -84,6 +95,14 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 			logStep := logOperation.WithField("step", step.Name())
 			logStep.Infof("Start step")
 
+			// the reason is set again by the step if it defers the operation
+			operation.DeferralReason = ""
+			// the step sees only its own retries, the retries of another step are kept if the step does not request a retry
+			retried := operation.RetryBackoff.Step == step.Name()
+			otherRetries := operation.RetryBackoff
+			if !retried {
+				operation.RetryBackoff = internal.RetryBackoff{Step: step.Name()}
+			}
 			operation, when, err = m.runStep(step, operation, logStep)
 			if err != nil {
 				logStep.Errorf("Process operation failed: %s", err)
@@ -93,12 +112,31 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 				logStep.Infof("Operation %q got status %s. Process finished.", operation.Operation.ID, operation.State)
 				return 0, nil
 			}
+			if operation.DeferralReason == internal.DeferralReasonRetry {
+				if m.ctx.Err() != nil {
+					// the retry would not be processed anymore, the operation is canceled so it does not stay in progress
+					_, when, err = m.operationManager.RetryOperationCtx(m.ctx, operation, lastRetryMessage(operation), when, 0, logStep)
+					return when, err
+				}
+			} else if retried {
+				// the retried step has not requested another retry, it made progress
+				operation = m.resetRetries(operation, logStep)
+			} else {
+				operation.RetryBackoff = otherRetries
+			}
 			if when == 0 {
 				logStep.Info("Process operation successful")
 				continue
 			}
 
-			logStep.Infof("Process operation will be repeated in %s ...", when)
+			if operation.DeferralReason == "" {
+				operation.DeferralReason = internal.DeferralReasonStepInProgress
+			}
+			logStep.Infof("Process operation will be repeated in %s (%s) ...", when, operation.DeferralReason)
+			// the retries are stored by the operation manager
+			if operation.DeferralReason != internal.DeferralReasonRetry && operation.DeferralReason != persistedReason {
+				m.saveDeferralReason(operation, logStep)
+			}
 			return when, nil
 		}
 	}
@@ -107,6 +145,36 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 	return 0, nil
 }
 
+// resetRetries clears the retries of the step which succeeded, so the next retry of the operation starts from the beginning with the base interval.
+// The retry history is kept for debugging.
+func (m *Manager) resetRetries(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) internal.UpgradeKymaOperation {
+	log.Infof("Step succeeded after %s of retries, resetting the retries", time.Since(operation.RetryBackoff.Since))
+	operation.RetryBackoff = internal.RetryBackoff{}
+	updated, err := m.operationStorage.UpdateUpgradeKymaOperation(operation)
+	if err != nil {
+		log.Errorf("Cannot reset the retries of the operation: %s", err)
+		return operation
+	}
+
+	return *updated
+}
+
+// lastRetryMessage returns the message of the latest retry recorded in the retry history, empty if the operation was not retried
+func lastRetryMessage(operation internal.UpgradeKymaOperation) string {
+	if len(operation.RetryHistory) == 0 {
+		return ""
+	}
+	return operation.RetryHistory[len(operation.RetryHistory)-1].Message
+}
+
+// saveDeferralReason stores the reason of the deferral, so it can be displayed while the operation waits to be processed again
+func (m *Manager) saveDeferralReason(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) {
+	_, err := m.operationStorage.UpdateUpgradeKymaOperation(operation)
+	if err != nil {
+		log.Errorf("Cannot save the deferral reason of the operation: %s", err)
+	}
+}
+
 func (m *Manager) sortWeight() []int {
 	var weight []int
 	for w := range m.steps {
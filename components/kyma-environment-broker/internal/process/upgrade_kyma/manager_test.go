@@ -33,6 +33,7 @@ func TestManager_Execute(t *testing.T) {
 		expectedError          bool
 		expectedRepeat         time.Duration
 		expectedDesc           string
+		expectedReason         internal.DeferralReason
 		expectedNumberOfEvents int
 	}{
 		"operation successful": {
@@ -52,6 +53,7 @@ func TestManager_Execute(t *testing.T) {
 			expectedError:          false,
 			expectedRepeat:         time.Duration(10),
 			expectedDesc:           "init",
+			expectedReason:         internal.DeferralReasonStepInProgress,
 			expectedNumberOfEvents: 1,
 		},
 	} {
@@ -89,9 +91,10 @@ func TestManager_Execute(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedRepeat, repeat)
 
-				operation, err := operations.GetOperationByID(tc.operationID)
+				operation, err := operations.GetUpgradeKymaOperationByID(tc.operationID)
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedDesc, strings.Trim(operation.Description, " "))
+				assert.Equal(t, tc.expectedReason, operation.DeferralReason)
 			}
 			assert.NoError(t, wait.PollImmediate(20*time.Millisecond, 2*time.Second, func() (bool, error) {
 				return len(eventCollector.Events) == tc.expectedNumberOfEvents, nil
@@ -100,6 +103,100 @@ func TestManager_Execute(t *testing.T) {
 	}
 }
 
+func TestManager_ExecuteResetsBackoffOnProgress(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	operations := memoryStorage.Operations()
+	err := operations.InsertUpgradeKymaOperation(fixOperation(operationIDRepeat))
+	assert.NoError(t, err)
+
+	flaky := &flakyStep{
+		operationManager: process.NewUpgradeKymaOperationManager(operations),
+		results:          []bool{false, false, false, true, false},
+	}
+	manager := NewManager(operations, event.NewPubSub(logrus.New()), logrus.New())
+	manager.InitStep(flaky)
+
+	// when
+	var intervals []time.Duration
+	for range flaky.results {
+		when, err := manager.Execute(operationIDRepeat)
+		assert.NoError(t, err)
+		intervals = append(intervals, when)
+	}
+
+	// then
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 0, time.Second}, intervals)
+	operation, err := operations.GetUpgradeKymaOperationByID(operationIDRepeat)
+	assert.NoError(t, err)
+	assert.Equal(t, flaky.Name(), operation.RetryBackoff.Step)
+	assert.Equal(t, time.Second, operation.RetryBackoff.Interval)
+}
+
+func TestManager_ExecuteKeepsRetriesOfAnotherStep(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	operations := memoryStorage.Operations()
+	err := operations.InsertUpgradeKymaOperation(fixOperation(operationIDSuccess))
+	assert.NoError(t, err)
+
+	failing := &failingStep{operationManager: process.NewUpgradeKymaOperationManager(operations)}
+	manager := NewManager(operations, event.NewPubSub(logrus.New()), logrus.New())
+	manager.InitStep(&testStep{t: t, name: "init", storage: operations})
+	manager.AddStep(1, failing)
+
+	// when
+	_, err = manager.Execute(operationIDSuccess)
+	assert.NoError(t, err)
+	first, err := operations.GetUpgradeKymaOperationByID(operationIDSuccess)
+	assert.NoError(t, err)
+	_, err = manager.Execute(operationIDSuccess)
+	assert.NoError(t, err)
+
+	// then
+	operation, err := operations.GetUpgradeKymaOperationByID(operationIDSuccess)
+	assert.NoError(t, err)
+	assert.Equal(t, failing.Name(), operation.RetryBackoff.Step)
+	assert.Equal(t, first.RetryBackoff.Since, operation.RetryBackoff.Since)
+	assert.Len(t, operation.RetryHistory, 2)
+
+	// when
+	operation.RetryBackoff.Since = time.Now().Add(-2 * time.Hour)
+	_, err = operations.UpdateUpgradeKymaOperation(*operation)
+	assert.NoError(t, err)
+	_, err = manager.Execute(operationIDSuccess)
+
+	// then
+	assert.Error(t, err)
+	operation, err = operations.GetUpgradeKymaOperationByID(operationIDSuccess)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Failed, operation.State)
+}
+
+func TestManager_ExecuteCancelsRetryWhenContextIsDone(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	operations := memoryStorage.Operations()
+	err := operations.InsertUpgradeKymaOperation(fixOperation(operationIDSuccess))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	manager := NewManager(operations, event.NewPubSub(logrus.New()), logrus.New()).WithContext(ctx)
+	manager.InitStep(&failingStep{operationManager: process.NewUpgradeKymaOperationManager(operations)})
+
+	// when
+	when, err := manager.Execute(operationIDSuccess)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), when)
+	operation, err := operations.GetUpgradeKymaOperationByID(operationIDSuccess)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LastOperationState(orchestration.Canceled), operation.State)
+	assert.Contains(t, operation.Description, "temporary error")
+}
+
 func fixOperation(ID string) internal.UpgradeKymaOperation {
 	return internal.UpgradeKymaOperation{
 		Operation: internal.Operation{
@@ -141,6 +238,40 @@ func (ts *testStep) Run(operation internal.UpgradeKymaOperation, logger logrus.F
 	}
 }
 
+// flakyStep succeeds or requests a retry with backoff according to the results of the subsequent runs
+type flakyStep struct {
+	operationManager *process.UpgradeKymaOperationManager
+	results          []bool
+	runs             int
+}
+
+func (s *flakyStep) Name() string {
+	return "flaky"
+}
+
+func (s *flakyStep) Run(operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	succeeded := s.results[s.runs]
+	s.runs++
+	if succeeded {
+		return operation, 0, nil
+	}
+
+	return s.operationManager.RetryStepWithBackoff(operation, s.Name(), "temporary error", time.Second, time.Minute, time.Hour, logger)
+}
+
+// failingStep always requests a retry limited by the time of retrying
+type failingStep struct {
+	operationManager *process.UpgradeKymaOperationManager
+}
+
+func (s *failingStep) Name() string {
+	return "failing"
+}
+
+func (s *failingStep) Run(operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	return s.operationManager.RetryOperation(operation, "temporary error", time.Second, time.Hour, logger)
+}
+
 type collectingEventHandler struct {
 	mu     sync.Mutex
 	Events []interface{}
@@ -13,7 +13,12 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-const DryRunPrefix = "dry_run-"
+const (
+	DryRunPrefix = "dry_run-"
+
+	// the maximum interval between the retries of failed calls to the provisioner
+	maxRetryInterval = 5 * time.Minute
+)
 
 type UpgradeKymaStep struct {
 	operationManager    *process.UpgradeKymaOperationManager
@@ -76,10 +81,13 @@ func (s *UpgradeKymaStep) Run(operation internal.UpgradeKymaOperation, log logru
 		provisionerResponse, err := s.provisionerClient.UpgradeRuntime(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeOperation.RuntimeID, requestInput)
 		if err != nil {
 			log.Errorf("call to provisioner failed: %s", err)
-			return operation, s.timeSchedule.Retry, nil
+			// the retries are counted from the last update of the operation before the first failure, so the failing calls are retried
+			// for UpgradeKymaTimeout like the operation which is not updated is bounded by the time limit checked above
+			return s.operationManager.RetryStepWithBackoff(operation, s.Name(), fmt.Sprintf("call to provisioner failed: %s", err), s.timeSchedule.Retry, maxRetryInterval, s.timeSchedule.UpgradeKymaTimeout, log)
 		}
 		operation.ProvisionerOperationID = *provisionerResponse.ID
 		operation.Description = "kyma upgrade in progress"
+		operation = s.operationManager.SetProgress(operation, progressUpgradeTriggered)
 
 		operation, repeat := s.operationManager.UpdateOperation(operation)
 		if repeat != 0 {
@@ -15,8 +15,12 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/kyma-project/kyma/components/kyma-operator/pkg/apis/installer/v1alpha1"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -78,6 +82,75 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 	assert.Equal(t, fixProvisionerOperationID, operation.ProvisionerOperationID)
 }
 
+func TestUpgradeKymaStep_RunDryRun(t *testing.T) {
+	// given
+	log := logrus.New()
+	memoryStorage := storage.NewMemoryStorage()
+
+	operation := fixUpgradeKymaOperationWithInputCreator(t)
+	operation.DryRun = true
+	operation.State = orchestration.InProgress
+	err := memoryStorage.Operations().InsertUpgradeKymaOperation(operation)
+	require.NoError(t, err)
+
+	provisionerClient := &provisionerAutomock.Client{}
+
+	step := NewUpgradeKymaStep(memoryStorage.Operations(), memoryStorage.RuntimeStates(), provisionerClient, nil)
+
+	// when
+	operation, repeat, err := step.Run(operation, log.WithFields(logrus.Fields{"step": "TEST"}))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), repeat)
+	assert.Equal(t, orchestration.Succeeded, string(operation.State))
+	assert.Equal(t, "dry run succeeded", operation.Description)
+	assert.Empty(t, operation.ProvisionerOperationID)
+	provisionerClient.AssertNotCalled(t, "UpgradeRuntime", mock.Anything, mock.Anything, mock.Anything)
+	provisionerClient.AssertNotCalled(t, "RuntimeOperationStatus", mock.Anything, mock.Anything)
+
+	state, err := memoryStorage.RuntimeStates().GetByOperationID(operation.Operation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, DryRunPrefix+fixRuntimeID, state.RuntimeID)
+}
+
+func TestUpgradeKymaStep_RunRetriesFailedUpgradeUntilTimeout(t *testing.T) {
+	// given
+	log := logrus.New()
+	memoryStorage := storage.NewMemoryStorage()
+
+	operation := fixUpgradeKymaOperationWithInputCreator(t)
+	operation.State = domain.InProgress
+	operation.UpdatedAt = time.Now().Add(-59 * time.Minute)
+	err := memoryStorage.Operations().InsertUpgradeKymaOperation(operation)
+	require.NoError(t, err)
+
+	provisionerClient := &provisionerAutomock.Client{}
+	provisionerClient.On("UpgradeRuntime", fixGlobalAccountID, fixRuntimeID, mock.Anything).Return(gqlschema.OperationStatus{}, errors.New("provisioner is not available"))
+
+	step := NewUpgradeKymaStep(memoryStorage.Operations(), memoryStorage.RuntimeStates(), provisionerClient, nil)
+
+	// when
+	operation, repeat, err := step.Run(operation, log.WithFields(logrus.Fields{"step": "TEST"}))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, repeat)
+	assert.Equal(t, domain.InProgress, operation.State)
+	assert.Equal(t, operation.UpdatedAt, operation.RetryBackoff.Since)
+
+	// when
+	// the retry updated the operation and the time limit passed since the last update before the failure
+	operation.UpdatedAt = time.Now()
+	operation.RetryBackoff.Since = operation.RetryBackoff.Since.Add(-2 * time.Minute)
+	operation, repeat, err = step.Run(operation, log.WithFields(logrus.Fields{"step": "TEST"}))
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, time.Duration(0), repeat)
+	assert.Equal(t, domain.Failed, operation.State)
+}
+
 func fixUpgradeKymaOperationWithInputCreator(t *testing.T) internal.UpgradeKymaOperation {
 	return internal.UpgradeKymaOperation{
 		Operation: internal.Operation{
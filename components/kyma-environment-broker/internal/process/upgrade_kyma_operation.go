@@ -1,23 +1,50 @@
 package process
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
+// DefaultRetryJitter is the fraction by which the intervals returned by RetryOperation are randomly shortened or extended,
+// so the operations which failed at the same time are not retried at the same time
+const DefaultRetryJitter = 0.2
+
 type UpgradeKymaOperationManager struct {
-	storage storage.UpgradeKyma
+	storage storage.Operations
+
+	jitter  float64
+	randMu  sync.Mutex
+	randGen *rand.Rand
 }
 
 func NewUpgradeKymaOperationManager(storage storage.Operations) *UpgradeKymaOperationManager {
-	return &UpgradeKymaOperationManager{storage: storage}
+	return &UpgradeKymaOperationManager{
+		storage: storage,
+		jitter:  DefaultRetryJitter,
+		randGen: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// WithRetryJitter sets the fraction of the jitter applied to the intervals returned by RetryOperation, e.g. 0.2 for ±20%,
+// and the source of the randomness. A zero fraction disables the jitter.
+func (om *UpgradeKymaOperationManager) WithRetryJitter(fraction float64, source rand.Source) *UpgradeKymaOperationManager {
+	om.jitter = fraction
+	om.randGen = rand.New(source)
+	return om
 }
 
 // OperationSucceeded marks the operation as succeeded and only repeats it if there is a storage error
@@ -31,18 +58,25 @@ func (om *UpgradeKymaOperationManager) OperationSucceeded(operation internal.Upg
 	return updatedOperation, 0, nil
 }
 
-// OperationFailed marks the operation as failed and only repeats it if there is a storage error
+// OperationFailed marks the operation as failed with the unknown failure category and only repeats it if there is a storage error
 func (om *UpgradeKymaOperationManager) OperationFailed(operation internal.UpgradeKymaOperation, description string) (internal.UpgradeKymaOperation, time.Duration, error) {
-	updatedOperation, repeat := om.update(operation, orchestration.Failed, description)
+	return om.OperationFailedWithError(operation, errors.New(description))
+}
+
+// OperationFailedWithError marks the operation as failed with the category of the given error and only repeats it if there is a storage error.
+// The category is taken from the cause of the error if it implements kebError.Categorizable, otherwise it is unknown.
+func (om *UpgradeKymaOperationManager) OperationFailedWithError(operation internal.UpgradeKymaOperation, err error) (internal.UpgradeKymaOperation, time.Duration, error) {
+	operation.FailureCategory = kebError.CategoryOf(err)
+	updatedOperation, repeat := om.update(operation, orchestration.Failed, err.Error())
 	// repeat in case of storage error
 	if repeat != 0 {
 		return updatedOperation, repeat, nil
 	}
 
-	return updatedOperation, 0, errors.New(description)
+	return updatedOperation, 0, err
 }
 
-// OperationSucceeded marks the operation as succeeded and only repeats it if there is a storage error
+// OperationCanceled marks the operation as canceled with the given reason as the description and only repeats it if there is a storage error
 func (om *UpgradeKymaOperationManager) OperationCanceled(operation internal.UpgradeKymaOperation, description string) (internal.UpgradeKymaOperation, time.Duration, error) {
 	updatedOperation, repeat := om.update(operation, orchestration.Canceled, description)
 	if repeat != 0 {
@@ -52,17 +86,268 @@ func (om *UpgradeKymaOperationManager) OperationCanceled(operation internal.Upgr
 	return updatedOperation, 0, nil
 }
 
-// RetryOperation retries an operation for at maxTime in retryInterval steps and fails the operation if retrying failed
-func (om *UpgradeKymaOperationManager) RetryOperation(operation internal.UpgradeKymaOperation, errorMessage string, retryInterval time.Duration, maxTime time.Duration, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
-	since := time.Since(operation.UpdatedAt)
+// RetryOption configures how a failed step of an operation is retried
+type RetryOption func(*retryConfig)
+
+type retryConfig struct {
+	ctx         context.Context
+	step        string
+	maxTime     time.Duration
+	maxAttempts int
+	multiplier  float64
+	maxInterval time.Duration
+	jitter      bool
+}
+
+// WithRetryContext cancels the operation instead of retrying it when the context is done
+func WithRetryContext(ctx context.Context) RetryOption {
+	return func(c *retryConfig) {
+		c.ctx = ctx
+	}
+}
+
+// WithRetryStep names the retried step, the retries of another step start from the beginning
+func WithRetryStep(step string) RetryOption {
+	return func(c *retryConfig) {
+		c.step = step
+	}
+}
+
+// WithRetryMaxTime fails the operation once the step was retried for maxTime
+func WithRetryMaxTime(maxTime time.Duration) RetryOption {
+	return func(c *retryConfig) {
+		c.maxTime = maxTime
+	}
+}
+
+// WithRetryMaxAttempts fails the operation once it was retried maxAttempts times
+func WithRetryMaxAttempts(maxAttempts int) RetryOption {
+	return func(c *retryConfig) {
+		c.maxAttempts = maxAttempts
+	}
+}
+
+// WithRetryBackoff multiplies the interval between the retries of the step by multiplier up to maxInterval
+func WithRetryBackoff(multiplier float64, maxInterval time.Duration) RetryOption {
+	return func(c *retryConfig) {
+		c.multiplier = multiplier
+		c.maxInterval = maxInterval
+	}
+}
+
+// WithJitter applies the jitter of the manager to the returned interval
+func WithJitter() RetryOption {
+	return func(c *retryConfig) {
+		c.jitter = true
+	}
+}
+
+// Retry schedules another run of the failed step of the operation in retryInterval, or fails the operation when the limits set by the options are exceeded.
+// The retries of a step are recorded in the RetryBackoff of the operation and counted from the first one, the time of the first retry defaults
+// to the last update of the operation. The upgrade kyma manager starts the retries of every step from the beginning and resets them when the step succeeds.
+// Each retry is recorded in the retry history of the operation, the operation is stored, so the retries are not reset when the broker restarts.
+func (om *UpgradeKymaOperationManager) Retry(operation internal.UpgradeKymaOperation, errorMessage string, retryInterval time.Duration, log logrus.FieldLogger, opts ...RetryOption) (internal.UpgradeKymaOperation, time.Duration, error) {
+	cfg := retryConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 
 	log.Infof("Retry Operation was triggered with message: %s", errorMessage)
-	log.Infof("Retrying for %s in %s steps", maxTime.String(), retryInterval.String())
-	if since < maxTime {
-		return operation, retryInterval, nil
+	if cfg.ctx != nil {
+		if err := cfg.ctx.Err(); err != nil {
+			log.Infof("Operation is not retried: %s", err)
+			operation.RetryBackoff = internal.RetryBackoff{}
+			return om.OperationCanceled(operation, fmt.Sprintf("operation was canceled while retrying (%s): %s", err, errorMessage))
+		}
+	}
+
+	state := operation.RetryBackoff
+	if cfg.step != "" && state.Step != cfg.step {
+		state = internal.RetryBackoff{Step: cfg.step}
+	}
+	if state.Since.IsZero() {
+		state.Since = operation.UpdatedAt
+	}
+	if state.Since.IsZero() {
+		state.Since = time.Now()
+	}
+
+	if cfg.maxTime > 0 && time.Since(state.Since) >= cfg.maxTime {
+		log.Errorf("Aborting after %s of failing retries", cfg.maxTime.String())
+		operation.RetryBackoff = internal.RetryBackoff{}
+		return om.OperationFailed(operation, errorMessage)
+	}
+	if cfg.maxAttempts > 0 && operation.RetryCount >= cfg.maxAttempts {
+		log.Errorf("Aborting after %d failing retries", operation.RetryCount)
+		operation.RetryBackoff = internal.RetryBackoff{}
+		return om.OperationFailed(operation, errorMessage)
+	}
+
+	state.Interval = nextRetryInterval(state.Interval, retryInterval, cfg.multiplier, cfg.maxInterval)
+	operation.RetryBackoff = state
+	if cfg.maxAttempts > 0 {
+		operation.RetryCount++
+		log.Infof("Retrying in %s, attempt %d of %d", state.Interval.String(), operation.RetryCount, cfg.maxAttempts)
+	} else {
+		log.Infof("Retrying for %s, next retry in %s", cfg.maxTime.String(), state.Interval.String())
+	}
+	operation.DeferralReason = internal.DeferralReasonRetry
+	operation.RetryHistory = internal.AppendRetryEntry(operation.RetryHistory, internal.RetryEntry{Timestamp: time.Now(), Message: errorMessage})
+
+	updatedOperation, repeat := om.UpdateOperation(operation)
+	if repeat != 0 {
+		return operation, repeat, nil
+	}
+
+	interval := state.Interval
+	if cfg.jitter {
+		interval = om.withJitter(interval)
 	}
-	log.Errorf("Aborting after %s of failing retries", maxTime.String())
-	return om.OperationFailed(operation, errorMessage)
+	return updatedOperation, interval, nil
+}
+
+// RetryOperation retries an operation for at most maxTime in retryInterval steps and fails the operation if retrying failed
+func (om *UpgradeKymaOperationManager) RetryOperation(operation internal.UpgradeKymaOperation, errorMessage string, retryInterval time.Duration, maxTime time.Duration, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	return om.Retry(operation, errorMessage, retryInterval, log, WithRetryMaxTime(maxTime), WithJitter())
+}
+
+// RetryOperationCtx retries an operation like RetryOperation unless the context is done, for example when the orchestration is shutting down.
+// Then the operation is canceled instead of scheduling another retry, so it does not stay in progress after the shutdown.
+func (om *UpgradeKymaOperationManager) RetryOperationCtx(ctx context.Context, operation internal.UpgradeKymaOperation, errorMessage string, retryInterval time.Duration, maxTime time.Duration, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	return om.Retry(operation, errorMessage, retryInterval, log, WithRetryContext(ctx), WithRetryMaxTime(maxTime), WithJitter())
+}
+
+// RetryOperationWithCount retries an operation at most maxAttempts times in retryInterval steps and fails the operation if retrying failed.
+// The limit applies to all retries of the operation scheduled with this method, not to the retries of a single step.
+func (om *UpgradeKymaOperationManager) RetryOperationWithCount(operation internal.UpgradeKymaOperation, errorMessage string, retryInterval time.Duration, maxAttempts int, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	return om.Retry(operation, errorMessage, retryInterval, log, WithRetryMaxAttempts(maxAttempts))
+}
+
+// RetryOperationWithBackoff retries an operation for at most maxTime, multiplying the interval between the retries by multiplier up to maxInterval
+func (om *UpgradeKymaOperationManager) RetryOperationWithBackoff(operation internal.UpgradeKymaOperation, errorMessage string, initialInterval time.Duration, multiplier float64, maxInterval, maxTime time.Duration, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	return om.Retry(operation, errorMessage, initialInterval, log, WithRetryMaxTime(maxTime), WithRetryBackoff(multiplier, maxInterval))
+}
+
+// RetryStepWithBackoff retries a step of an operation for at most maxTime, doubling the interval between the retries up to maxInterval.
+// Retrying another step starts again with retryInterval.
+func (om *UpgradeKymaOperationManager) RetryStepWithBackoff(operation internal.UpgradeKymaOperation, step, errorMessage string, retryInterval, maxInterval, maxTime time.Duration, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	return om.Retry(operation, errorMessage, retryInterval, log, WithRetryStep(step), WithRetryMaxTime(maxTime), WithRetryBackoff(2, maxInterval))
+}
+
+// nextRetryInterval returns the base interval for the first retry, the following ones are multiplied by multiplier up to max
+func nextRetryInterval(current, base time.Duration, multiplier float64, max time.Duration) time.Duration {
+	if current <= 0 || multiplier <= 1 {
+		return base
+	}
+	next := time.Duration(float64(current) * multiplier)
+	if max > 0 && next > max {
+		return max
+	}
+
+	return next
+}
+
+// withJitter returns the interval randomly shortened or extended by at most the jitter fraction
+func (om *UpgradeKymaOperationManager) withJitter(interval time.Duration) time.Duration {
+	if om.jitter <= 0 || interval <= 0 {
+		return interval
+	}
+
+	om.randMu.Lock()
+	factor := 1 + om.jitter*(2*om.randGen.Float64()-1)
+	om.randMu.Unlock()
+
+	return time.Duration(float64(interval) * factor)
+}
+
+// ForceFail marks the operation as failed regardless of its current state and records who did it and why.
+// It is meant for operations which cannot finish anymore, for example when the external resources are gone.
+func (om *UpgradeKymaOperationManager) ForceFail(operation internal.UpgradeKymaOperation, reason, initiator string) (internal.UpgradeKymaOperation, error) {
+	if reason == "" || initiator == "" {
+		return operation, errors.New("both the reason and the initiator are required to force the failure of an operation")
+	}
+
+	operation.ForcedFailure = &internal.ForcedFailure{
+		Reason:        reason,
+		Initiator:     initiator,
+		PreviousState: operation.State,
+		FailedAt:      time.Now(),
+	}
+	operation.State = orchestration.Failed
+	operation.Description = fmt.Sprintf("operation was force-failed by %s: %s", initiator, reason)
+	operation.SLABreached = internal.IsSLABreached(operation.CreatedAt, operation.ForcedFailure.FailedAt, internal.UpgradeKymaSLA)
+
+	updatedOperation, err := om.storage.UpdateUpgradeKymaOperation(operation)
+	if err != nil {
+		return operation, errors.Wrap(err, "while updating force-failed operation")
+	}
+
+	return *updatedOperation, nil
+}
+
+// MarkStaleOperationsFailed fails the in progress upgrade operations which were not updated for longer than maxAge with the timeout failure category,
+// so the operations whose steps stopped being processed, e.g. after a crash, do not stay in progress forever. It is meant to be run periodically by a reconciler,
+// and returns the failed operations. An operation which cannot be failed does not stop the others, the errors are returned together.
+func (om *UpgradeKymaOperationManager) MarkStaleOperationsFailed(maxAge time.Duration) ([]internal.UpgradeKymaOperation, error) {
+	notFinished, err := om.storage.GetNotFinishedOperationsByType(dbmodel.OperationTypeUpgradeKyma)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting not finished upgrade kyma operations")
+	}
+
+	now := time.Now()
+	var failed []internal.UpgradeKymaOperation
+	var result error
+	for _, op := range notFinished {
+		if op.State != domain.InProgress || now.Sub(op.UpdatedAt) <= maxAge {
+			continue
+		}
+		operation, err := om.storage.GetUpgradeKymaOperationByID(op.ID)
+		if err != nil {
+			result = multierror.Append(result, errors.Wrapf(err, "while getting upgrade kyma operation %s", op.ID))
+			continue
+		}
+		// the operation could be processed since it was listed
+		age := now.Sub(operation.UpdatedAt)
+		if operation.State != domain.InProgress || age <= maxAge {
+			continue
+		}
+
+		reason := kebError.NewCategorizedError(kebError.FailureCategoryTimeout, "operation timed out: it was not updated for %s, more than %s", age.Round(time.Second), maxAge)
+		updated, repeat, _ := om.OperationFailedWithError(*operation, reason)
+		if repeat != 0 {
+			result = multierror.Append(result, errors.Errorf("while marking upgrade kyma operation %s as failed: storage update failed", op.ID))
+			continue
+		}
+		failed = append(failed, updated)
+	}
+
+	return failed, result
+}
+
+// SetProgress sets the progress of the operation to the given percent limited to the range from 0 to 100.
+// The progress never decreases, so a step repeated after a later one does not move it back.
+func (om *UpgradeKymaOperationManager) SetProgress(operation internal.UpgradeKymaOperation, percent int) internal.UpgradeKymaOperation {
+	switch {
+	case percent < 0:
+		percent = 0
+	case percent > 100:
+		percent = 100
+	}
+	if percent > operation.ProgressPercent {
+		operation.ProgressPercent = percent
+	}
+
+	return operation
+}
+
+// UpdateProgress sets the progress of the operation like SetProgress and stores the operation only if the progress changed
+func (om *UpgradeKymaOperationManager) UpdateProgress(operation internal.UpgradeKymaOperation, percent int) (internal.UpgradeKymaOperation, time.Duration) {
+	updated := om.SetProgress(operation, percent)
+	if updated.ProgressPercent == operation.ProgressPercent {
+		return operation, 0
+	}
+
+	return om.UpdateOperation(updated)
 }
 
 // UpdateOperation updates a given operation
@@ -77,6 +362,12 @@ func (om *UpgradeKymaOperationManager) UpdateOperation(operation internal.Upgrad
 func (om *UpgradeKymaOperationManager) update(operation internal.UpgradeKymaOperation, state domain.LastOperationState, description string) (internal.UpgradeKymaOperation, time.Duration) {
 	operation.State = state
 	operation.Description = description
+	if state == domain.Succeeded {
+		operation = om.SetProgress(operation, 100)
+	}
+	if state == domain.Succeeded || state == domain.Failed {
+		operation.SLABreached = internal.IsSLABreached(operation.CreatedAt, time.Now(), internal.UpgradeKymaSLA)
+	}
 
 	return om.UpdateOperation(operation)
 }
@@ -1,14 +1,18 @@
 package process
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -28,6 +32,7 @@ func TestUpgradeKymaOperationManager_OperationSucceeded(t *testing.T) {
 	// then
 	assert.NoError(t, err)
 	assert.Equal(t, domain.Succeeded, op.State)
+	assert.Equal(t, 100, op.ProgressPercent)
 	assert.Equal(t, time.Duration(0), when)
 }
 
@@ -40,6 +45,7 @@ func TestUpgradeKymaOperationManager_OperationFailed(t *testing.T) {
 	err := operations.InsertUpgradeKymaOperation(op)
 	require.NoError(t, err)
 
+	op.ProgressPercent = 50
 	errMsg := "task failed miserably"
 
 	// when
@@ -49,15 +55,112 @@ func TestUpgradeKymaOperationManager_OperationFailed(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, errMsg)
 	assert.Equal(t, domain.Failed, op.State)
+	assert.Equal(t, kebError.FailureCategoryUnknown, op.FailureCategory)
+	assert.Equal(t, 50, op.ProgressPercent)
 	assert.Equal(t, time.Duration(0), when)
 }
 
+func TestUpgradeKymaOperationManager_OperationFailedWithError(t *testing.T) {
+	// given
+	memory := storage.NewMemoryStorage()
+	operations := memory.Operations()
+	opManager := NewUpgradeKymaOperationManager(operations)
+	op := fixUpgradeKymaOperation()
+	err := operations.InsertUpgradeKymaOperation(op)
+	require.NoError(t, err)
+
+	cause := kebError.NewCategorizedError(kebError.FailureCategoryQuota, "quota exceeded")
+
+	// when
+	op, when, err := opManager.OperationFailedWithError(op, errors.Wrap(cause, "while provisioning"))
+
+	// then
+	assert.EqualError(t, err, "while provisioning: quota exceeded")
+	assert.Equal(t, domain.Failed, op.State)
+	assert.Equal(t, time.Duration(0), when)
+	stored, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kebError.FailureCategoryQuota, stored.FailureCategory)
+	assert.Equal(t, "while provisioning: quota exceeded", stored.Description)
+}
+
+func TestUpgradeKymaOperationManager_OperationCanceled(t *testing.T) {
+	// given
+	memory := storage.NewMemoryStorage()
+	operations := memory.Operations()
+	opManager := NewUpgradeKymaOperationManager(operations)
+	op := fixUpgradeKymaOperation()
+	err := operations.InsertUpgradeKymaOperation(op)
+	require.NoError(t, err)
+
+	reason := "orchestration was canceled"
+
+	// when
+	op, when, err := opManager.OperationCanceled(op, reason)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LastOperationState(orchestration.Canceled), op.State)
+	assert.Equal(t, reason, op.Description)
+	assert.Equal(t, time.Duration(0), when)
+	stored, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LastOperationState(orchestration.Canceled), stored.State)
+}
+
+func TestUpgradeKymaOperationManager_RetryOperationCtx(t *testing.T) {
+	t.Run("should retry the operation while the context is not done", func(t *testing.T) {
+		// given
+		memory := storage.NewMemoryStorage()
+		operations := memory.Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		op.UpdatedAt = time.Now()
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		op, when, err := opManager.RetryOperationCtx(context.Background(), op, "task failed", time.Minute, time.Hour, fixLogger())
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, when > 0)
+		assert.Equal(t, internal.DeferralReasonRetry, op.DeferralReason)
+	})
+
+	t.Run("should cancel the operation when the context is canceled", func(t *testing.T) {
+		// given
+		memory := storage.NewMemoryStorage()
+		operations := memory.Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		op.UpdatedAt = time.Now()
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when
+		op, when, err := opManager.RetryOperationCtx(ctx, op, "task failed", time.Minute, time.Hour, fixLogger())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), when)
+		assert.Equal(t, domain.LastOperationState(orchestration.Canceled), op.State)
+		assert.Contains(t, op.Description, "task failed")
+		stored, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LastOperationState(orchestration.Canceled), stored.State)
+	})
+}
+
 func TestUpgradeKymaOperationManager_RetryOperation(t *testing.T) {
 	// given
 	memory := storage.NewMemoryStorage()
 	operations := memory.Operations()
 	opManager := NewUpgradeKymaOperationManager(operations)
 	op := internal.UpgradeKymaOperation{}
+	op.ID = "operation-id"
 	op.UpdatedAt = time.Now()
 	retryInterval := time.Hour
 	errorMessage := fmt.Sprintf("task failed")
@@ -73,6 +176,7 @@ func TestUpgradeKymaOperationManager_RetryOperation(t *testing.T) {
 	// when - first retry
 	assert.True(t, when > 0)
 	assert.Nil(t, err)
+	assert.Equal(t, internal.DeferralReasonRetry, op.DeferralReason)
 
 	// then - second call
 	t.Log(op.UpdatedAt.String())
@@ -86,6 +190,441 @@ func TestUpgradeKymaOperationManager_RetryOperation(t *testing.T) {
 
 }
 
+func TestUpgradeKymaOperationManager_RetryOperationHistory(t *testing.T) {
+	t.Run("should record each retry", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		op.UpdatedAt = time.Now()
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		op, _, err = opManager.RetryOperation(op, "first failure", time.Minute, time.Hour, fixLogger())
+		require.NoError(t, err)
+		op, _, err = opManager.RetryOperation(op, "second failure", time.Minute, time.Hour, fixLogger())
+		require.NoError(t, err)
+
+		// then
+		require.Len(t, op.RetryHistory, 2)
+		assert.Equal(t, "first failure", op.RetryHistory[0].Message)
+		assert.Equal(t, "second failure", op.RetryHistory[1].Message)
+		assert.False(t, op.RetryHistory[1].Timestamp.Before(op.RetryHistory[0].Timestamp))
+	})
+
+	t.Run("should keep only the latest retries", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		op.UpdatedAt = time.Now()
+		require.NoError(t, operations.InsertUpgradeKymaOperation(op))
+
+		// when
+		for i := 0; i < internal.MaxRetryHistory+3; i++ {
+			var err error
+			op, _, err = opManager.RetryOperation(op, fmt.Sprintf("failure %d", i), time.Minute, time.Hour, fixLogger())
+			require.NoError(t, err)
+		}
+
+		// then
+		require.Len(t, op.RetryHistory, internal.MaxRetryHistory)
+		assert.Equal(t, "failure 3", op.RetryHistory[0].Message)
+		assert.Equal(t, fmt.Sprintf("failure %d", internal.MaxRetryHistory+2), op.RetryHistory[internal.MaxRetryHistory-1].Message)
+	})
+
+	t.Run("should count the time of retrying from the first retry", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+		op.RetryBackoff = internal.RetryBackoff{Since: time.Now().Add(-2 * time.Hour)}
+		op.UpdatedAt = time.Now()
+
+		// when
+		op, when, err := opManager.RetryOperation(op, "task failed", time.Minute, time.Hour, fixLogger())
+
+		// then
+		assert.EqualError(t, err, "task failed")
+		assert.Equal(t, time.Duration(0), when)
+		assert.Equal(t, domain.Failed, op.State)
+		assert.Equal(t, internal.RetryBackoff{}, op.RetryBackoff)
+	})
+}
+
+func TestUpgradeKymaOperationManager_RetryOperationJitter(t *testing.T) {
+	t.Run("should keep the interval within the jitter band", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations).WithRetryJitter(0.2, rand.NewSource(1))
+		op := fixUpgradeKymaOperation()
+		op.UpdatedAt = time.Now()
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		for i := 0; i < 100; i++ {
+			// when
+			_, when, err := opManager.RetryOperation(op, "task failed", 10*time.Second, time.Hour, fixLogger())
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, when >= 8*time.Second, "interval %s is shorter than the jitter band", when)
+			assert.True(t, when <= 12*time.Second, "interval %s is longer than the jitter band", when)
+		}
+	})
+
+	t.Run("should return the interval without jitter", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations).WithRetryJitter(0, rand.NewSource(1))
+		op := fixUpgradeKymaOperation()
+		op.UpdatedAt = time.Now()
+		require.NoError(t, operations.InsertUpgradeKymaOperation(op))
+
+		// when
+		_, when, err := opManager.RetryOperation(op, "task failed", 10*time.Second, time.Hour, fixLogger())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, when)
+	})
+}
+
+func TestUpgradeKymaOperationManager_RetryOperationWithCount(t *testing.T) {
+	t.Run("should retry until the last allowed attempt", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		for i := 1; i <= 3; i++ {
+			// when
+			var when time.Duration
+			op, when, err = opManager.RetryOperationWithCount(op, "task failed", time.Minute, 3, fixLogger())
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, time.Minute, when)
+			assert.Equal(t, i, op.RetryCount)
+			assert.Equal(t, internal.DeferralReasonRetry, op.DeferralReason)
+		}
+		stored, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, stored.RetryCount)
+	})
+
+	t.Run("should fail the operation when the attempts are exhausted", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		op.RetryCount = 3
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		op, when, err := opManager.RetryOperationWithCount(op, "task failed", time.Minute, 3, fixLogger())
+
+		// then
+		assert.EqualError(t, err, "task failed")
+		assert.Equal(t, time.Duration(0), when)
+		assert.Equal(t, domain.Failed, op.State)
+	})
+}
+
+func TestUpgradeKymaOperationManager_RetryOperationWithBackoff(t *testing.T) {
+	t.Run("should grow the intervals up to the maximum", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		var intervals []time.Duration
+		for i := 0; i < 5; i++ {
+			var when time.Duration
+			op, when, err = opManager.RetryOperationWithBackoff(op, "task failed", time.Minute, 2, 5*time.Minute, time.Hour, fixLogger())
+			require.NoError(t, err)
+			intervals = append(intervals, when)
+		}
+
+		// then
+		assert.Equal(t, []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}, intervals)
+		assert.Equal(t, internal.DeferralReasonRetry, op.DeferralReason)
+	})
+
+	t.Run("should fail the operation after max time", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		op.UpdatedAt = time.Now().Add(-time.Hour)
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		op, when, err := opManager.RetryOperationWithBackoff(op, "task failed", time.Minute, 2, 5*time.Minute, time.Hour, fixLogger())
+
+		// then
+		assert.EqualError(t, err, "task failed")
+		assert.Equal(t, time.Duration(0), when)
+		assert.Equal(t, domain.Failed, op.State)
+	})
+}
+
+func TestUpgradeKymaOperationManager_UpdateProgress(t *testing.T) {
+	// given
+	memory := storage.NewMemoryStorage()
+	operations := memory.Operations()
+	opManager := NewUpgradeKymaOperationManager(operations)
+	op := fixUpgradeKymaOperation()
+	err := operations.InsertUpgradeKymaOperation(op)
+	require.NoError(t, err)
+
+	t.Run("should increase progress and store it", func(t *testing.T) {
+		// when
+		op, when := opManager.UpdateProgress(op, 25)
+
+		// then
+		assert.Equal(t, time.Duration(0), when)
+		assert.Equal(t, 25, op.ProgressPercent)
+		stored, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 25, stored.ProgressPercent)
+	})
+
+	t.Run("should never decrease progress", func(t *testing.T) {
+		// given
+		op, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+
+		// when
+		progressed, when := opManager.UpdateProgress(*op, 75)
+		updated, _ := opManager.UpdateProgress(progressed, 50)
+
+		// then
+		assert.Equal(t, time.Duration(0), when)
+		assert.Equal(t, 75, updated.ProgressPercent)
+		stored, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 75, stored.ProgressPercent)
+	})
+
+	t.Run("should not store unchanged progress", func(t *testing.T) {
+		// given
+		op, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+
+		// when
+		updated, when := opManager.UpdateProgress(*op, 75)
+
+		// then
+		assert.Equal(t, time.Duration(0), when)
+		assert.Equal(t, op.Version, updated.Version)
+	})
+}
+
+func TestUpgradeKymaOperationManager_SetProgress(t *testing.T) {
+	opManager := NewUpgradeKymaOperationManager(storage.NewMemoryStorage().Operations())
+
+	for name, tc := range map[string]struct {
+		current  int
+		percent  int
+		expected int
+	}{
+		"increase":            {current: 25, percent: 50, expected: 50},
+		"decrease is ignored": {current: 50, percent: 25, expected: 50},
+		"below zero":          {current: 0, percent: -10, expected: 0},
+		"above hundred":       {current: 50, percent: 150, expected: 100},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			op := fixUpgradeKymaOperation()
+			op.ProgressPercent = tc.current
+
+			// when
+			op = opManager.SetProgress(op, tc.percent)
+
+			// then
+			assert.Equal(t, tc.expected, op.ProgressPercent)
+		})
+	}
+}
+
+func TestUpgradeKymaOperationManager_ForceFail(t *testing.T) {
+	t.Run("should record the forced failure", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		op.State = domain.Succeeded
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		op, err = opManager.ForceFail(op, "shoot was deleted", "admin@kyma.cx")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, op.State)
+		stored, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, stored.State)
+		require.NotNil(t, stored.ForcedFailure)
+		assert.Equal(t, "shoot was deleted", stored.ForcedFailure.Reason)
+		assert.Equal(t, "admin@kyma.cx", stored.ForcedFailure.Initiator)
+		assert.Equal(t, domain.Succeeded, stored.ForcedFailure.PreviousState)
+		assert.False(t, stored.ForcedFailure.FailedAt.IsZero())
+	})
+
+	t.Run("should require the reason and the initiator", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		_, err = opManager.ForceFail(op, "", "admin@kyma.cx")
+
+		// then
+		assert.Error(t, err)
+		stored, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, stored.State)
+		assert.Nil(t, stored.ForcedFailure)
+	})
+}
+
+func TestUpgradeKymaOperationManager_RetryStepWithBackoff(t *testing.T) {
+	t.Run("should double the interval up to the maximum", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		var intervals []time.Duration
+		for i := 0; i < 4; i++ {
+			var when time.Duration
+			op, when, err = opManager.RetryStepWithBackoff(op, "step", "task failed", time.Second, 3*time.Second, time.Hour, fixLogger())
+			require.NoError(t, err)
+			intervals = append(intervals, when)
+		}
+
+		// then
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}, intervals)
+		assert.Equal(t, internal.DeferralReasonRetry, op.DeferralReason)
+		stored, err := operations.GetUpgradeKymaOperationByID(op.Operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Second, stored.RetryBackoff.Interval)
+	})
+
+	t.Run("should start with the base interval for another step", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		op.RetryBackoff = internal.RetryBackoff{Step: "previous", Interval: time.Minute, Since: time.Now()}
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		op, when, err := opManager.RetryStepWithBackoff(op, "step", "task failed", time.Second, time.Minute, time.Hour, fixLogger())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, when)
+		assert.Equal(t, "step", op.RetryBackoff.Step)
+	})
+
+	t.Run("should fail the operation after max time", func(t *testing.T) {
+		// given
+		operations := storage.NewMemoryStorage().Operations()
+		opManager := NewUpgradeKymaOperationManager(operations)
+		op := fixUpgradeKymaOperation()
+		op.RetryBackoff = internal.RetryBackoff{Step: "step", Interval: time.Minute, Since: time.Now().Add(-time.Hour)}
+		err := operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+
+		// when
+		op, when, err := opManager.RetryStepWithBackoff(op, "step", "task failed", time.Second, time.Minute, time.Hour, fixLogger())
+
+		// then
+		assert.EqualError(t, err, "task failed")
+		assert.Equal(t, time.Duration(0), when)
+		assert.Equal(t, domain.Failed, op.State)
+	})
+}
+
+func TestUpgradeKymaOperationManager_MarkStaleOperationsFailed(t *testing.T) {
+	// given
+	operations := storage.NewMemoryStorage().Operations()
+	opManager := NewUpgradeKymaOperationManager(operations)
+
+	stale := fixUpgradeKymaOperation()
+	stale.ID = "stale-operation"
+	stale.UpdatedAt = time.Now().Add(-3 * time.Hour)
+	fresh := fixUpgradeKymaOperation()
+	fresh.ID = "fresh-operation"
+	fresh.UpdatedAt = time.Now().Add(-time.Minute)
+	succeeded := fixUpgradeKymaOperation()
+	succeeded.ID = "succeeded-operation"
+	succeeded.State = domain.Succeeded
+	succeeded.UpdatedAt = time.Now().Add(-3 * time.Hour)
+	for _, op := range []internal.UpgradeKymaOperation{stale, fresh, succeeded} {
+		require.NoError(t, operations.InsertUpgradeKymaOperation(op))
+	}
+
+	// when
+	failed, err := opManager.MarkStaleOperationsFailed(time.Hour)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, stale.ID, failed[0].ID)
+
+	op, err := operations.GetUpgradeKymaOperationByID(stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.Equal(t, kebError.FailureCategoryTimeout, op.FailureCategory)
+	assert.Contains(t, op.Description, "operation timed out")
+
+	op, err = operations.GetUpgradeKymaOperationByID(fresh.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.InProgress, op.State)
+
+	op, err = operations.GetUpgradeKymaOperationByID(succeeded.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Succeeded, op.State)
+}
+
+func TestUpgradeKymaOperationManager_MarkStaleOperationsFailedNothingStale(t *testing.T) {
+	// given
+	operations := storage.NewMemoryStorage().Operations()
+	opManager := NewUpgradeKymaOperationManager(operations)
+	op := fixUpgradeKymaOperation()
+	op.UpdatedAt = time.Now()
+	require.NoError(t, operations.InsertUpgradeKymaOperation(op))
+
+	// when
+	failed, err := opManager.MarkStaleOperationsFailed(time.Hour)
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, failed)
+}
+
 func fixUpgradeKymaOperation() internal.UpgradeKymaOperation {
 	return internal.UpgradeKymaOperation{
 		Operation: internal.Operation{
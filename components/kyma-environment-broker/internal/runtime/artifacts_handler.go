@@ -0,0 +1,87 @@
+package runtime
+
+import (
+	"net/http"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/artifact"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ArtifactsHandler exposes the artifacts attached to operations, e.g. the logs of the failed steps
+type ArtifactsHandler struct {
+	store artifact.Store
+	log   logrus.FieldLogger
+}
+
+func NewArtifactsHandler(store artifact.Store, log logrus.FieldLogger) *ArtifactsHandler {
+	return &ArtifactsHandler{
+		store: store,
+		log:   log,
+	}
+}
+
+func (h *ArtifactsHandler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/operations/{operation_id}/artifacts", h.listArtifacts).Methods(http.MethodGet)
+	router.HandleFunc("/operations/{operation_id}/artifacts/{name}", h.getArtifact).Methods(http.MethodGet)
+}
+
+func (h *ArtifactsHandler) listArtifacts(w http.ResponseWriter, req *http.Request) {
+	operationID := mux.Vars(req)["operation_id"]
+	if err := artifact.ValidateName(operationID); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "invalid operation ID"))
+		return
+	}
+
+	artifacts, err := h.store.List(operationID)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while listing artifacts of operation %s", operationID))
+		return
+	}
+
+	result := make([]pkg.ArtifactDTO, 0, len(artifacts))
+	for _, a := range artifacts {
+		result = append(result, pkg.ArtifactDTO{
+			OperationID: a.OperationID,
+			Name:        a.Name,
+			Size:        a.Size,
+			CreatedAt:   a.CreatedAt,
+		})
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, result)
+}
+
+func (h *ArtifactsHandler) getArtifact(w http.ResponseWriter, req *http.Request) {
+	operationID := mux.Vars(req)["operation_id"]
+	name := mux.Vars(req)["name"]
+	if err := artifact.ValidateName(operationID); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "invalid operation ID"))
+		return
+	}
+	if err := artifact.ValidateName(name); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "invalid artifact name"))
+		return
+	}
+
+	data, err := h.store.Fetch(operationID, name)
+	switch {
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, err)
+		return
+	case err != nil:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while fetching artifact %s of operation %s", name, operationID))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		h.log.Warnf("could not write artifact %s of operation %s: %s", name, operationID, err)
+	}
+}
@@ -0,0 +1,95 @@
+package runtime_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/artifact"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/logger"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactsHandler(t *testing.T) {
+	// given
+	dir, err := ioutil.TempDir("", "artifacts")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := artifact.NewFilesystemStore(dir)
+	require.NoError(t, store.Save("op-1", "step.log", []byte("step failed")))
+
+	router := mux.NewRouter()
+	runtime.NewArtifactsHandler(store, logger.NewLogDummy()).AttachRoutes(router)
+
+	t.Run("should list artifacts of operation", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/operations/op-1/artifacts", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out []pkg.ArtifactDTO
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		require.Len(t, out, 1)
+		assert.Equal(t, "op-1", out[0].OperationID)
+		assert.Equal(t, "step.log", out[0].Name)
+		assert.Equal(t, int64(len("step failed")), out[0].Size)
+	})
+
+	t.Run("should return artifact content", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/operations/op-1/artifacts/step.log", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/octet-stream", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "step failed", rr.Body.String())
+	})
+
+	t.Run("should return not found for missing artifact", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/operations/op-1/artifacts/other.log", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should reject invalid artifact name", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/operations/op-1/artifacts/.hidden", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -2,9 +2,11 @@ package runtime
 
 import (
 	"strings"
+	"time"
 
 	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 )
 
 type Converter interface {
@@ -14,6 +16,8 @@ type Converter interface {
 	ApplyUpgradingKymaOperations(dto *pkg.RuntimeDTO, oprs []internal.UpgradeKymaOperation, totalCount int)
 	ApplySuspensionOperations(dto *pkg.RuntimeDTO, oprs []internal.DeprovisioningOperation)
 	ApplyUnsuspensionOperations(dto *pkg.RuntimeDTO, oprs []internal.ProvisioningOperation)
+	ApplyKymaVersion(dto *pkg.RuntimeDTO, pOpr *internal.ProvisioningOperation, ukOprs []internal.UpgradeKymaOperation)
+	NewOperationDTO(operation internal.Operation, operationType pkg.OperationType) pkg.Operation
 }
 
 type converter struct {
@@ -51,12 +55,19 @@ func (c *converter) applyOperation(source *internal.Operation, target *pkg.Opera
 	if source != nil {
 		target.OperationID = source.ID
 		target.CreatedAt = source.CreatedAt
+		target.UpdatedAt = source.UpdatedAt
 		target.State = string(source.State)
 		target.Description = source.Description
 		target.OrchestrationID = source.OrchestrationID
 	}
 }
 
+func (c *converter) NewOperationDTO(operation internal.Operation, operationType pkg.OperationType) pkg.Operation {
+	op := pkg.Operation{Type: operationType}
+	c.applyOperation(&operation, &op)
+	return op
+}
+
 func (c *converter) NewDTO(instance internal.Instance) (pkg.RuntimeDTO, error) {
 	toReturn := pkg.RuntimeDTO{
 		InstanceID:       instance.InstanceID,
@@ -69,6 +80,7 @@ func (c *converter) NewDTO(instance internal.Instance) (pkg.RuntimeDTO, error) {
 		ServicePlanName:  instance.ServicePlanName,
 		ProviderRegion:   instance.ProviderRegion,
 		UserID:           instance.Parameters.ErsContext.UserID,
+		ZoneCount:        zoneCount(instance.Parameters),
 		Status: pkg.RuntimeStatus{
 			CreatedAt:    instance.CreatedAt,
 			ModifiedAt:   instance.UpdatedAt,
@@ -86,6 +98,15 @@ func (c *converter) NewDTO(instance internal.Instance) (pkg.RuntimeDTO, error) {
 	return toReturn, nil
 }
 
+// zoneCount returns the number of zones requested in the provisioning parameters.
+// The providers create the cluster in a single zone if no zones are requested.
+func zoneCount(pp internal.ProvisioningParameters) int {
+	if len(pp.Parameters.Zones) > 0 {
+		return len(pp.Parameters.Zones)
+	}
+	return 1
+}
+
 func (c *converter) ApplyUpgradingKymaOperations(dto *pkg.RuntimeDTO, oprs []internal.UpgradeKymaOperation, totalCount int) {
 	dto.Status.UpgradingKyma.TotalCount = totalCount
 	dto.Status.UpgradingKyma.Count = len(oprs)
@@ -129,3 +150,19 @@ func (c *converter) ApplyUnsuspensionOperations(dto *pkg.RuntimeDTO, oprs []inte
 		dto.Status.Unsuspension.Data = append(dto.Status.Unsuspension.Data, op)
 	}
 }
+
+// ApplyKymaVersion sets the Kyma version of the latest succeeded upgrade operation, which is not a dry run, or of the provisioning operation
+func (c *converter) ApplyKymaVersion(dto *pkg.RuntimeDTO, pOpr *internal.ProvisioningOperation, ukOprs []internal.UpgradeKymaOperation) {
+	if pOpr != nil && pOpr.State == domain.Succeeded {
+		dto.KymaVersion = pOpr.RuntimeVersion.Version
+	}
+
+	var upgradedAt time.Time
+	for _, op := range ukOprs {
+		if op.DryRun || op.State != domain.Succeeded || op.RuntimeVersion.Version == "" || op.CreatedAt.Before(upgradedAt) {
+			continue
+		}
+		dto.KymaVersion = op.RuntimeVersion.Version
+		upgradedAt = op.CreatedAt
+	}
+}
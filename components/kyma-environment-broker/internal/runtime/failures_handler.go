@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// defaultFailuresLimit is the number of failure groups returned when the limit is not specified
+const defaultFailuresLimit = 10
+
+// FailuresHandler exposes the most frequent failures of operations across all runtimes
+type FailuresHandler struct {
+	operationsDb storage.Operations
+}
+
+func NewFailuresHandler(operationsDb storage.Operations) *FailuresHandler {
+	return &FailuresHandler{
+		operationsDb: operationsDb,
+	}
+}
+
+func (h *FailuresHandler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/failures", h.getFailures).Methods(http.MethodGet)
+}
+
+func (h *FailuresHandler) getFailures(w http.ResponseWriter, req *http.Request) {
+	limit := defaultFailuresLimit
+	if value := req.URL.Query().Get(pkg.LimitParam); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("invalid value for %s: %s, the value must be a positive number", pkg.LimitParam, value))
+			return
+		}
+	}
+
+	groups, err := h.operationsDb.GetFailureGroups(limit)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching failure groups"))
+		return
+	}
+
+	result := make([]pkg.FailureGroupDTO, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, pkg.FailureGroupDTO{
+			Message:    group.Message,
+			Count:      group.Count,
+			RuntimeIDs: group.RuntimeIDs,
+		})
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, result)
+}
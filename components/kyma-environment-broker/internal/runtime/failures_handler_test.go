@@ -0,0 +1,70 @@
+package runtime_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
+
+	"github.com/gorilla/mux"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailuresHandler(t *testing.T) {
+	// given
+	operations := memory.NewOperation()
+	for _, op := range []internal.Operation{
+		{ID: "op-1", State: domain.Failed, Description: "provisioner operation 9d75a545-2e1e-4786-abd8-a37b14e185b9 failed", InstanceDetails: internal.InstanceDetails{RuntimeID: "rid-1"}},
+		{ID: "op-2", State: domain.Failed, Description: "provisioner operation ef4e3210-652c-453e-8015-bba1c1cd1e1c failed", InstanceDetails: internal.InstanceDetails{RuntimeID: "rid-2"}},
+		{ID: "op-3", State: domain.Failed, Description: "operation has reached the time limit"},
+		{ID: "op-4", State: domain.Succeeded, Description: "provisioner operation 3a7d2b6b-4aa4-4c2e-9fd6-9e56cd6ffc0a failed"},
+	} {
+		err := operations.InsertProvisioningOperation(internal.ProvisioningOperation{Operation: op})
+		require.NoError(t, err)
+	}
+
+	router := mux.NewRouter()
+	runtime.NewFailuresHandler(operations).AttachRoutes(router)
+
+	t.Run("should return failure groups", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/failures?limit=1", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out []pkg.FailureGroupDTO
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		require.Len(t, out, 1)
+		assert.Equal(t, "provisioner operation <id> failed", out[0].Message)
+		assert.Equal(t, 2, out[0].Count)
+		assert.ElementsMatch(t, []string{"rid-1", "rid-2"}, out[0].RuntimeIDs)
+	})
+
+	t.Run("should reject invalid limit", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/failures?limit=0", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
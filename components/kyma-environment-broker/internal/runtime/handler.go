@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"net/http"
+	"sort"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
@@ -36,6 +37,8 @@ func NewHandler(instanceDb storage.Instances, operationDb storage.Operations, de
 
 func (h *Handler) AttachRoutes(router *mux.Router) {
 	router.HandleFunc("/runtimes", h.getRuntimes)
+	router.HandleFunc("/runtimes/{runtime_id}/operations", h.getRuntimeOperations).Methods(http.MethodGet)
+	router.HandleFunc("/operations/{operation_id}", h.getOperation).Methods(http.MethodGet)
 }
 
 func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
@@ -87,6 +90,7 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 			httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching upgrade kyma operation for instance"))
 			return
 		}
+		h.converter.ApplyKymaVersion(&dto, &firstProvOp, ukOprs)
 		ukOprs, totalCount := h.takeLastNonDryRunOperations(ukOprs)
 		h.converter.ApplyUpgradingKymaOperations(&dto, ukOprs, totalCount)
 
@@ -105,7 +109,131 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 		Count:      count,
 		TotalCount: totalCount,
 	}
-	httputil.WriteResponse(w, http.StatusOK, runtimePage)
+	httputil.WriteResponseWithETag(w, req, runtimePage)
+}
+
+// getRuntimeOperations returns all operations of the runtime with a given ID in chronological order
+func (h *Handler) getRuntimeOperations(w http.ResponseWriter, req *http.Request) {
+	runtimeID := mux.Vars(req)["runtime_id"]
+
+	instances, err := h.instancesDb.FindAllInstancesForRuntimes([]string{runtimeID})
+	switch {
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Wrapf(err, "while fetching instance for runtime %s", runtimeID))
+		return
+	case err != nil:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while fetching instance for runtime %s", runtimeID))
+		return
+	}
+
+	toReturn := make([]pkg.Operation, 0)
+	for _, instance := range instances {
+		operations, err := h.listInstanceOperations(instance.InstanceID)
+		if err != nil {
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+		toReturn = append(toReturn, operations...)
+	}
+	sort.SliceStable(toReturn, func(i, j int) bool {
+		return toReturn[i].CreatedAt.Before(toReturn[j].CreatedAt)
+	})
+
+	httputil.WriteResponse(w, http.StatusOK, toReturn)
+}
+
+func (h *Handler) getOperation(w http.ResponseWriter, req *http.Request) {
+	operationID := mux.Vars(req)["operation_id"]
+
+	operation, err := h.operationsDb.GetOperationByID(operationID)
+	switch {
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Wrapf(err, "while fetching operation %s", operationID))
+		return
+	case err != nil:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while fetching operation %s", operationID))
+		return
+	}
+
+	// the type of the operation follows from the other operations of the instance, e.g. only the oldest provisioning is not an unsuspension
+	operations, err := h.listInstanceOperations(operation.InstanceID)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	for _, op := range operations {
+		if op.OperationID != operationID {
+			continue
+		}
+		if op.Type == pkg.OperationTypeUpgradeKyma {
+			if err := h.setUpgradeKymaDetails(&op); err != nil {
+				httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+		httputil.WriteResponse(w, http.StatusOK, op)
+		return
+	}
+
+	httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("operation %s not found", operationID))
+}
+
+// setUpgradeKymaDetails sets the retries and the progress recorded in the Kyma upgrade operation
+func (h *Handler) setUpgradeKymaDetails(op *pkg.Operation) error {
+	operation, err := h.operationsDb.GetUpgradeKymaOperationByID(op.OperationID)
+	if err != nil {
+		return errors.Wrapf(err, "while fetching upgrade kyma operation %s", op.OperationID)
+	}
+
+	history := make([]pkg.RetryEntry, 0, len(operation.RetryHistory))
+	for _, entry := range operation.RetryHistory {
+		history = append(history, pkg.RetryEntry{Timestamp: entry.Timestamp, Message: entry.Message})
+	}
+	op.RetryHistory = history
+	op.ProgressPercent = operation.ProgressPercent
+	return nil
+}
+
+func (h *Handler) listInstanceOperations(instanceID string) ([]pkg.Operation, error) {
+	toReturn := make([]pkg.Operation, 0)
+
+	provOprs, err := h.operationsDb.ListProvisioningOperationsByInstanceID(instanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return nil, errors.Wrap(err, "while fetching provisioning operations list for instance")
+	}
+	// the provisioning operations are sorted from the newest, only the oldest one provisioned the runtime
+	for i, op := range provOprs {
+		operationType := pkg.OperationTypeUnsuspension
+		if i == len(provOprs)-1 {
+			operationType = pkg.OperationTypeProvision
+		}
+		toReturn = append(toReturn, h.converter.NewOperationDTO(op.Operation, operationType))
+	}
+
+	deprovOprs, err := h.operationsDb.ListDeprovisioningOperationsByInstanceID(instanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return nil, errors.Wrap(err, "while fetching deprovisioning operations list for instance")
+	}
+	for _, op := range deprovOprs {
+		operationType := pkg.OperationTypeDeprovision
+		if op.Temporary {
+			operationType = pkg.OperationTypeSuspension
+		}
+		toReturn = append(toReturn, h.converter.NewOperationDTO(op.Operation, operationType))
+	}
+
+	ukOprs, err := h.operationsDb.ListUpgradeKymaOperationsByInstanceID(instanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return nil, errors.Wrap(err, "while fetching upgrade kyma operation for instance")
+	}
+	for _, op := range ukOprs {
+		if op.DryRun {
+			continue
+		}
+		toReturn = append(toReturn, h.converter.NewOperationDTO(op.Operation, pkg.OperationTypeUpgradeKyma))
+	}
+
+	return toReturn, nil
 }
 
 func (h *Handler) takeLastNonDryRunOperations(oprs []internal.UpgradeKymaOperation) ([]internal.UpgradeKymaOperation, int) {
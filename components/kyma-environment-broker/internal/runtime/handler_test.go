@@ -165,6 +165,47 @@ func TestRuntimeHandler(t *testing.T) {
 		assert.Equal(t, testID1, out.Data[0].InstanceID)
 	})
 
+	t.Run("should return the zone count of the runtimes", func(t *testing.T) {
+		// given
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		testTime := time.Now()
+		singleZone := fixInstance("single-zone", testTime)
+		multiZone := fixInstance("multi-zone", testTime.Add(time.Minute))
+		multiZone.Parameters.Parameters.Zones = []string{"1", "2", "3"}
+
+		err := instances.Insert(singleZone)
+		require.NoError(t, err)
+		err = instances.Insert(multiZone)
+		require.NoError(t, err)
+
+		runtimeHandler := runtime.NewHandler(instances, operations, 2, "")
+
+		req, err := http.NewRequest("GET", "/runtimes", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out pkg.RuntimesPage
+
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		zones := map[string]int{}
+		for _, rt := range out.Data {
+			zones[rt.InstanceID] = rt.ZoneCount
+		}
+		assert.Equal(t, map[string]int{"single-zone": 1, "multi-zone": 3}, zones)
+	})
+
 	t.Run("should show suspension and unsuspension operations", func(t *testing.T) {
 		// given
 		operations := memory.NewOperation()
@@ -246,6 +287,187 @@ func TestRuntimeHandler(t *testing.T) {
 	})
 }
 
+func TestRuntimeHandler_GetRuntimeOperations(t *testing.T) {
+	// given
+	operations := memory.NewOperation()
+	instances := memory.NewInstance(operations)
+	now := time.Now()
+	testID := "Test1"
+	err := instances.Insert(fixInstance(testID, now))
+	require.NoError(t, err)
+
+	for _, op := range []internal.ProvisioningOperation{
+		{Operation: internal.Operation{ID: "provisioning-id", CreatedAt: now, InstanceID: testID}},
+		{Operation: internal.Operation{ID: "unsuspension-id", CreatedAt: now.Add(3 * time.Hour), InstanceID: testID}},
+	} {
+		err = operations.InsertProvisioningOperation(op)
+		require.NoError(t, err)
+	}
+	err = operations.InsertDeprovisioningOperation(internal.DeprovisioningOperation{
+		Operation: internal.Operation{ID: "suspension-id", CreatedAt: now.Add(2 * time.Hour), InstanceID: testID},
+		Temporary: true,
+	})
+	require.NoError(t, err)
+	for _, op := range []internal.UpgradeKymaOperation{
+		{Operation: internal.Operation{ID: "upgrade-id", CreatedAt: now.Add(time.Hour), InstanceID: testID}},
+		{Operation: internal.Operation{ID: "dry-run-id", CreatedAt: now.Add(time.Hour), InstanceID: testID}, DryRun: true},
+	} {
+		err = operations.InsertUpgradeKymaOperation(op)
+		require.NoError(t, err)
+	}
+
+	router := mux.NewRouter()
+	runtime.NewHandler(instances, operations, 2, "").AttachRoutes(router)
+
+	t.Run("should return operations in chronological order", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/runtimes/%s/operations", testID), nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out []pkg.Operation
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		require.Len(t, out, 4)
+		for i, expected := range []struct {
+			id            string
+			operationType pkg.OperationType
+		}{
+			{id: "provisioning-id", operationType: pkg.OperationTypeProvision},
+			{id: "upgrade-id", operationType: pkg.OperationTypeUpgradeKyma},
+			{id: "suspension-id", operationType: pkg.OperationTypeSuspension},
+			{id: "unsuspension-id", operationType: pkg.OperationTypeUnsuspension},
+		} {
+			assert.Equal(t, expected.id, out[i].OperationID)
+			assert.Equal(t, expected.operationType, out[i].Type)
+		}
+	})
+
+	t.Run("should return not found for unknown runtime", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/runtimes/unknown/operations", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestRuntimeHandler_GetOperation(t *testing.T) {
+	// given
+	operations := memory.NewOperation()
+	instances := memory.NewInstance(operations)
+	now := time.Now()
+	testID := "Test1"
+	err := instances.Insert(fixInstance(testID, now))
+	require.NoError(t, err)
+
+	for _, op := range []internal.ProvisioningOperation{
+		{Operation: internal.Operation{ID: "provisioning-id", CreatedAt: now, InstanceID: testID}},
+		{Operation: internal.Operation{ID: "unsuspension-id", CreatedAt: now.Add(2 * time.Hour), InstanceID: testID, Description: "unsuspending"}},
+	} {
+		err = operations.InsertProvisioningOperation(op)
+		require.NoError(t, err)
+	}
+	err = operations.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+		Operation: internal.Operation{ID: "dry-run-id", CreatedAt: now.Add(time.Hour), InstanceID: testID},
+		DryRun:    true,
+	})
+	require.NoError(t, err)
+
+	retriedAt := now.Add(3 * time.Hour)
+	err = operations.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+		Operation:       internal.Operation{ID: "upgrade-id", CreatedAt: now.Add(3 * time.Hour), InstanceID: testID},
+		RetryHistory:    []internal.RetryEntry{{Timestamp: retriedAt, Message: "provisioner unavailable"}},
+		ProgressPercent: 50,
+	})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	runtime.NewHandler(instances, operations, 2, "").AttachRoutes(router)
+
+	t.Run("should return operation with its type", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/operations/unsuspension-id", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out pkg.Operation
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, "unsuspension-id", out.OperationID)
+		assert.Equal(t, pkg.OperationTypeUnsuspension, out.Type)
+		assert.Equal(t, "unsuspending", out.Description)
+	})
+
+	t.Run("should return upgrade operation with its retry history and progress", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/operations/upgrade-id", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out pkg.Operation
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, pkg.OperationTypeUpgradeKyma, out.Type)
+		require.Len(t, out.RetryHistory, 1)
+		assert.Equal(t, "provisioner unavailable", out.RetryHistory[0].Message)
+		assert.True(t, retriedAt.Equal(out.RetryHistory[0].Timestamp))
+		assert.Equal(t, 50, out.ProgressPercent)
+	})
+
+	t.Run("should return not found for unknown operation", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/operations/unknown", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return not found for dry run operation", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodGet, "/operations/dry-run-id", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
 func fixInstance(id string, t time.Time) internal.Instance {
 	return internal.Instance{
 		InstanceID:      id,
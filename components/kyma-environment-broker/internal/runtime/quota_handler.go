@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"net/http"
+	"sort"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// QuotaHandler exposes the number of runtimes of a global account per service plan, compared to the plan limits
+type QuotaHandler struct {
+	instancesDb    storage.Instances
+	enabledPlanIDs map[string]struct{}
+	// limits holds the maximum number of runtimes per service plan ID in one global account, the plans without a limit are unlimited
+	limits map[string]int
+}
+
+func NewQuotaHandler(instancesDb storage.Instances, enabledPlans broker.EnablePlans, limits map[string]int) *QuotaHandler {
+	enabledPlanIDs := map[string]struct{}{}
+	for _, planName := range enabledPlans {
+		enabledPlanIDs[broker.PlanIDsMapping[planName]] = struct{}{}
+	}
+
+	return &QuotaHandler{
+		instancesDb:    instancesDb,
+		enabledPlanIDs: enabledPlanIDs,
+		limits:         limits,
+	}
+}
+
+func (h *QuotaHandler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/quotas/{global_account_id}", h.getQuota).Methods(http.MethodGet)
+}
+
+func (h *QuotaHandler) getQuota(w http.ResponseWriter, req *http.Request) {
+	globalAccountID := mux.Vars(req)["global_account_id"]
+
+	instances, _, _, err := h.instancesDb.List(dbmodel.InstanceFilter{GlobalAccountIDs: []string{globalAccountID}})
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching instances"))
+		return
+	}
+
+	used := make(map[string]int)
+	for _, instance := range instances {
+		used[instance.ServicePlanID]++
+	}
+
+	plans := make([]pkg.PlanQuotaDTO, 0)
+	for planID, name := range broker.PlanNamesMapping {
+		_, enabled := h.enabledPlanIDs[planID]
+		if !enabled && used[planID] == 0 {
+			continue
+		}
+		limit, limited := h.limits[planID]
+		plans = append(plans, pkg.PlanQuotaDTO{
+			ServicePlanID:   planID,
+			ServicePlanName: name,
+			Used:            used[planID],
+			Limit:           limit,
+			Unlimited:       !limited,
+		})
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].ServicePlanName < plans[j].ServicePlanName
+	})
+
+	httputil.WriteResponse(w, http.StatusOK, pkg.AccountQuotaDTO{
+		GlobalAccountID: globalAccountID,
+		Plans:           plans,
+	})
+}
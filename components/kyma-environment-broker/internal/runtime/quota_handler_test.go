@@ -0,0 +1,60 @@
+package runtime_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaHandler(t *testing.T) {
+	// given
+	instances := memory.NewInstance(memory.NewOperation())
+	for _, instance := range []internal.Instance{
+		{InstanceID: "inst-1", GlobalAccountID: "ga1", ServicePlanID: broker.AzurePlanID},
+		{InstanceID: "inst-2", GlobalAccountID: "ga1", ServicePlanID: broker.AzurePlanID},
+		{InstanceID: "inst-3", GlobalAccountID: "ga1", ServicePlanID: broker.TrialPlanID},
+		{InstanceID: "inst-4", GlobalAccountID: "ga2", ServicePlanID: broker.GCPPlanID},
+	} {
+		err := instances.Insert(instance)
+		require.NoError(t, err)
+	}
+
+	handler := runtime.NewQuotaHandler(instances, broker.EnablePlans{broker.GCPPlanName, broker.TrialPlanName}, map[string]int{broker.TrialPlanID: 1})
+	router := mux.NewRouter()
+	handler.AttachRoutes(router)
+
+	req, err := http.NewRequest(http.MethodGet, "/quotas/ga1", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rr, req)
+
+	// then
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var out pkg.AccountQuotaDTO
+	err = json.Unmarshal(rr.Body.Bytes(), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, pkg.AccountQuotaDTO{
+		GlobalAccountID: "ga1",
+		Plans: []pkg.PlanQuotaDTO{
+			// the plan is not enabled anymore, but the account still has its runtimes
+			{ServicePlanID: broker.AzurePlanID, ServicePlanName: broker.AzurePlanName, Used: 2, Unlimited: true},
+			{ServicePlanID: broker.GCPPlanID, ServicePlanName: broker.GCPPlanName, Used: 0, Unlimited: true},
+			{ServicePlanID: broker.TrialPlanID, ServicePlanName: broker.TrialPlanName, Used: 1, Limit: 1},
+		},
+	}, out)
+}
@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"net/http"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// SLAHandler exposes the SLA breach rates of completed operations per operation type
+type SLAHandler struct {
+	operationsDb storage.Operations
+}
+
+func NewSLAHandler(operationsDb storage.Operations) *SLAHandler {
+	return &SLAHandler{
+		operationsDb: operationsDb,
+	}
+}
+
+func (h *SLAHandler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/sla", h.getSLAReport).Methods(http.MethodGet)
+}
+
+func (h *SLAHandler) getSLAReport(w http.ResponseWriter, req *http.Request) {
+	stats, err := h.operationsDb.GetSLAStats()
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching SLA stats"))
+		return
+	}
+
+	result := make([]pkg.SLAReportDTO, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, pkg.SLAReportDTO{
+			Type:     stat.Type,
+			Total:    stat.Total,
+			Breached: stat.Breached,
+		})
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, result)
+}
@@ -0,0 +1,50 @@
+package runtime_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
+
+	"github.com/gorilla/mux"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLAHandler(t *testing.T) {
+	// given
+	operations := memory.NewOperation()
+	for _, op := range []internal.Operation{
+		{ID: "op-1", State: domain.Succeeded},
+		{ID: "op-2", State: domain.Failed, SLABreached: true},
+		{ID: "op-3", State: domain.InProgress, SLABreached: true},
+	} {
+		err := operations.InsertProvisioningOperation(internal.ProvisioningOperation{Operation: op})
+		require.NoError(t, err)
+	}
+
+	router := mux.NewRouter()
+	runtime.NewSLAHandler(operations).AttachRoutes(router)
+
+	req, err := http.NewRequest(http.MethodGet, "/sla", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rr, req)
+
+	// then
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var out []pkg.SLAReportDTO
+	err = json.Unmarshal(rr.Body.Bytes(), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, []pkg.SLAReportDTO{{Type: "provision", Total: 2, Breached: 1}}, out)
+}
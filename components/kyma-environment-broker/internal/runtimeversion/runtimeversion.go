@@ -41,3 +41,16 @@ func (rvc *RuntimeVersionConfigurator) ForUpgrade(op internal.UpgradeKymaOperati
 
 	return internal.NewRuntimeVersionFromDefaults(rvc.defaultVersion), nil
 }
+
+// ForAccount returns the Kyma version to which the runtimes of the given accounts are upgraded
+func (rvc *RuntimeVersionConfigurator) ForAccount(globalAccountID, subAccountID string) (string, error) {
+	version, found, err := rvc.accountMapping.Get(globalAccountID, subAccountID)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return version, nil
+	}
+
+	return rvc.defaultVersion, nil
+}
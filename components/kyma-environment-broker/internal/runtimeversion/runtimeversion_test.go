@@ -98,6 +98,34 @@ func Test_RuntimeVersionConfigurator_ForProvisioning_FromParameters(t *testing.T
 	})
 }
 
+func Test_RuntimeVersionConfigurator_ForAccount(t *testing.T) {
+	t.Run("should return version from Defaults when account is not mapped", func(t *testing.T) {
+		// given
+		rvc := NewRuntimeVersionConfigurator("1.1.1", fixAccountVersionMapping(t, map[string]string{}))
+
+		// when
+		ver, err := rvc.ForAccount(fixGlobalAccountID, fixSubAccountID)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, "1.1.1", ver)
+	})
+	t.Run("should return version from SubAccount mapping", func(t *testing.T) {
+		// given
+		rvc := NewRuntimeVersionConfigurator("1.1.1", fixAccountVersionMapping(t, map[string]string{
+			fmt.Sprintf("%s%s", globalAccountPrefix, fixGlobalAccountID): versionForGA,
+			fmt.Sprintf("%s%s", subaccountPrefix, fixSubAccountID):       versionForSA,
+		}))
+
+		// when
+		ver, err := rvc.ForAccount(fixGlobalAccountID, fixSubAccountID)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, versionForSA, ver)
+	})
+}
+
 func fixAccountVersionMapping(t *testing.T, mapping map[string]string) *AccountVersionMapping {
 	sch := runtime.NewScheme()
 	require.NoError(t, coreV1.AddToScheme(sch))
@@ -0,0 +1,22 @@
+package internal
+
+import "time"
+
+// SLAs of the operations agreed with the product, measured from the creation of an operation until it is completed
+const (
+	ProvisioningSLA   = 30 * time.Minute
+	DeprovisioningSLA = 30 * time.Minute
+	UpgradeKymaSLA    = 1 * time.Hour
+)
+
+// SLAStats describes how many completed operations of a given type breached their SLA
+type SLAStats struct {
+	Type     string
+	Total    int
+	Breached int
+}
+
+// IsSLABreached returns true when an operation created at createdAt and completed at completedAt lasted longer than the SLA
+func IsSLABreached(createdAt, completedAt time.Time, sla time.Duration) bool {
+	return completedAt.Sub(createdAt) > sla
+}
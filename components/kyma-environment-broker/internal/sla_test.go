@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSLABreached(t *testing.T) {
+	createdAt := time.Date(2020, 11, 10, 10, 0, 0, 0, time.UTC)
+
+	for name, sla := range map[string]time.Duration{
+		"provisioning":   ProvisioningSLA,
+		"deprovisioning": DeprovisioningSLA,
+		"upgrade kyma":   UpgradeKymaSLA,
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, tc := range []struct {
+				duration time.Duration
+				expected bool
+			}{
+				{duration: sla - time.Second, expected: false},
+				{duration: sla, expected: false},
+				{duration: sla + time.Second, expected: true},
+			} {
+				assert.Equal(t, tc.expected, IsSLABreached(createdAt, createdAt.Add(tc.duration), sla), "duration %s", tc.duration)
+			}
+		})
+	}
+}
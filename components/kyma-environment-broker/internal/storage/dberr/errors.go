@@ -7,8 +7,12 @@ const (
 	CodeNotFound      = 2
 	CodeAlreadyExists = 3
 	CodeConflict      = 4
+	CodeEmptyID       = 5
 )
 
+// ErrEmptyID is returned when an entity without the primary key is inserted into the storage
+var ErrEmptyID Error = errorf(CodeEmptyID, "entity ID must not be empty")
+
 type Error interface {
 	Append(string, ...interface{}) Error
 	Code() int
@@ -47,6 +51,19 @@ func Conflict(format string, a ...interface{}) Error {
 	return errorf(CodeConflict, format, a...)
 }
 
+// RequireID returns ErrEmptyID when the given primary key is empty
+func RequireID(id string) Error {
+	if id == "" {
+		return ErrEmptyID
+	}
+	return nil
+}
+
+func IsEmptyID(err error) bool {
+	dbe, ok := err.(Error)
+	return ok && dbe.Code() == CodeEmptyID
+}
+
 func (e dbError) Append(additionalFormat string, a ...interface{}) Error {
 	format := additionalFormat + ", " + e.message
 	return errorf(e.code, format, a...)
@@ -73,3 +73,13 @@ func TestAppError(t *testing.T) {
 		assert.True(t, checkTwo)
 	})
 }
+
+func TestRequireID(t *testing.T) {
+	assert.NoError(t, RequireID("id"))
+
+	err := RequireID("")
+	assert.Error(t, err)
+	assert.True(t, IsEmptyID(err))
+	assert.True(t, IsEmptyID(err.Append("while inserting")))
+	assert.False(t, IsEmptyID(Internal("error")))
+}
@@ -50,6 +50,21 @@ type OperationStatEntry struct {
 	PlanID string
 }
 
+// FailureGroupEntry holds the number of failed operations with the same normalized description
+// and a comma separated list of example runtime IDs
+type FailureGroupEntry struct {
+	Message  string
+	Total    int
+	Examples string
+}
+
+// SLAStatsEntry holds the number of completed operations of a given type and the number of the ones which breached the SLA
+type SLAStatsEntry struct {
+	Type     string
+	Total    int
+	Breached int
+}
+
 type InstanceByGlobalAccountIDStatEntry struct {
 	GlobalAccountID string
 	Total           int
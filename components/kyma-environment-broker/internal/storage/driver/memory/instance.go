@@ -141,6 +141,9 @@ func (s *instances) Delete(instanceID string) error {
 }
 
 func (s *instances) Insert(instance internal.Instance) error {
+	if err := dberr.RequireID(instance.InstanceID); err != nil {
+		return err.Append("while inserting instance")
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.instances[instance.InstanceID] = instance
@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstances_InsertWithEmptyID(t *testing.T) {
+	// given
+	svc := NewInstance(NewOperation())
+	err := svc.Insert(internal.Instance{InstanceID: "inst-1", GlobalAccountID: "ga-1"})
+	require.NoError(t, err)
+
+	// when
+	err = svc.Insert(internal.Instance{GlobalAccountID: "ga-2"})
+
+	// then
+	assert.True(t, dberr.IsEmptyID(err))
+	_, err = svc.GetByID("")
+	assert.True(t, dberr.IsNotFound(err))
+	inst, err := svc.GetByID("inst-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ga-1", inst.GlobalAccountID)
+}
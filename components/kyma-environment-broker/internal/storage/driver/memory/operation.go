@@ -1,8 +1,10 @@
 package memory
 
 import (
+	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
@@ -36,6 +38,9 @@ func (s *operations) InsertProvisioningOperation(operation internal.Provisioning
 	defer s.mu.Unlock()
 
 	id := operation.ID
+	if err := dberr.RequireID(id); err != nil {
+		return err.Append("while inserting operation")
+	}
 	if _, exists := s.provisioningOperations[id]; exists {
 		return dberr.AlreadyExists("instance operation with id %s already exist", id)
 	}
@@ -101,11 +106,35 @@ func (s *operations) ListProvisioningOperationsByInstanceID(instanceID string) (
 	return operations, nil
 }
 
+func (s *operations) GetProvisioningOperationByFingerprint(instanceID, fingerprint string, since time.Time) (*internal.ProvisioningOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []internal.ProvisioningOperation
+	for _, op := range s.provisioningOperations {
+		if op.InstanceID != instanceID || op.Fingerprint != fingerprint {
+			continue
+		}
+		if op.State == domain.InProgress || !op.CreatedAt.Before(since) {
+			result = append(result, op)
+		}
+	}
+	if len(result) == 0 {
+		return nil, dberr.NotFound("provisioning operation of instance %s with fingerprint %s not found", instanceID, fingerprint)
+	}
+	s.sortProvisioningByCreatedAtDesc(result)
+
+	return &result[0], nil
+}
+
 func (s *operations) InsertDeprovisioningOperation(operation internal.DeprovisioningOperation) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	id := operation.ID
+	if err := dberr.RequireID(id); err != nil {
+		return err.Append("while inserting operation")
+	}
 	if _, exists := s.deprovisioningOperations[id]; exists {
 		return dberr.AlreadyExists("instance operation with id %s already exist", id)
 	}
@@ -176,6 +205,9 @@ func (s *operations) InsertUpgradeKymaOperation(operation internal.UpgradeKymaOp
 	defer s.mu.Unlock()
 
 	id := operation.Operation.ID
+	if err := dberr.RequireID(id); err != nil {
+		return err.Append("while inserting operation")
+	}
 	if _, exists := s.upgradeKymaOperations[id]; exists {
 		return dberr.AlreadyExists("instance operation with id %s already exist", id)
 	}
@@ -184,6 +216,33 @@ func (s *operations) InsertUpgradeKymaOperation(operation internal.UpgradeKymaOp
 	return nil
 }
 
+// InsertUpgradeKymaOperations inserts all given operations or none of them. The whole batch is validated
+// before the first insert, so the error names the operation which conflicts and the storage stays untouched.
+func (s *operations) InsertUpgradeKymaOperations(operations []internal.UpgradeKymaOperation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch := make(map[string]struct{}, len(operations))
+	for i, operation := range operations {
+		id := operation.Operation.ID
+		if err := dberr.RequireID(id); err != nil {
+			return err.Append("while inserting operation %d of the batch", i)
+		}
+		if _, exists := s.upgradeKymaOperations[id]; exists {
+			return dberr.AlreadyExists("instance operation with id %s (operation %d of the batch) already exist", id, i)
+		}
+		if _, exists := batch[id]; exists {
+			return dberr.AlreadyExists("instance operation with id %s (operation %d of the batch) is duplicated in the batch", id, i)
+		}
+		batch[id] = struct{}{}
+	}
+
+	for _, operation := range operations {
+		s.upgradeKymaOperations[operation.Operation.ID] = operation
+	}
+	return nil
+}
+
 func (s *operations) GetUpgradeKymaOperationByID(operationID string) (*internal.UpgradeKymaOperation, error) {
 	op, exists := s.upgradeKymaOperations[operationID]
 	if !exists {
@@ -219,6 +278,27 @@ func (s *operations) UpdateUpgradeKymaOperation(op internal.UpgradeKymaOperation
 	return &op, nil
 }
 
+// UpsertUpgradeKymaOperation inserts the operation if it does not exist, otherwise it updates the operation respecting its version
+func (s *operations) UpsertUpgradeKymaOperation(op internal.UpgradeKymaOperation) (*internal.UpgradeKymaOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := op.Operation.ID
+	if err := dberr.RequireID(id); err != nil {
+		return nil, err.Append("while upserting operation")
+	}
+	oldOp, exists := s.upgradeKymaOperations[id]
+	if exists {
+		if oldOp.Version != op.Version {
+			return nil, dberr.Conflict("unable to update upgradeKyma operation with id %s (for instance id %s) - conflict", id, op.InstanceID)
+		}
+		op.Version = op.Version + 1
+	}
+	s.upgradeKymaOperations[id] = op
+
+	return &op, nil
+}
+
 func (s *operations) GetLastOperation(instanceID string) (*internal.Operation, error) {
 	var rows []internal.Operation
 
@@ -289,11 +369,47 @@ func (s *operations) GetNotFinishedOperationsByType(opType dbmodel.OperationType
 				ops = append(ops, op.Operation)
 			}
 		}
+	case dbmodel.OperationTypeUpgradeKyma:
+		for _, op := range s.upgradeKymaOperations {
+			if op.State == domain.InProgress {
+				ops = append(ops, op.Operation)
+			}
+		}
 	}
 
 	return ops, nil
 }
 
+// ListPendingOperationsByPriority returns all pending and in progress operations ordered by priority, the highest first, and by creation time, the oldest first
+func (s *operations) ListPendingOperationsByPriority() ([]internal.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ops := make([]internal.Operation, 0)
+	for _, op := range s.provisioningOperations {
+		if isPending(op.Operation) {
+			ops = append(ops, op.Operation)
+		}
+	}
+	for _, op := range s.deprovisioningOperations {
+		if isPending(op.Operation) {
+			ops = append(ops, op.Operation)
+		}
+	}
+	for _, op := range s.upgradeKymaOperations {
+		if isPending(op.Operation) {
+			ops = append(ops, op.Operation)
+		}
+	}
+	internal.SortOperationsByPriority(ops)
+
+	return ops, nil
+}
+
+func isPending(op internal.Operation) bool {
+	return op.State == orchestration.Pending || op.State == domain.InProgress
+}
+
 func (s *operations) GetOperationsForIDs(opIdList []string) ([]internal.Operation, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -383,6 +499,88 @@ func (s *operations) GetOperationStatsForOrchestration(orchestrationID string) (
 	return result, nil
 }
 
+func (s *operations) GetFailureGroups(limit int) ([]internal.FailureGroup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	operations, err := s.filterAll(dbmodel.OperationFilter{States: []string{string(domain.Failed)}})
+	switch {
+	case dberr.IsNotFound(err):
+		return []internal.FailureGroup{}, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "while listing failed operations")
+	}
+	s.sortByCreatedAt(operations)
+
+	groups := make(map[string]*internal.FailureGroup)
+	for _, op := range operations {
+		message := internal.NormalizeFailureDescription(op.Description)
+		group, found := groups[message]
+		if !found {
+			group = &internal.FailureGroup{Message: message, RuntimeIDs: []string{}}
+			groups[message] = group
+		}
+		group.Count++
+		if op.RuntimeID != "" && len(group.RuntimeIDs) < internal.FailureGroupExamples && !containsString(group.RuntimeIDs, op.RuntimeID) {
+			group.RuntimeIDs = append(group.RuntimeIDs, op.RuntimeID)
+		}
+	}
+
+	result := make([]internal.FailureGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Message < result[j].Message
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+func (s *operations) GetSLAStats() ([]internal.SLAStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := []internal.SLAStats{
+		{Type: string(dbmodel.OperationTypeDeprovision)},
+		{Type: string(dbmodel.OperationTypeProvision)},
+		{Type: string(dbmodel.OperationTypeUpgradeKyma)},
+	}
+	count := func(stat *internal.SLAStats, op internal.Operation) {
+		if op.State != domain.Succeeded && op.State != domain.Failed {
+			return
+		}
+		stat.Total++
+		if op.SLABreached {
+			stat.Breached++
+		}
+	}
+	for _, op := range s.deprovisioningOperations {
+		count(&stats[0], op.Operation)
+	}
+	for _, op := range s.provisioningOperations {
+		count(&stats[1], op.Operation)
+	}
+	for _, op := range s.upgradeKymaOperations {
+		count(&stats[2], op.Operation)
+	}
+
+	result := make([]internal.SLAStats, 0, len(stats))
+	for _, stat := range stats {
+		if stat.Total > 0 {
+			result = append(result, stat)
+		}
+	}
+
+	return result, nil
+}
+
 func (s *operations) ListOperations(filter dbmodel.OperationFilter) ([]internal.Operation, int, int, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -406,15 +604,88 @@ func (s *operations) ListOperations(filter dbmodel.OperationFilter) ([]internal.
 		nil
 }
 
-func (s *operations) ListUpgradeKymaOperations() ([]internal.UpgradeKymaOperation, error) {
+func (s *operations) ListOperationsByState(state domain.LastOperationState) ([]internal.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	operations, err := s.filterAll(dbmodel.OperationFilter{States: []string{string(state)}})
+	if err != nil {
+		if dberr.IsNotFound(err) {
+			return []internal.Operation{}, nil
+		}
+		return nil, errors.Wrapf(err, "while listing operations in state %s", state)
+	}
+	s.sortByCreatedAt(operations)
+
+	return operations, nil
+}
+
+func (s *operations) ListOperationsInTimeRange(from, to time.Time) ([]internal.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	operations := make([]internal.Operation, 0)
+	ops, err := s.getAll()
+	if err != nil {
+		if dberr.IsNotFound(err) {
+			return operations, nil
+		}
+		return nil, errors.Wrap(err, "while listing operations in time range")
+	}
+	for _, op := range ops {
+		if (!from.IsZero() && op.CreatedAt.Before(from)) || (!to.IsZero() && op.CreatedAt.After(to)) {
+			continue
+		}
+		operations = append(operations, op)
+	}
+	s.sortByCreatedAt(operations)
+
+	return operations, nil
+}
+
+func (s *operations) ForEach(ctx context.Context, filter dbmodel.OperationFilter, fn func(internal.Operation) error) error {
+	s.mu.Lock()
+	operations, err := s.filterAll(filter)
+	s.mu.Unlock()
+	if err != nil {
+		if dberr.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "while iterating over operations")
+	}
+	s.sortByCreatedAt(operations)
+
+	for _, op := range operations {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(op); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListUpgradeKymaOperations returns the given page of the upgrade kyma operations, the newest first, and the total number of them
+func (s *operations) ListUpgradeKymaOperations(pageSize, page int) ([]internal.UpgradeKymaOperation, int, error) {
+	if err := pagination.ValidatePageParameters(pageSize, page); err != nil {
+		return nil, -1, errors.Wrap(err, "while validating page parameters")
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Empty filter means get all
 	operations := s.filterUpgrade(dbmodel.OperationFilter{})
-	s.sortUpgradeByCreatedAt(operations)
+	s.sortUpgradeByCreatedAtDesc(operations)
 
-	return operations, nil
+	result := make([]internal.UpgradeKymaOperation, 0, pageSize)
+	for i := (page - 1) * pageSize; i < page*pageSize && i < len(operations); i++ {
+		result = append(result, operations[i])
+	}
+
+	return result, len(operations), nil
 }
 
 func (s *operations) ListUpgradeKymaOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]internal.UpgradeKymaOperation, int, int, error) {
@@ -424,11 +695,16 @@ func (s *operations) ListUpgradeKymaOperationsByOrchestrationID(orchestrationID
 	result := make([]internal.UpgradeKymaOperation, 0)
 	offset := pagination.ConvertPageAndPageSizeToOffset(filter.PageSize, filter.Page)
 
-	operations := s.filterUpgrade(filter)
+	operations := make([]internal.UpgradeKymaOperation, 0)
+	for _, op := range s.filterUpgrade(filter) {
+		if op.OrchestrationID == orchestrationID {
+			operations = append(operations, op)
+		}
+	}
 	s.sortUpgradeByCreatedAt(operations)
 
-	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations)+offset; i++ {
-		result = append(result, s.upgradeKymaOperations[operations[i].OrchestrationID])
+	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations); i++ {
+		result = append(result, operations[i])
 	}
 
 	return result,
@@ -442,7 +718,12 @@ func (s *operations) ListUpgradeKymaOperationsByInstanceID(instanceID string) ([
 	defer s.mu.Unlock()
 
 	// Empty filter means get all
-	operations := s.filterUpgrade(dbmodel.OperationFilter{})
+	operations := make([]internal.UpgradeKymaOperation, 0)
+	for _, op := range s.filterUpgrade(dbmodel.OperationFilter{}) {
+		if op.InstanceID == instanceID {
+			operations = append(operations, op)
+		}
+	}
 	s.sortUpgradeByCreatedAt(operations)
 
 	return operations, nil
@@ -454,6 +735,12 @@ func (s *operations) sortUpgradeByCreatedAt(operations []internal.UpgradeKymaOpe
 	})
 }
 
+func (s *operations) sortUpgradeByCreatedAtDesc(operations []internal.UpgradeKymaOperation) {
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].CreatedAt.After(operations[j].CreatedAt)
+	})
+}
+
 func (s *operations) sortProvisioningByCreatedAtDesc(operations []internal.ProvisioningOperation) {
 	sort.Slice(operations, func(i, j int) bool {
 		return operations[i].CreatedAt.After(operations[j].CreatedAt)
@@ -569,3 +856,12 @@ func (s *operations) filterUpgrade(filter dbmodel.OperationFilter) []internal.Up
 func (s *operations) equalFilter(a, b string) bool {
 	return a == b
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,555 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperations_ForEach(t *testing.T) {
+	t.Run("should process all operations ordered by creation time", func(t *testing.T) {
+		// given
+		svc := fixOperations(t)
+		var ids []string
+
+		// when
+		err := svc.ForEach(context.Background(), dbmodel.OperationFilter{}, func(op internal.Operation) error {
+			ids = append(ids, op.ID)
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"op-1", "op-2", "op-3"}, ids)
+	})
+
+	t.Run("should process only operations matching the filter", func(t *testing.T) {
+		// given
+		svc := fixOperations(t)
+		var ids []string
+
+		// when
+		err := svc.ForEach(context.Background(), dbmodel.OperationFilter{States: []string{string(domain.Failed)}}, func(op internal.Operation) error {
+			ids = append(ids, op.ID)
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"op-2"}, ids)
+	})
+
+	t.Run("should not fail when there are no operations", func(t *testing.T) {
+		// given
+		svc := NewOperation()
+		called := 0
+
+		// when
+		err := svc.ForEach(context.Background(), dbmodel.OperationFilter{}, func(op internal.Operation) error {
+			called++
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, called)
+	})
+
+	t.Run("should stop on callback error", func(t *testing.T) {
+		// given
+		svc := fixOperations(t)
+		givenErr := errors.New("callback error")
+		called := 0
+
+		// when
+		err := svc.ForEach(context.Background(), dbmodel.OperationFilter{}, func(op internal.Operation) error {
+			called++
+			return givenErr
+		})
+
+		// then
+		assert.Equal(t, givenErr, err)
+		assert.Equal(t, 1, called)
+	})
+
+	t.Run("should stop when context is canceled", func(t *testing.T) {
+		// given
+		svc := fixOperations(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		called := 0
+
+		// when
+		err := svc.ForEach(ctx, dbmodel.OperationFilter{}, func(op internal.Operation) error {
+			called++
+			cancel()
+			return nil
+		})
+
+		// then
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 1, called)
+	})
+}
+
+func TestOperations_InsertWithEmptyID(t *testing.T) {
+	// given
+	svc := NewOperation()
+	err := svc.InsertProvisioningOperation(internal.ProvisioningOperation{Operation: internal.Operation{ID: "op-1", InstanceID: "inst-1"}})
+	require.NoError(t, err)
+
+	t.Run("should reject provisioning operation", func(t *testing.T) {
+		// when
+		err := svc.InsertProvisioningOperation(internal.ProvisioningOperation{Operation: internal.Operation{InstanceID: "inst-2"}})
+
+		// then
+		assert.True(t, dberr.IsEmptyID(err))
+		_, err = svc.GetProvisioningOperationByInstanceID("inst-2")
+		assert.True(t, dberr.IsNotFound(err))
+	})
+
+	t.Run("should reject deprovisioning operation", func(t *testing.T) {
+		// when
+		err := svc.InsertDeprovisioningOperation(internal.DeprovisioningOperation{Operation: internal.Operation{InstanceID: "inst-1"}})
+
+		// then
+		assert.True(t, dberr.IsEmptyID(err))
+		_, err = svc.GetDeprovisioningOperationByInstanceID("inst-1")
+		assert.True(t, dberr.IsNotFound(err))
+	})
+
+	t.Run("should reject upgrade kyma operation", func(t *testing.T) {
+		// when
+		err := svc.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: internal.Operation{InstanceID: "inst-1"}})
+
+		// then
+		assert.True(t, dberr.IsEmptyID(err))
+		_, err = svc.GetUpgradeKymaOperationByInstanceID("inst-1")
+		assert.True(t, dberr.IsNotFound(err))
+	})
+}
+
+func TestOperations_ListOperationsByState(t *testing.T) {
+	// given
+	svc := fixOperations(t)
+	now := time.Now()
+	err := svc.InsertProvisioningOperation(internal.ProvisioningOperation{
+		Operation: internal.Operation{ID: "op-4", State: domain.InProgress, CreatedAt: now.Add(-time.Hour)},
+	})
+	require.NoError(t, err)
+
+	for state, expected := range map[domain.LastOperationState][]string{
+		domain.InProgress: {"op-4", "op-3"},
+		domain.Failed:     {"op-2"},
+		domain.Succeeded:  {"op-1"},
+		"pending":         {},
+	} {
+		t.Run(string(state), func(t *testing.T) {
+			// when
+			ops, err := svc.ListOperationsByState(state)
+
+			// then
+			require.NoError(t, err)
+			ids := make([]string, 0)
+			for _, op := range ops {
+				ids = append(ids, op.ID)
+			}
+			assert.Equal(t, expected, ids)
+		})
+	}
+}
+
+func TestOperations_ListPendingOperationsByPriority(t *testing.T) {
+	// given
+	svc := NewOperation()
+	now := time.Now()
+	for _, op := range []internal.Operation{
+		{ID: "low-old", State: orchestration.Pending, Priority: 1, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "high-new", State: domain.InProgress, Priority: 10, CreatedAt: now},
+		{ID: "default", State: orchestration.Pending, CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "high-old", State: orchestration.Pending, Priority: 10, CreatedAt: now.Add(-time.Hour)},
+		{ID: "low-new", State: domain.InProgress, Priority: 1, CreatedAt: now.Add(-time.Minute)},
+		{ID: "high-finished", State: domain.Succeeded, Priority: 20, CreatedAt: now.Add(-time.Hour)},
+		{ID: "high-failed", State: domain.Failed, Priority: 20, CreatedAt: now.Add(-time.Hour)},
+	} {
+		err := svc.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: op})
+		require.NoError(t, err)
+	}
+	err := svc.InsertProvisioningOperation(internal.ProvisioningOperation{
+		Operation: internal.Operation{ID: "provisioning", State: domain.InProgress, Priority: 5, CreatedAt: now},
+	})
+	require.NoError(t, err)
+
+	// when
+	ops, err := svc.ListPendingOperationsByPriority()
+
+	// then
+	require.NoError(t, err)
+	ids := make([]string, 0)
+	for _, op := range ops {
+		ids = append(ids, op.ID)
+	}
+	assert.Equal(t, []string{"high-old", "high-new", "provisioning", "low-old", "low-new", "default"}, ids)
+}
+
+func TestOperations_ListOperationsInTimeRange(t *testing.T) {
+	// given
+	svc := NewOperation()
+	now := time.Now()
+	for i, id := range []string{"op-1", "op-2", "op-3"} {
+		err := svc.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+			Operation: internal.Operation{ID: id, State: domain.Succeeded, CreatedAt: now.Add(time.Duration(i) * time.Hour)},
+		})
+		require.NoError(t, err)
+	}
+
+	for name, tc := range map[string]struct {
+		from     time.Time
+		to       time.Time
+		expected []string
+	}{
+		"both open": {
+			expected: []string{"op-1", "op-2", "op-3"},
+		},
+		"open from": {
+			to:       now.Add(time.Hour),
+			expected: []string{"op-1", "op-2"},
+		},
+		"open to": {
+			from:     now.Add(time.Hour),
+			expected: []string{"op-2", "op-3"},
+		},
+		"both bounds": {
+			from:     now.Add(time.Hour),
+			to:       now.Add(time.Hour),
+			expected: []string{"op-2"},
+		},
+		"empty result": {
+			from:     now.Add(3 * time.Hour),
+			expected: []string{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			ops, err := svc.ListOperationsInTimeRange(tc.from, tc.to)
+
+			// then
+			require.NoError(t, err)
+			ids := make([]string, 0)
+			for _, op := range ops {
+				ids = append(ids, op.ID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func TestOperations_UpsertUpgradeKymaOperation(t *testing.T) {
+	t.Run("should insert missing operation", func(t *testing.T) {
+		// given
+		svc := NewOperation()
+
+		// when
+		op, err := svc.UpsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: internal.Operation{ID: "op-1", InstanceID: "inst-1"}})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 0, op.Version)
+		stored, err := svc.GetUpgradeKymaOperationByID("op-1")
+		require.NoError(t, err)
+		assert.Equal(t, "inst-1", stored.InstanceID)
+	})
+
+	t.Run("should update existing operation", func(t *testing.T) {
+		// given
+		svc := NewOperation()
+		err := svc.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: internal.Operation{ID: "op-1", InstanceID: "inst-1"}})
+		require.NoError(t, err)
+
+		// when
+		op, err := svc.UpsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: internal.Operation{ID: "op-1", InstanceID: "inst-1", Description: "updated"}})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, op.Version)
+		stored, err := svc.GetUpgradeKymaOperationByID("op-1")
+		require.NoError(t, err)
+		assert.Equal(t, "updated", stored.Description)
+	})
+
+	t.Run("should reject operation with stale version", func(t *testing.T) {
+		// given
+		svc := NewOperation()
+		err := svc.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: internal.Operation{ID: "op-1", InstanceID: "inst-1", Version: 2}})
+		require.NoError(t, err)
+
+		// when
+		_, err = svc.UpsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: internal.Operation{ID: "op-1", InstanceID: "inst-1", Version: 1, Description: "stale"}})
+
+		// then
+		assert.True(t, dberr.IsConflict(err))
+		stored, err := svc.GetUpgradeKymaOperationByID("op-1")
+		require.NoError(t, err)
+		assert.Empty(t, stored.Description)
+	})
+}
+
+func TestOperations_InsertUpgradeKymaOperations(t *testing.T) {
+	t.Run("should insert all operations", func(t *testing.T) {
+		// given
+		svc := NewOperation()
+
+		// when
+		err := svc.InsertUpgradeKymaOperations([]internal.UpgradeKymaOperation{
+			{Operation: internal.Operation{ID: "op-1", InstanceID: "inst-1"}},
+			{Operation: internal.Operation{ID: "op-2", InstanceID: "inst-2"}},
+			{Operation: internal.Operation{ID: "op-3", InstanceID: "inst-3"}},
+		})
+
+		// then
+		require.NoError(t, err)
+		ops, total, err := svc.ListUpgradeKymaOperations(10, 1)
+		require.NoError(t, err)
+		assert.Len(t, ops, 3)
+		assert.Equal(t, 3, total)
+	})
+
+	t.Run("should not insert any operation when one in the middle of the batch conflicts", func(t *testing.T) {
+		// given
+		svc := NewOperation()
+		err := svc.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: internal.Operation{ID: "op-2", InstanceID: "inst-2"}})
+		require.NoError(t, err)
+
+		// when
+		err = svc.InsertUpgradeKymaOperations([]internal.UpgradeKymaOperation{
+			{Operation: internal.Operation{ID: "op-1", InstanceID: "inst-1"}},
+			{Operation: internal.Operation{ID: "op-2", InstanceID: "inst-2", Description: "conflicting"}},
+			{Operation: internal.Operation{ID: "op-3", InstanceID: "inst-3"}},
+		})
+
+		// then
+		require.Error(t, err)
+		dbErr, ok := err.(dberr.Error)
+		require.True(t, ok)
+		assert.Equal(t, dberr.CodeAlreadyExists, dbErr.Code())
+		assert.Contains(t, err.Error(), "op-2")
+
+		ops, _, err := svc.ListUpgradeKymaOperations(10, 1)
+		require.NoError(t, err)
+		require.Len(t, ops, 1)
+		assert.Equal(t, "op-2", ops[0].Operation.ID)
+		assert.Empty(t, ops[0].Description)
+	})
+}
+
+func TestOperations_ListUpgradeKymaOperations(t *testing.T) {
+	// given
+	svc := NewOperation()
+	now := time.Now()
+	for i, id := range []string{"op-1", "op-2", "op-3", "op-4", "op-5"} {
+		err := svc.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: internal.Operation{
+			ID:        id,
+			CreatedAt: now.Add(time.Duration(i) * time.Minute),
+		}})
+		require.NoError(t, err)
+	}
+
+	for tn, tc := range map[string]struct {
+		pageSize    int
+		page        int
+		expectedIDs []string
+	}{
+		"first page": {
+			pageSize:    2,
+			page:        1,
+			expectedIDs: []string{"op-5", "op-4"},
+		},
+		"middle page": {
+			pageSize:    2,
+			page:        2,
+			expectedIDs: []string{"op-3", "op-2"},
+		},
+		"last incomplete page": {
+			pageSize:    2,
+			page:        3,
+			expectedIDs: []string{"op-1"},
+		},
+		"out of range page": {
+			pageSize:    2,
+			page:        4,
+			expectedIDs: []string{},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			ops, total, err := svc.ListUpgradeKymaOperations(tc.pageSize, tc.page)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, 5, total)
+			ids := make([]string, 0, len(ops))
+			for _, op := range ops {
+				ids = append(ids, op.Operation.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+
+	t.Run("should reject invalid page parameters", func(t *testing.T) {
+		// when
+		_, _, errPage := svc.ListUpgradeKymaOperations(2, 0)
+		_, _, errPageSize := svc.ListUpgradeKymaOperations(0, 1)
+
+		// then
+		assert.Error(t, errPage)
+		assert.Error(t, errPageSize)
+	})
+}
+
+func TestOperations_GetProvisioningOperationByFingerprint(t *testing.T) {
+	// given
+	svc := NewOperation()
+	now := time.Now()
+	for _, op := range []internal.ProvisioningOperation{
+		{Operation: internal.Operation{ID: "old-succeeded", InstanceID: "inst-1", State: domain.Succeeded, CreatedAt: now.Add(-2 * time.Hour)}, Fingerprint: "fp-1"},
+		{Operation: internal.Operation{ID: "old-in-progress", InstanceID: "inst-1", State: domain.InProgress, CreatedAt: now.Add(-2 * time.Hour)}, Fingerprint: "fp-2"},
+		{Operation: internal.Operation{ID: "recent-failed", InstanceID: "inst-1", State: domain.Failed, CreatedAt: now.Add(-time.Minute)}, Fingerprint: "fp-3"},
+		{Operation: internal.Operation{ID: "other-instance", InstanceID: "inst-2", State: domain.InProgress, CreatedAt: now.Add(-time.Minute)}, Fingerprint: "fp-4"},
+	} {
+		err := svc.InsertProvisioningOperation(op)
+		require.NoError(t, err)
+	}
+	since := now.Add(-time.Hour)
+
+	for fingerprint, expectedID := range map[string]string{
+		"fp-2": "old-in-progress",
+		"fp-3": "recent-failed",
+	} {
+		t.Run("should find operation with "+fingerprint, func(t *testing.T) {
+			// when
+			op, err := svc.GetProvisioningOperationByFingerprint("inst-1", fingerprint, since)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, expectedID, op.ID)
+		})
+	}
+
+	for _, fingerprint := range []string{"fp-1", "fp-4"} {
+		t.Run("should not find operation with "+fingerprint, func(t *testing.T) {
+			// when
+			_, err := svc.GetProvisioningOperationByFingerprint("inst-1", fingerprint, since)
+
+			// then
+			assert.True(t, dberr.IsNotFound(err))
+		})
+	}
+}
+
+func TestOperations_GetFailureGroups(t *testing.T) {
+	// given
+	svc := NewOperation()
+	now := time.Now()
+	for i, op := range []internal.Operation{
+		{ID: "op-1", State: domain.Failed, Description: "provisioner operation 9d75a545-2e1e-4786-abd8-a37b14e185b9 failed", InstanceDetails: internal.InstanceDetails{RuntimeID: "rid-1"}},
+		{ID: "op-2", State: domain.Failed, Description: "provisioner operation ef4e3210-652c-453e-8015-bba1c1cd1e1c failed", InstanceDetails: internal.InstanceDetails{RuntimeID: "rid-2"}},
+		{ID: "op-3", State: domain.Failed, Description: "provisioner operation 3a7d2b6b-4aa4-4c2e-9fd6-9e56cd6ffc0a failed", InstanceDetails: internal.InstanceDetails{RuntimeID: "rid-1"}},
+		{ID: "op-4", State: domain.Failed, Description: "call to provisioner failed after 3 retries", InstanceDetails: internal.InstanceDetails{RuntimeID: "rid-3"}},
+		{ID: "op-5", State: domain.Failed, Description: "call to provisioner failed after 5 retries"},
+		{ID: "op-6", State: domain.Failed, Description: "operation has reached the time limit"},
+		{ID: "op-7", State: domain.Succeeded, Description: "provisioner operation 9d75a545-2e1e-4786-abd8-a37b14e185b9 failed"},
+	} {
+		op.CreatedAt = now.Add(time.Duration(i) * time.Minute)
+		err := svc.InsertProvisioningOperation(internal.ProvisioningOperation{Operation: op})
+		require.NoError(t, err)
+	}
+
+	t.Run("should group failed operations by normalized description", func(t *testing.T) {
+		// when
+		groups, err := svc.GetFailureGroups(0)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []internal.FailureGroup{
+			{Message: "provisioner operation <id> failed", Count: 3, RuntimeIDs: []string{"rid-1", "rid-2"}},
+			{Message: "call to provisioner failed after <n> retries", Count: 2, RuntimeIDs: []string{"rid-3"}},
+			{Message: "operation has reached the time limit", Count: 1, RuntimeIDs: []string{}},
+		}, groups)
+	})
+
+	t.Run("should return only the most frequent groups", func(t *testing.T) {
+		// when
+		groups, err := svc.GetFailureGroups(1)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, 3, groups[0].Count)
+	})
+
+	t.Run("should not fail when there are no operations", func(t *testing.T) {
+		// when
+		groups, err := NewOperation().GetFailureGroups(10)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+}
+
+func TestOperations_GetSLAStats(t *testing.T) {
+	// given
+	svc := NewOperation()
+	for _, op := range []internal.Operation{
+		{ID: "op-1", State: domain.Succeeded},
+		{ID: "op-2", State: domain.Succeeded, SLABreached: true},
+		{ID: "op-3", State: domain.Failed, SLABreached: true},
+		{ID: "op-4", State: domain.InProgress},
+	} {
+		err := svc.InsertProvisioningOperation(internal.ProvisioningOperation{Operation: op})
+		require.NoError(t, err)
+	}
+	err := svc.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+		Operation: internal.Operation{ID: "op-5", State: domain.Succeeded},
+	})
+	require.NoError(t, err)
+
+	// when
+	stats, err := svc.GetSLAStats()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []internal.SLAStats{
+		{Type: string(dbmodel.OperationTypeProvision), Total: 3, Breached: 2},
+		{Type: string(dbmodel.OperationTypeUpgradeKyma), Total: 1, Breached: 0},
+	}, stats)
+}
+
+func fixOperations(t *testing.T) *operations {
+	svc := NewOperation()
+	now := time.Now()
+
+	err := svc.InsertProvisioningOperation(internal.ProvisioningOperation{
+		Operation: internal.Operation{ID: "op-1", State: domain.Succeeded, CreatedAt: now},
+	})
+	require.NoError(t, err)
+	err = svc.InsertDeprovisioningOperation(internal.DeprovisioningOperation{
+		Operation: internal.Operation{ID: "op-2", State: domain.Failed, CreatedAt: now.Add(time.Minute)},
+	})
+	require.NoError(t, err)
+	err = svc.InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+		Operation: internal.Operation{ID: "op-3", State: domain.InProgress, CreatedAt: now.Add(2 * time.Minute)},
+	})
+	require.NoError(t, err)
+
+	return svc
+}
@@ -1,10 +1,13 @@
 package postsql
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -104,6 +107,35 @@ func (s *operations) GetProvisioningOperationByInstanceID(instanceID string) (*i
 	return ret, nil
 }
 
+// GetProvisioningOperationByFingerprint fetches the latest ProvisioningOperation of the instance with the given fingerprint,
+// which is in progress or was created since the given time, returns error if not found
+func (s *operations) GetProvisioningOperationByFingerprint(instanceID, fingerprint string, since time.Time) (*internal.ProvisioningOperation, error) {
+	session := s.NewReadSession()
+	operation := dbmodel.OperationDTO{}
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operation, lastErr = session.GetProvisioningOperationByFingerprint(instanceID, fingerprint, since)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				lastErr = dberr.NotFound("operation does not exist")
+				return false, lastErr
+			}
+			log.Errorf("while reading operation from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	ret, err := s.toProvisioningOperation(&operation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting DTO to Operation")
+	}
+
+	return ret, nil
+}
+
 // UpdateProvisioningOperation updates ProvisioningOperation, fails if not exists or optimistic locking failure occurs.
 func (s *operations) UpdateProvisioningOperation(op internal.ProvisioningOperation) (*internal.ProvisioningOperation, error) {
 	session := s.NewWriteSession()
@@ -310,6 +342,35 @@ func (s *operations) InsertUpgradeKymaOperation(operation internal.UpgradeKymaOp
 	return lastErr
 }
 
+// InsertUpgradeKymaOperations inserts all given operations within one transaction, none of them is stored if any insert fails
+func (s *operations) InsertUpgradeKymaOperations(operations []internal.UpgradeKymaOperation) error {
+	dtos := make([]dbmodel.OperationDTO, 0, len(operations))
+	for i := range operations {
+		dto, err := s.upgradeKymaOperationToDTO(&operations[i])
+		if err != nil {
+			return errors.Wrapf(err, "while inserting upgrade kyma operation (id: %s)", operations[i].Operation.ID)
+		}
+		dtos = append(dtos, dto)
+	}
+
+	session, dbErr := s.NewSessionWithinTransaction()
+	if dbErr != nil {
+		return errors.Wrap(dbErr, "while starting transaction")
+	}
+	defer session.RollbackUnlessCommitted()
+
+	for i, dto := range dtos {
+		if dbErr := session.InsertOperation(dto); dbErr != nil {
+			return errors.Wrapf(dbErr, "while inserting upgrade kyma operation (id: %s, operation %d of the batch)", dto.ID, i)
+		}
+	}
+
+	if dbErr := session.Commit(); dbErr != nil {
+		return errors.Wrap(dbErr, "while committing transaction")
+	}
+	return nil
+}
+
 // GetUpgradeKymaOperationByID fetches the UpgradeKymaOperation by given ID, returns error if not found
 func (s *operations) GetUpgradeKymaOperationByID(operationID string) (*internal.UpgradeKymaOperation, error) {
 	session := s.NewReadSession()
@@ -366,12 +427,20 @@ func (s *operations) GetUpgradeKymaOperationByInstanceID(instanceID string) (*in
 	return ret, nil
 }
 
-func (s *operations) ListUpgradeKymaOperations() ([]internal.UpgradeKymaOperation, error) {
+// ListUpgradeKymaOperations returns the given page of the upgrade kyma operations, the newest first, and the total number of them
+func (s *operations) ListUpgradeKymaOperations(pageSize, page int) ([]internal.UpgradeKymaOperation, int, error) {
+	if err := pagination.ValidatePageParameters(pageSize, page); err != nil {
+		return nil, -1, errors.Wrap(err, "while validating page parameters")
+	}
+
 	session := s.NewReadSession()
-	var operations []dbmodel.OperationDTO
-	var lastErr dberr.Error
+	var (
+		operations []dbmodel.OperationDTO
+		totalCount int
+		lastErr    error
+	)
 	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
-		operations, lastErr = session.ListOperationsByType(dbmodel.OperationTypeUpgradeKyma)
+		operations, totalCount, lastErr = session.ListOperationsByTypeWithPagination(dbmodel.OperationTypeUpgradeKyma, pageSize, page)
 		if lastErr != nil {
 			log.Errorf("while reading operation from the storage: %v", lastErr)
 			return false, nil
@@ -379,14 +448,14 @@ func (s *operations) ListUpgradeKymaOperations() ([]internal.UpgradeKymaOperatio
 		return true, nil
 	})
 	if err != nil {
-		return nil, lastErr
+		return nil, -1, lastErr
 	}
 	ret, err := s.toUpgradeKymaOperationList(operations)
 	if err != nil {
-		return nil, errors.Wrapf(err, "while converting DTO to Operation")
+		return nil, -1, errors.Wrapf(err, "while converting DTO to Operation")
 	}
 
-	return ret, nil
+	return ret, totalCount, nil
 }
 
 func (s *operations) ListUpgradeKymaOperationsByInstanceID(instanceID string) ([]internal.UpgradeKymaOperation, error) {
@@ -442,6 +511,23 @@ func (s *operations) UpdateUpgradeKymaOperation(operation internal.UpgradeKymaOp
 	return &operation, lastErr
 }
 
+// UpsertUpgradeKymaOperation inserts UpgradeKymaOperation if it does not exist, otherwise it updates the operation,
+// which fails if optimistic locking failure occurs.
+func (s *operations) UpsertUpgradeKymaOperation(operation internal.UpgradeKymaOperation) (*internal.UpgradeKymaOperation, error) {
+	_, err := s.GetUpgradeKymaOperationByID(operation.Operation.ID)
+	switch {
+	case dberr.IsNotFound(err):
+		if err := s.InsertUpgradeKymaOperation(operation); err != nil {
+			return nil, errors.Wrapf(err, "while upserting upgrade kyma operation (id: %s)", operation.Operation.ID)
+		}
+		return &operation, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "while upserting upgrade kyma operation (id: %s)", operation.Operation.ID)
+	}
+
+	return s.UpdateUpgradeKymaOperation(operation)
+}
+
 // GetLastOperation returns Operation for given instance ID which is not in 'pending' state. Returns an error if the operation does not exists.
 func (s *operations) GetLastOperation(instanceID string) (*internal.Operation, error) {
 	session := s.NewReadSession()
@@ -463,11 +549,11 @@ func (s *operations) GetLastOperation(instanceID string) (*internal.Operation, e
 	if err != nil {
 		return nil, lastErr
 	}
-	err = json.Unmarshal([]byte(operation.Data), &op)
+	err = unmarshalOperationData(operation.Data, &op)
 	if err != nil {
 		return nil, errors.New("unable to unmarshall operation data")
 	}
-	op, err = s.toOperation(&operation, op.InstanceDetails)
+	op, err = s.toOperation(&operation, op)
 	if err != nil {
 		return nil, err
 	}
@@ -495,11 +581,11 @@ func (s *operations) GetOperationByID(operationID string) (*internal.Operation,
 	if err != nil {
 		return nil, lastErr
 	}
-	err = json.Unmarshal([]byte(operation.Data), &op)
+	err = unmarshalOperationData(operation.Data, &op)
 	if err != nil {
 		return nil, errors.New("unable to unmarshall operation data")
 	}
-	op, err = s.toOperation(&operation, op.InstanceDetails)
+	op, err = s.toOperation(&operation, op)
 	if err != nil {
 		return nil, err
 	}
@@ -563,6 +649,42 @@ func (s *operations) GetOperationStatsForOrchestration(orchestrationID string) (
 	return result, nil
 }
 
+func (s *operations) GetFailureGroups(limit int) ([]internal.FailureGroup, error) {
+	entries, err := s.NewReadSession().GetFailureGroups(limit, internal.FailureGroupExamples)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]internal.FailureGroup, 0, len(entries))
+	for _, entry := range entries {
+		runtimeIDs := []string{}
+		if entry.Examples != "" {
+			runtimeIDs = strings.Split(entry.Examples, ",")
+		}
+		result = append(result, internal.FailureGroup{
+			Message:    entry.Message,
+			Count:      entry.Total,
+			RuntimeIDs: runtimeIDs,
+		})
+	}
+	return result, nil
+}
+
+func (s *operations) GetSLAStats() ([]internal.SLAStats, error) {
+	entries, err := s.NewReadSession().GetSLAStats()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]internal.SLAStats, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, internal.SLAStats{
+			Type:     entry.Type,
+			Total:    entry.Total,
+			Breached: entry.Breached,
+		})
+	}
+	return result, nil
+}
+
 func (s *operations) GetOperationsForIDs(operationIDList []string) ([]internal.Operation, error) {
 	session := s.NewReadSession()
 	operations := make([]dbmodel.OperationDTO, 0)
@@ -607,6 +729,78 @@ func (s *operations) ListOperations(filter dbmodel.OperationFilter) ([]internal.
 	return result, size, total, err
 }
 
+// ListOperationsByState returns all operations in the given state ordered by creation time, the oldest first
+func (s *operations) ListOperationsByState(state domain.LastOperationState) ([]internal.Operation, error) {
+	operations, _, _, err := s.ListOperations(dbmodel.OperationFilter{States: []string{string(state)}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while listing operations in state %s", state)
+	}
+
+	return operations, nil
+}
+
+// ListPendingOperationsByPriority returns all pending and in progress operations ordered by priority, the highest first, and by creation time, the oldest first
+func (s *operations) ListPendingOperationsByPriority() ([]internal.Operation, error) {
+	session := s.NewReadSession()
+	operations := make([]dbmodel.OperationDTO, 0)
+	var lastErr error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operations, lastErr = session.ListNotFinishedOperationsByPriority()
+		if lastErr != nil {
+			log.Errorf("while getting operations from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(lastErr, "while listing pending operations")
+	}
+
+	return s.toOperations(operations)
+}
+
+// ListOperationsInTimeRange returns all operations created within the given time range ordered by creation time, a zero bound leaves the range open
+func (s *operations) ListOperationsInTimeRange(from, to time.Time) ([]internal.Operation, error) {
+	session := s.NewReadSession()
+	operations := make([]dbmodel.OperationDTO, 0)
+	var lastErr error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operations, lastErr = session.ListOperationsInTimeRange(from, to)
+		if lastErr != nil {
+			log.Errorf("while getting operations from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(lastErr, "while listing operations in time range")
+	}
+
+	return s.toOperations(operations)
+}
+
+// ForEach streams operations matching the filter ordered by creation time and calls fn for each of them.
+// It stops on the first error returned by fn or when the context is done.
+func (s *operations) ForEach(ctx context.Context, filter dbmodel.OperationFilter, fn func(internal.Operation) error) error {
+	session := s.NewReadSession()
+
+	return session.ForEachOperation(ctx, filter, func(dto dbmodel.OperationDTO) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		operation := internal.Operation{}
+		err := unmarshalOperationData(dto.Data, &operation)
+		if err != nil {
+			return errors.Wrapf(err, "while unmarshalling data of operation %s", dto.ID)
+		}
+		operation, err = s.toOperation(&dto, operation)
+		if err != nil {
+			return errors.Wrapf(err, "while converting DTO to Operation")
+		}
+		return fn(operation)
+	})
+}
+
 func (s *operations) ListUpgradeKymaOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]internal.UpgradeKymaOperation, int, int, error) {
 	session := s.NewReadSession()
 	var (
@@ -713,7 +907,8 @@ func (s *operations) operationToDB(op internal.Operation) (dbmodel.OperationDTO,
 	}, nil
 }
 
-func (s *operations) toOperation(op *dbmodel.OperationDTO, instanceDetails internal.InstanceDetails) (internal.Operation, error) {
+// toOperation converts the DTO to the operation, taking the fields stored in the operation data from the operation unmarshalled from it
+func (s *operations) toOperation(op *dbmodel.OperationDTO, data internal.Operation) (internal.Operation, error) {
 	pp := internal.ProvisioningParameters{}
 	if op.ProvisioningParameters.Valid {
 		err := json.Unmarshal([]byte(op.ProvisioningParameters.String), &pp)
@@ -737,7 +932,10 @@ func (s *operations) toOperation(op *dbmodel.OperationDTO, instanceDetails inter
 		Version:                op.Version,
 		OrchestrationID:        storage.SQLNullStringToString(op.OrchestrationID),
 		ProvisioningParameters: pp,
-		InstanceDetails:        instanceDetails,
+		InstanceDetails:        data.InstanceDetails,
+		Priority:               data.Priority,
+		SLABreached:            data.SLABreached,
+		ScheduledRetry:         data.ScheduledRetry,
 	}, nil
 }
 
@@ -745,11 +943,11 @@ func (s *operations) toOperations(op []dbmodel.OperationDTO) ([]internal.Operati
 	operations := make([]internal.Operation, 0)
 	for _, o := range op {
 		operation := internal.Operation{}
-		err := json.Unmarshal([]byte(o.Data), &operation)
+		err := unmarshalOperationData(o.Data, &operation)
 		if err != nil {
 			return nil, errors.New("unable to unmarshall provisioning data")
 		}
-		operation, err = s.toOperation(&o, operation.InstanceDetails)
+		operation, err = s.toOperation(&o, operation)
 		if err != nil {
 			return nil, err
 		}
@@ -764,11 +962,11 @@ func (s *operations) toProvisioningOperation(op *dbmodel.OperationDTO) (*interna
 	}
 	var operation internal.ProvisioningOperation
 	var err error
-	err = json.Unmarshal([]byte(op.Data), &operation)
+	err = unmarshalOperationData(op.Data, &operation)
 	if err != nil {
 		return nil, errors.New("unable to unmarshall provisioning data")
 	}
-	operation.Operation, err = s.toOperation(op, operation.InstanceDetails)
+	operation.Operation, err = s.toOperation(op, operation.Operation)
 	if err != nil {
 		return nil, err
 	}
@@ -804,7 +1002,7 @@ func (s *operations) toDeprovisioningOperationList(ops []dbmodel.OperationDTO) (
 }
 
 func (s *operations) provisioningOperationToDTO(op *internal.ProvisioningOperation) (dbmodel.OperationDTO, error) {
-	serialized, err := json.Marshal(op)
+	serialized, err := marshalOperationData(op)
 	if err != nil {
 		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while serializing provisioning data %v", op)
 	}
@@ -813,7 +1011,7 @@ func (s *operations) provisioningOperationToDTO(op *internal.ProvisioningOperati
 	if err != nil {
 		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while converting to operationDB %v", op)
 	}
-	ret.Data = string(serialized)
+	ret.Data = serialized
 	ret.Type = dbmodel.OperationTypeProvision
 	return ret, nil
 }
@@ -824,11 +1022,11 @@ func (s *operations) toDeprovisioningOperation(op *dbmodel.OperationDTO) (*inter
 	}
 	var operation internal.DeprovisioningOperation
 	var err error
-	err = json.Unmarshal([]byte(op.Data), &operation)
+	err = unmarshalOperationData(op.Data, &operation)
 	if err != nil {
 		return nil, errors.New("unable to unmarshall provisioning data")
 	}
-	operation.Operation, err = s.toOperation(op, operation.InstanceDetails)
+	operation.Operation, err = s.toOperation(op, operation.Operation)
 	if err != nil {
 		return nil, err
 	}
@@ -837,7 +1035,7 @@ func (s *operations) toDeprovisioningOperation(op *dbmodel.OperationDTO) (*inter
 }
 
 func (s *operations) deprovisioningOperationToDTO(op *internal.DeprovisioningOperation) (dbmodel.OperationDTO, error) {
-	serialized, err := json.Marshal(op)
+	serialized, err := marshalOperationData(op)
 	if err != nil {
 		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while serializing deprovisioning data %v", op)
 	}
@@ -846,7 +1044,7 @@ func (s *operations) deprovisioningOperationToDTO(op *internal.DeprovisioningOpe
 	if err != nil {
 		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while converting to operationDB %v", op)
 	}
-	ret.Data = string(serialized)
+	ret.Data = serialized
 	ret.Type = dbmodel.OperationTypeDeprovision
 	return ret, nil
 }
@@ -857,11 +1055,11 @@ func (s *operations) toUpgradeKymaOperation(op *dbmodel.OperationDTO) (*internal
 	}
 	var operation internal.UpgradeKymaOperation
 	var err error
-	err = json.Unmarshal([]byte(op.Data), &operation)
+	err = unmarshalOperationData(op.Data, &operation)
 	if err != nil {
 		return nil, errors.New("unable to unmarshall provisioning data")
 	}
-	operation.Operation, err = s.toOperation(op, operation.InstanceDetails)
+	operation.Operation, err = s.toOperation(op, operation.Operation)
 	if err != nil {
 		return nil, err
 	}
@@ -888,7 +1086,7 @@ func (s *operations) toUpgradeKymaOperationList(ops []dbmodel.OperationDTO) ([]i
 }
 
 func (s *operations) upgradeKymaOperationToDTO(op *internal.UpgradeKymaOperation) (dbmodel.OperationDTO, error) {
-	serialized, err := json.Marshal(op)
+	serialized, err := marshalOperationData(op)
 	if err != nil {
 		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while serializing provisioning data %v", op)
 	}
@@ -897,7 +1095,7 @@ func (s *operations) upgradeKymaOperationToDTO(op *internal.UpgradeKymaOperation
 	if err != nil {
 		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while converting to operationDB %v", op)
 	}
-	ret.Data = string(serialized)
+	ret.Data = serialized
 	ret.Type = dbmodel.OperationTypeUpgradeKyma
 	ret.OrchestrationID = storage.StringToSQLNullString(op.OrchestrationID)
 	return ret, nil
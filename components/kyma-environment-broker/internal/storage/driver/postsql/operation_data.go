@@ -0,0 +1,62 @@
+package postsql
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// operationSchemaVersion is the version of the operation data written by this version of the broker.
+// Increase it when the meaning of already stored fields changes, so the data written by older versions can be recognized.
+const operationSchemaVersion = 1
+
+// operationSchemaVersionField is the key of the schema version in the serialized operation data
+const operationSchemaVersionField = "schema_version"
+
+type operationDataHeader struct {
+	SchemaVersion int `json:"schema_version"`
+}
+
+// marshalOperationData serializes the operation together with the schema version of the data
+func marshalOperationData(op interface{}) (string, error) {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return "", err
+	}
+	fields := map[string]json.RawMessage{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return "", errors.Wrap(err, "while adding schema version")
+	}
+	version, err := json.Marshal(operationSchemaVersion)
+	if err != nil {
+		return "", errors.Wrap(err, "while adding schema version")
+	}
+	fields[operationSchemaVersionField] = version
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// unmarshalOperationData deserializes the operation data written by any version of the broker.
+// Fields written by newer versions and unknown to this version are ignored, and so are fields
+// whose type was changed by a newer version. Fields missing in the data written by older versions
+// keep the values set in op before the call, which makes them the defaults.
+func unmarshalOperationData(data string, op interface{}) error {
+	header := operationDataHeader{}
+	err := json.Unmarshal([]byte(data), &header)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal([]byte(data), op)
+	if _, typeErr := err.(*json.UnmarshalTypeError); typeErr && header.SchemaVersion > operationSchemaVersion {
+		log.Warnf("ignoring incompatible field of operation data with schema version %d: %s", header.SchemaVersion, err)
+		return nil
+	}
+	return err
+}
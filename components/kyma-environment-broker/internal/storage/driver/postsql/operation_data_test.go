@@ -0,0 +1,89 @@
+package postsql
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalOperationData(t *testing.T) {
+	// given
+	op := internal.ProvisioningOperation{
+		Operation: internal.Operation{
+			InstanceDetails: internal.InstanceDetails{RuntimeID: "runtime-id"},
+		},
+		Fingerprint: "fingerprint",
+	}
+
+	// when
+	data, err := marshalOperationData(op)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, data, `"schema_version":1`)
+
+	got := internal.ProvisioningOperation{}
+	err = unmarshalOperationData(data, &got)
+	require.NoError(t, err)
+	assert.Equal(t, op.RuntimeID, got.RuntimeID)
+	assert.Equal(t, op.Fingerprint, got.Fingerprint)
+}
+
+func TestUnmarshalOperationData(t *testing.T) {
+	t.Run("should read data written by a newer version", func(t *testing.T) {
+		// given
+		data := `{"schema_version":2,"runtime_id":"runtime-id","fingerprint":"fingerprint","retry_count":3,"tags":["a","b"],"step_results":{"step":{"state":"succeeded"}}}`
+		op := internal.ProvisioningOperation{}
+
+		// when
+		err := unmarshalOperationData(data, &op)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "runtime-id", op.RuntimeID)
+		assert.Equal(t, "fingerprint", op.Fingerprint)
+	})
+
+	t.Run("should ignore fields with a type changed by a newer version", func(t *testing.T) {
+		// given
+		data := `{"schema_version":2,"runtime_id":"runtime-id","lms":"moved to another field"}`
+		op := internal.ProvisioningOperation{}
+
+		// when
+		err := unmarshalOperationData(data, &op)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "runtime-id", op.RuntimeID)
+	})
+
+	t.Run("should fail for fields with invalid type written by the current version", func(t *testing.T) {
+		// given
+		data := `{"schema_version":1,"runtime_id":"runtime-id","lms":"invalid"}`
+		op := internal.ProvisioningOperation{}
+
+		// when
+		err := unmarshalOperationData(data, &op)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should read data written by an older version", func(t *testing.T) {
+		// given
+		data := `{"runtime_id":"runtime-id","shoot_name":"c-1234567"}`
+		op := internal.ProvisioningOperation{Fingerprint: "default"}
+
+		// when
+		err := unmarshalOperationData(data, &op)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "runtime-id", op.RuntimeID)
+		assert.Equal(t, "c-1234567", op.ShootName)
+		assert.Equal(t, "default", op.Fingerprint)
+	})
+}
@@ -1,9 +1,14 @@
 package storage
 
 import (
+	"context"
+	"time"
+
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/predicate"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 )
 
 type Instances interface {
@@ -30,7 +35,19 @@ type Operations interface {
 	GetOperationStatsByPlan() (map[string]internal.OperationStats, error)
 	GetOperationsForIDs(operationIDList []string) ([]internal.Operation, error)
 	GetOperationStatsForOrchestration(orchestrationID string) (map[string]int, error)
+	// GetFailureGroups returns the failed operations grouped by normalized description, the most frequent first
+	GetFailureGroups(limit int) ([]internal.FailureGroup, error)
+	// GetSLAStats returns the number of completed operations and the number of operations which breached the SLA per operation type
+	GetSLAStats() ([]internal.SLAStats, error)
 	ListOperations(filter dbmodel.OperationFilter) ([]internal.Operation, int, int, error)
+	// ListOperationsByState returns all operations in the given state, the oldest first
+	ListOperationsByState(state domain.LastOperationState) ([]internal.Operation, error)
+	// ListOperationsInTimeRange returns all operations created within the given time range including both bounds, the oldest first.
+	// A zero from or to leaves the range open.
+	ListOperationsInTimeRange(from, to time.Time) ([]internal.Operation, error)
+	// ListPendingOperationsByPriority returns all pending and in progress operations, the highest priority first and the oldest first within the same priority
+	ListPendingOperationsByPriority() ([]internal.Operation, error)
+	ForEach(ctx context.Context, filter dbmodel.OperationFilter, fn func(internal.Operation) error) error
 }
 
 type Provisioning interface {
@@ -39,6 +56,7 @@ type Provisioning interface {
 	GetProvisioningOperationByInstanceID(instanceID string) (*internal.ProvisioningOperation, error)
 	UpdateProvisioningOperation(operation internal.ProvisioningOperation) (*internal.ProvisioningOperation, error)
 	ListProvisioningOperationsByInstanceID(instanceID string) ([]internal.ProvisioningOperation, error)
+	GetProvisioningOperationByFingerprint(instanceID, fingerprint string, since time.Time) (*internal.ProvisioningOperation, error)
 }
 
 type Deprovisioning interface {
@@ -65,10 +83,12 @@ type RuntimeStates interface {
 
 type UpgradeKyma interface {
 	InsertUpgradeKymaOperation(operation internal.UpgradeKymaOperation) error
+	InsertUpgradeKymaOperations(operations []internal.UpgradeKymaOperation) error
 	UpdateUpgradeKymaOperation(operation internal.UpgradeKymaOperation) (*internal.UpgradeKymaOperation, error)
+	UpsertUpgradeKymaOperation(operation internal.UpgradeKymaOperation) (*internal.UpgradeKymaOperation, error)
 	GetUpgradeKymaOperationByID(operationID string) (*internal.UpgradeKymaOperation, error)
 	GetUpgradeKymaOperationByInstanceID(instanceID string) (*internal.UpgradeKymaOperation, error)
-	ListUpgradeKymaOperations() ([]internal.UpgradeKymaOperation, error)
+	ListUpgradeKymaOperations(pageSize, page int) ([]internal.UpgradeKymaOperation, int, error)
 	ListUpgradeKymaOperationsByInstanceID(instanceID string) ([]internal.UpgradeKymaOperation, error)
 	ListUpgradeKymaOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]internal.UpgradeKymaOperation, int, int, error)
 }
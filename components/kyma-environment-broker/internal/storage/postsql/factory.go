@@ -1,6 +1,9 @@
 package postsql
 
 import (
+	"context"
+	"time"
+
 	dbr "github.com/gocraft/dbr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
@@ -23,11 +26,16 @@ type ReadSession interface {
 	GetLastOperation(instanceID string) (dbmodel.OperationDTO, dberr.Error)
 	GetOperationByID(opID string) (dbmodel.OperationDTO, dberr.Error)
 	GetNotFinishedOperationsByType(operationType dbmodel.OperationType) ([]dbmodel.OperationDTO, dberr.Error)
+	ListNotFinishedOperationsByPriority() ([]dbmodel.OperationDTO, dberr.Error)
 	GetOperationByTypeAndInstanceID(inID string, opType dbmodel.OperationType) (dbmodel.OperationDTO, dberr.Error)
 	GetOperationsByTypeAndInstanceID(inID string, opType dbmodel.OperationType) ([]dbmodel.OperationDTO, dberr.Error)
+	GetProvisioningOperationByFingerprint(instanceID, fingerprint string, since time.Time) (dbmodel.OperationDTO, dberr.Error)
 	GetOperationsForIDs(opIdList []string) ([]dbmodel.OperationDTO, dberr.Error)
 	ListOperations(filter dbmodel.OperationFilter) ([]dbmodel.OperationDTO, int, int, error)
+	ForEachOperation(ctx context.Context, filter dbmodel.OperationFilter, fn func(dbmodel.OperationDTO) error) error
 	ListOperationsByType(operationType dbmodel.OperationType) ([]dbmodel.OperationDTO, dberr.Error)
+	ListOperationsByTypeWithPagination(operationType dbmodel.OperationType, pageSize, page int) ([]dbmodel.OperationDTO, int, error)
+	ListOperationsInTimeRange(from, to time.Time) ([]dbmodel.OperationDTO, dberr.Error)
 	GetLMSTenant(name, region string) (dbmodel.LMSTenantDTO, dberr.Error)
 	GetOperationStats() ([]dbmodel.OperationStatEntry, error)
 	GetInstanceStats() ([]dbmodel.InstanceByGlobalAccountIDStatEntry, error)
@@ -39,6 +47,8 @@ type ReadSession interface {
 	ListInstances(filter dbmodel.InstanceFilter) ([]dbmodel.InstanceDTO, int, int, error)
 	ListOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]dbmodel.OperationDTO, int, int, error)
 	GetOperationStatsForOrchestration(orchestrationID string) ([]dbmodel.OperationStatEntry, error)
+	GetFailureGroups(limit, examples int) ([]dbmodel.FailureGroupEntry, error)
+	GetSLAStats() ([]dbmodel.SLAStatsEntry, error)
 }
 
 //go:generate mockery -name=WriteSession
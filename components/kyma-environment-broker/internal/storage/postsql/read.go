@@ -1,10 +1,13 @@
 package postsql
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/pkg/errors"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -159,6 +162,39 @@ func (r readSession) ListOperations(filter dbmodel.OperationFilter) ([]dbmodel.O
 		nil
 }
 
+func (r readSession) ForEachOperation(ctx context.Context, filter dbmodel.OperationFilter, fn func(dbmodel.OperationDTO) error) error {
+	stmt := r.session.Select("id", "instance_id", "target_operation_id", "version", "state", "description",
+		"type", "data", "created_at", "updated_at", "orchestration_id", "provisioning_parameters").
+		From(OperationTableName).
+		OrderBy(CreatedAtField)
+
+	// Apply filtering if provided
+	addOperationFilters(stmt, filter)
+
+	rows, err := stmt.RowsContext(ctx)
+	if err != nil {
+		return dberr.Internal("Failed to fetch operations: %s", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dto dbmodel.OperationDTO
+		err := rows.Scan(&dto.ID, &dto.InstanceID, &dto.TargetOperationID, &dto.Version, &dto.State, &dto.Description,
+			&dto.Type, &dto.Data, &dto.CreatedAt, &dto.UpdatedAt, &dto.OrchestrationID, &dto.ProvisioningParameters)
+		if err != nil {
+			return dberr.Internal("Failed to scan operation: %s", err)
+		}
+		if err := fn(dto); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return dberr.Internal("Failed to iterate over operations: %s", err)
+	}
+
+	return nil
+}
+
 func (r readSession) GetOrchestrationByID(oID string) (dbmodel.OrchestrationDTO, dberr.Error) {
 	condition := dbr.Eq("orchestration_id", oID)
 	operation, err := r.getOrchestration(condition)
@@ -220,6 +256,27 @@ func (r readSession) GetNotFinishedOperationsByType(operationType dbmodel.Operat
 	return operations, nil
 }
 
+// ListNotFinishedOperationsByPriority returns the pending and in progress operations, the highest priority first and the oldest first within the same priority.
+// The priority is stored in the operation data and it is omitted when it is 0.
+func (r readSession) ListNotFinishedOperationsByPriority() ([]dbmodel.OperationDTO, dberr.Error) {
+	stateInProgress := dbr.Eq("state", domain.InProgress)
+	statePending := dbr.Eq("state", orchestration.Pending)
+	var operations []dbmodel.OperationDTO
+
+	_, err := r.session.
+		Select("*").
+		From(OperationTableName).
+		Where(dbr.Or(statePending, stateInProgress)).
+		OrderDesc("coalesce((data->>'priority')::int, 0)").
+		OrderAsc(CreatedAtField).
+		OrderAsc("id").
+		Load(&operations)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operations: %s", err)
+	}
+	return operations, nil
+}
+
 func (r readSession) GetOperationByTypeAndInstanceID(inID string, opType dbmodel.OperationType) (dbmodel.OperationDTO, dberr.Error) {
 	idCondition := dbr.Eq("instance_id", inID)
 	typeCondition := dbr.Eq("type", string(opType))
@@ -242,6 +299,31 @@ func (r readSession) GetOperationByTypeAndInstanceID(inID string, opType dbmodel
 	return operation, nil
 }
 
+func (r readSession) GetProvisioningOperationByFingerprint(instanceID, fingerprint string, since time.Time) (dbmodel.OperationDTO, dberr.Error) {
+	idCondition := dbr.Eq("instance_id", instanceID)
+	typeCondition := dbr.Eq("type", string(dbmodel.OperationTypeProvision))
+	recentCondition := dbr.Or(dbr.Eq("state", domain.InProgress), dbr.Gte(CreatedAtField, since))
+	var operation dbmodel.OperationDTO
+
+	err := r.session.
+		Select("*").
+		From(OperationTableName).
+		Where(idCondition).
+		Where(typeCondition).
+		Where("data->>'fingerprint' = ?", fingerprint).
+		Where(recentCondition).
+		OrderDesc(CreatedAtField).
+		LoadOne(&operation)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.OperationDTO{}, dberr.NotFound("cannot find operation: %s", err)
+		}
+		return dbmodel.OperationDTO{}, dberr.Internal("Failed to get operation: %s", err)
+	}
+	return operation, nil
+}
+
 func (r readSession) GetOperationsByTypeAndInstanceID(inID string, opType dbmodel.OperationType) ([]dbmodel.OperationDTO, dberr.Error) {
 	idCondition := dbr.Eq("instance_id", inID)
 	typeCondition := dbr.Eq("type", string(opType))
@@ -290,6 +372,59 @@ func (r readSession) ListOperationsByType(operationType dbmodel.OperationType) (
 	return operations, nil
 }
 
+// ListOperationsByTypeWithPagination returns the given page of the operations of the given type, the newest first,
+// together with the total number of the operations of that type
+func (r readSession) ListOperationsByTypeWithPagination(operationType dbmodel.OperationType, pageSize, page int) ([]dbmodel.OperationDTO, int, error) {
+	var operations []dbmodel.OperationDTO
+	typeCondition := dbr.Eq("type", operationType)
+
+	_, err := r.session.
+		Select("*").
+		From(OperationTableName).
+		Where(typeCondition).
+		OrderDesc(CreatedAtField).
+		Paginate(uint64(page), uint64(pageSize)).
+		Load(&operations)
+	if err != nil {
+		return nil, -1, dberr.Internal("Failed to get operations: %s", err)
+	}
+
+	var res struct {
+		Total int
+	}
+	err = r.session.Select("count(*) as total").
+		From(OperationTableName).
+		Where(typeCondition).
+		LoadOne(&res)
+	if err != nil {
+		return nil, -1, dberr.Internal("Failed to count operations: %s", err)
+	}
+
+	return operations, res.Total, nil
+}
+
+// ListOperationsInTimeRange returns the operations created within the given time range including both bounds, a zero bound is ignored
+func (r readSession) ListOperationsInTimeRange(from, to time.Time) ([]dbmodel.OperationDTO, dberr.Error) {
+	var operations []dbmodel.OperationDTO
+
+	stmt := r.session.
+		Select("*").
+		From(OperationTableName).
+		OrderBy(CreatedAtField)
+	if !from.IsZero() {
+		stmt.Where(dbr.Gte(CreatedAtField, from))
+	}
+	if !to.IsZero() {
+		stmt.Where(dbr.Lte(CreatedAtField, to))
+	}
+
+	_, err := stmt.Load(&operations)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operations: %s", err)
+	}
+	return operations, nil
+}
+
 func (r readSession) ListOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]dbmodel.OperationDTO, int, int, error) {
 	var ops []dbmodel.OperationDTO
 	condition := dbr.Eq("orchestration_id", orchestrationID)
@@ -448,6 +583,44 @@ func (r readSession) GetOperationStatsForOrchestration(orchestrationID string) (
 	return rows, err
 }
 
+// GetFailureGroups groups the failed operations by the description with the internal.FailurePatterns replaced
+func (r readSession) GetFailureGroups(limit, examples int) ([]dbmodel.FailureGroupEntry, error) {
+	message := "description"
+	var args []interface{}
+	for _, pattern := range internal.FailurePatterns {
+		message = fmt.Sprintf("regexp_replace(%s, ?, ?, 'g')", message)
+		args = append(args, pattern.Expression, pattern.Replacement)
+	}
+	query := fmt.Sprintf(`select %s as message, count(*) as total,
+coalesce(array_to_string((array_agg(distinct data->>'runtime_id') filter (where data->>'runtime_id' <> ''))[1:%d], ','), '') as examples
+from %s where state = ? group by message order by total desc, message`,
+		message, examples, OperationTableName)
+	args = append(args, string(domain.Failed))
+	if limit > 0 {
+		query = fmt.Sprintf("%s limit %d", query, limit)
+	}
+
+	var rows []dbmodel.FailureGroupEntry
+	_, err := r.session.SelectBySql(query, args...).Load(&rows)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get failure groups: %s", err)
+	}
+	return rows, nil
+}
+
+// GetSLAStats counts the completed operations and the ones marked with a breached SLA per operation type
+func (r readSession) GetSLAStats() ([]dbmodel.SLAStatsEntry, error) {
+	query := fmt.Sprintf(`select type, count(*) as total, count(*) filter (where data->>'sla_breached' = 'true') as breached
+from %s where state in (?, ?) group by type order by type`, OperationTableName)
+
+	var rows []dbmodel.SLAStatsEntry
+	_, err := r.session.SelectBySql(query, string(domain.Succeeded), string(domain.Failed)).Load(&rows)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get SLA stats: %s", err)
+	}
+	return rows, nil
+}
+
 func (r readSession) GetInstanceStats() ([]dbmodel.InstanceByGlobalAccountIDStatEntry, error) {
 	var rows []dbmodel.InstanceByGlobalAccountIDStatEntry
 	_, err := r.session.SelectBySql(fmt.Sprintf("select global_account_id, count(*) as total from %s group by global_account_id",
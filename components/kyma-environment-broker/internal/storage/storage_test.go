@@ -741,6 +741,220 @@ func TestPostgres(t *testing.T) {
 			assert.Equal(t, count, 3)
 			assert.Equal(t, totalCount, 3)
 		})
+		t.Run("ForEach", func(t *testing.T) {
+			containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+			require.NoError(t, err)
+			defer containerCleanupFunc()
+
+			err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+			require.NoError(t, err)
+
+			brokerStorage, _, err := storage.NewFromConfig(cfg, logrus.StandardLogger())
+			require.NoError(t, err)
+
+			svc := brokerStorage.Operations()
+
+			givenOperation1 := fixProvisionOperation("inst-1")
+			givenOperation1.CreatedAt = fixTime()
+			givenOperation2 := fixDeprovisionOperation("inst-2")
+			givenOperation2.CreatedAt = fixTime().Add(time.Hour)
+			givenOperation2.State = domain.Failed
+			givenOperation3 := fixProvisionOperation("inst-3")
+			givenOperation3.CreatedAt = fixTime().Add(2 * time.Hour)
+
+			err = svc.InsertProvisioningOperation(givenOperation1)
+			require.NoError(t, err)
+			err = svc.InsertDeprovisioningOperation(givenOperation2)
+			require.NoError(t, err)
+			err = svc.InsertProvisioningOperation(givenOperation3)
+			require.NoError(t, err)
+
+			// when
+			var ids []string
+			err = svc.ForEach(ctx, dbmodel.OperationFilter{}, func(op internal.Operation) error {
+				ids = append(ids, op.ID)
+				return nil
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, []string{givenOperation1.ID, givenOperation2.ID, givenOperation3.ID}, ids)
+
+			// when
+			ids = nil
+			err = svc.ForEach(ctx, dbmodel.OperationFilter{States: []string{string(domain.Succeeded)}}, func(op internal.Operation) error {
+				ids = append(ids, op.ID)
+				return nil
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, []string{givenOperation1.ID, givenOperation3.ID}, ids)
+
+			// when
+			givenErr := fmt.Errorf("callback error")
+			called := 0
+			err = svc.ForEach(ctx, dbmodel.OperationFilter{}, func(op internal.Operation) error {
+				called++
+				return givenErr
+			})
+
+			// then
+			assert.Equal(t, givenErr, err)
+			assert.Equal(t, 1, called)
+		})
+		t.Run("Failure groups", func(t *testing.T) {
+			containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+			require.NoError(t, err)
+			defer containerCleanupFunc()
+
+			err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+			require.NoError(t, err)
+
+			brokerStorage, _, err := storage.NewFromConfig(cfg, logrus.StandardLogger())
+			require.NoError(t, err)
+
+			svc := brokerStorage.Operations()
+
+			for i, description := range []string{
+				"provisioner operation 9d75a545-2e1e-4786-abd8-a37b14e185b9 failed",
+				"provisioner operation ef4e3210-652c-453e-8015-bba1c1cd1e1c failed",
+				"call to provisioner failed after 3 retries",
+			} {
+				givenOperation := fixProvisionOperation(fmt.Sprintf("inst-%d", i))
+				givenOperation.State = domain.Failed
+				givenOperation.Description = description
+				givenOperation.RuntimeID = fmt.Sprintf("runtime-%d", i)
+				err = svc.InsertProvisioningOperation(givenOperation)
+				require.NoError(t, err)
+			}
+			givenOperation := fixDeprovisionOperation("inst-succeeded")
+			givenOperation.Description = "provisioner operation 3a7d2b6b-4aa4-4c2e-9fd6-9e56cd6ffc0a failed"
+			err = svc.InsertDeprovisioningOperation(givenOperation)
+			require.NoError(t, err)
+
+			// when
+			groups, err := svc.GetFailureGroups(10)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, []internal.FailureGroup{
+				{Message: "provisioner operation <id> failed", Count: 2, RuntimeIDs: []string{"runtime-0", "runtime-1"}},
+				{Message: "call to provisioner failed after <n> retries", Count: 1, RuntimeIDs: []string{"runtime-2"}},
+			}, groups)
+
+			// when
+			groups, err = svc.GetFailureGroups(1)
+
+			// then
+			require.NoError(t, err)
+			assert.Len(t, groups, 1)
+		})
+
+		t.Run("Pending operations by priority", func(t *testing.T) {
+			containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+			require.NoError(t, err)
+			defer containerCleanupFunc()
+
+			err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+			require.NoError(t, err)
+
+			brokerStorage, _, err := storage.NewFromConfig(cfg, logrus.StandardLogger())
+			require.NoError(t, err)
+
+			svc := brokerStorage.Operations()
+
+			for i, tc := range []struct {
+				state    domain.LastOperationState
+				priority int
+			}{
+				{state: domain.InProgress, priority: 0},
+				{state: orchestration.Pending, priority: 10},
+				{state: domain.InProgress, priority: 10},
+				{state: domain.Succeeded, priority: 20},
+				{state: domain.InProgress, priority: 5},
+			} {
+				givenOperation := fixProvisionOperation(fmt.Sprintf("inst-%d", i))
+				givenOperation.ID = fmt.Sprintf("op-%d", i)
+				givenOperation.CreatedAt = fixTime().Add(time.Duration(i) * time.Hour)
+				givenOperation.State = tc.state
+				givenOperation.Priority = tc.priority
+				err = svc.InsertProvisioningOperation(givenOperation)
+				require.NoError(t, err)
+			}
+
+			// when
+			operations, err := svc.ListPendingOperationsByPriority()
+
+			// then
+			require.NoError(t, err)
+			ids := make([]string, 0, len(operations))
+			for _, op := range operations {
+				ids = append(ids, op.ID)
+			}
+			assert.Equal(t, []string{"op-1", "op-2", "op-4", "op-0"}, ids)
+		})
+	})
+	t.Run("SLA stats", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+
+		brokerStorage, _, err := storage.NewFromConfig(cfg, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		svc := brokerStorage.Operations()
+
+		for i, breached := range []bool{false, true, true} {
+			givenOperation := fixProvisionOperation(fmt.Sprintf("inst-%d", i))
+			givenOperation.State = domain.Succeeded
+			givenOperation.SLABreached = breached
+			err = svc.InsertProvisioningOperation(givenOperation)
+			require.NoError(t, err)
+		}
+		givenOperation := fixProvisionOperation("inst-in-progress")
+		givenOperation.State = domain.InProgress
+		err = svc.InsertProvisioningOperation(givenOperation)
+		require.NoError(t, err)
+		givenDeprovisioning := fixDeprovisionOperation("inst-deprovisioned")
+		givenDeprovisioning.State = domain.Failed
+		err = svc.InsertDeprovisioningOperation(givenDeprovisioning)
+		require.NoError(t, err)
+
+		// when
+		stats, err := svc.GetSLAStats()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []internal.SLAStats{
+			{Type: string(dbmodel.OperationTypeDeprovision), Total: 1, Breached: 0},
+			{Type: string(dbmodel.OperationTypeProvision), Total: 3, Breached: 2},
+		}, stats)
+
+		t.Run("Should keep the SLA breach flag on update", func(t *testing.T) {
+			givenOperation := fixProvisionOperation("inst-breached")
+			givenOperation.SLABreached = true
+			err = svc.InsertProvisioningOperation(givenOperation)
+			require.NoError(t, err)
+
+			// when
+			gotOperation, err := svc.GetProvisioningOperationByID(givenOperation.ID)
+			require.NoError(t, err)
+			assert.True(t, gotOperation.SLABreached)
+
+			gotOperation.Description = "updated"
+			_, err = svc.UpdateProvisioningOperation(*gotOperation)
+			require.NoError(t, err)
+
+			// then
+			gotOperation, err = svc.GetProvisioningOperationByID(givenOperation.ID)
+			require.NoError(t, err)
+			assert.True(t, gotOperation.SLABreached)
+			assert.Equal(t, "updated", gotOperation.Description)
+		})
 	})
 	t.Run("Operations conflicts", func(t *testing.T) {
 		t.Run("Provisioning", func(t *testing.T) {
@@ -0,0 +1,14 @@
+package webhook
+
+import "time"
+
+type Config struct {
+	Disabled bool   `envconfig:"default=true"`
+	URL      string `envconfig:"optional"`
+
+	// MaxRetries is the number of additional attempts made when the webhook call fails
+	MaxRetries int `envconfig:"default=3"`
+	// RetryInterval is the wait time before the first retry, doubled with every next retry
+	RetryInterval time.Duration `envconfig:"default=5s"`
+	Timeout       time.Duration `envconfig:"default=30s"`
+}
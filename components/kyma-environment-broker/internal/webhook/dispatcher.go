@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Payload is the body sent to the webhook URL when an operation reaches a terminal state
+type Payload struct {
+	OperationID string                    `json:"operationID"`
+	Type        dbmodel.OperationType     `json:"type"`
+	State       domain.LastOperationState `json:"state"`
+	Description string                    `json:"description"`
+	InstanceID  string                    `json:"instanceID"`
+	RuntimeID   string                    `json:"runtimeID"`
+	Timestamp   time.Time                 `json:"timestamp"`
+}
+
+// Dispatcher notifies the configured URL about operations which reached a terminal state.
+// It is driven by the step processed events, which are handled asynchronously by the event broker,
+// so the webhook calls and their retries do not block the operation processing.
+type Dispatcher struct {
+	config     Config
+	httpClient *http.Client
+	log        logrus.FieldLogger
+}
+
+func NewDispatcher(config Config, httpClient *http.Client, log logrus.FieldLogger) *Dispatcher {
+	return &Dispatcher{
+		config:     config,
+		httpClient: httpClient,
+		log:        log,
+	}
+}
+
+// Register subscribes the dispatcher to the step processed events of all operation managers
+func (d *Dispatcher) Register(sub event.Subscriber) {
+	sub.Subscribe(process.ProvisioningStepProcessed{}, d.OnProvisioningStepProcessed)
+	sub.Subscribe(process.DeprovisioningStepProcessed{}, d.OnDeprovisioningStepProcessed)
+	sub.Subscribe(process.UpgradeKymaStepProcessed{}, d.OnUpgradeKymaStepProcessed)
+}
+
+func (d *Dispatcher) OnProvisioningStepProcessed(ctx context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.ProvisioningStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected process.ProvisioningStepProcessed but got %+v", ev)
+	}
+
+	return d.dispatch(ctx, stepProcessed.OldOperation.Operation, stepProcessed.Operation.Operation, dbmodel.OperationTypeProvision)
+}
+
+func (d *Dispatcher) OnDeprovisioningStepProcessed(ctx context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.DeprovisioningStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected process.DeprovisioningStepProcessed but got %+v", ev)
+	}
+
+	return d.dispatch(ctx, stepProcessed.OldOperation.Operation, stepProcessed.Operation.Operation, dbmodel.OperationTypeDeprovision)
+}
+
+func (d *Dispatcher) OnUpgradeKymaStepProcessed(ctx context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.UpgradeKymaStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected process.UpgradeKymaStepProcessed but got %+v", ev)
+	}
+
+	return d.dispatch(ctx, stepProcessed.OldOperation.Operation, stepProcessed.Operation.Operation, dbmodel.OperationTypeUpgradeKyma)
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, oldOperation, operation internal.Operation, opType dbmodel.OperationType) error {
+	if d.config.Disabled || oldOperation.State == operation.State || !isTerminal(operation.State) {
+		return nil
+	}
+
+	payload := Payload{
+		OperationID: operation.ID,
+		Type:        opType,
+		State:       operation.State,
+		Description: operation.Description,
+		InstanceID:  operation.InstanceID,
+		RuntimeID:   operation.RuntimeID,
+		Timestamp:   operation.UpdatedAt,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "while marshaling webhook payload")
+	}
+
+	log := d.log.WithFields(logrus.Fields{"operation": operation.ID, "instanceID": operation.InstanceID})
+	interval := d.config.RetryInterval
+	for attempt := 0; ; attempt++ {
+		err = d.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= d.config.MaxRetries {
+			return errors.Wrapf(err, "while notifying webhook about operation %s after %d attempts", operation.ID, attempt+1)
+		}
+		log.Warnf("webhook call failed, retrying in %s: %s", interval, err)
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "while notifying webhook about operation %s", operation.ID)
+		case <-time.After(interval):
+		}
+		interval = 2 * interval
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "while creating webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "while sending webhook request")
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func isTerminal(state domain.LastOperationState) bool {
+	switch state {
+	case domain.Succeeded, domain.Failed, orchestration.Canceled:
+		return true
+	}
+	return false
+}
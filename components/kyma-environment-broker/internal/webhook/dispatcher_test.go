@@ -0,0 +1,203 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/webhook"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_OnProvisioningStepProcessed(t *testing.T) {
+	t.Run("should send payload when operation succeeded", func(t *testing.T) {
+		// given
+		srv := newFakeServer(t, 0)
+		defer srv.Close()
+		dispatcher := webhook.NewDispatcher(fixConfig(srv.URL), http.DefaultClient, logrus.New())
+
+		// when
+		err := dispatcher.OnProvisioningStepProcessed(context.Background(), fixProvisioningStepProcessed(domain.InProgress, domain.Succeeded))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, srv.payloads(), 1)
+		assert.Equal(t, webhook.Payload{
+			OperationID: "op-id",
+			Type:        dbmodel.OperationTypeProvision,
+			State:       domain.Succeeded,
+			Description: "description",
+			InstanceID:  "instance-id",
+			RuntimeID:   "runtime-id",
+			Timestamp:   fixTime(),
+		}, srv.payloads()[0])
+	})
+
+	t.Run("should not send payload when operation is in progress", func(t *testing.T) {
+		// given
+		srv := newFakeServer(t, 0)
+		defer srv.Close()
+		dispatcher := webhook.NewDispatcher(fixConfig(srv.URL), http.DefaultClient, logrus.New())
+
+		// when
+		err := dispatcher.OnProvisioningStepProcessed(context.Background(), fixProvisioningStepProcessed(domain.InProgress, domain.InProgress))
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, srv.payloads())
+	})
+
+	t.Run("should not send payload again when state did not change", func(t *testing.T) {
+		// given
+		srv := newFakeServer(t, 0)
+		defer srv.Close()
+		dispatcher := webhook.NewDispatcher(fixConfig(srv.URL), http.DefaultClient, logrus.New())
+
+		// when
+		err := dispatcher.OnProvisioningStepProcessed(context.Background(), fixProvisioningStepProcessed(domain.Failed, domain.Failed))
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, srv.payloads())
+	})
+
+	t.Run("should retry failed calls", func(t *testing.T) {
+		// given
+		srv := newFakeServer(t, 2)
+		defer srv.Close()
+		dispatcher := webhook.NewDispatcher(fixConfig(srv.URL), http.DefaultClient, logrus.New())
+
+		// when
+		err := dispatcher.OnProvisioningStepProcessed(context.Background(), fixProvisioningStepProcessed(domain.InProgress, domain.Failed))
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, srv.payloads(), 1)
+		assert.Equal(t, 3, srv.calls())
+	})
+
+	t.Run("should give up after max retries", func(t *testing.T) {
+		// given
+		srv := newFakeServer(t, 10)
+		defer srv.Close()
+		dispatcher := webhook.NewDispatcher(fixConfig(srv.URL), http.DefaultClient, logrus.New())
+
+		// when
+		err := dispatcher.OnProvisioningStepProcessed(context.Background(), fixProvisioningStepProcessed(domain.InProgress, domain.Failed))
+
+		// then
+		assert.Error(t, err)
+		assert.Empty(t, srv.payloads())
+		assert.Equal(t, 4, srv.calls())
+	})
+}
+
+func TestDispatcher_OnUpgradeKymaStepProcessed(t *testing.T) {
+	// given
+	srv := newFakeServer(t, 0)
+	defer srv.Close()
+	dispatcher := webhook.NewDispatcher(fixConfig(srv.URL), http.DefaultClient, logrus.New())
+
+	// when
+	err := dispatcher.OnUpgradeKymaStepProcessed(context.Background(), process.UpgradeKymaStepProcessed{
+		OldOperation: internal.UpgradeKymaOperation{Operation: fixOperation(domain.InProgress)},
+		Operation:    internal.UpgradeKymaOperation{Operation: fixOperation(domain.Failed)},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, srv.payloads(), 1)
+	assert.Equal(t, dbmodel.OperationTypeUpgradeKyma, srv.payloads()[0].Type)
+	assert.Equal(t, domain.Failed, srv.payloads()[0].State)
+}
+
+type fakeServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	failures int
+	called   int
+	received []webhook.Payload
+}
+
+// newFakeServer returns a server which responds with an error to the given number of first calls
+func newFakeServer(t *testing.T, failures int) *fakeServer {
+	srv := &fakeServer{failures: failures}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		srv.called++
+		if srv.called <= srv.failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var payload webhook.Payload
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.NoError(t, err)
+		srv.received = append(srv.received, payload)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return srv
+}
+
+func (s *fakeServer) payloads() []webhook.Payload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.received
+}
+
+func (s *fakeServer) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.called
+}
+
+func fixConfig(url string) webhook.Config {
+	return webhook.Config{
+		URL:           url,
+		MaxRetries:    3,
+		RetryInterval: time.Millisecond,
+		Timeout:       time.Second,
+	}
+}
+
+func fixProvisioningStepProcessed(oldState, state domain.LastOperationState) process.ProvisioningStepProcessed {
+	return process.ProvisioningStepProcessed{
+		OldOperation: internal.ProvisioningOperation{Operation: fixOperation(oldState)},
+		Operation:    internal.ProvisioningOperation{Operation: fixOperation(state)},
+	}
+}
+
+func fixOperation(state domain.LastOperationState) internal.Operation {
+	return internal.Operation{
+		ID:          "op-id",
+		InstanceID:  "instance-id",
+		State:       state,
+		Description: "description",
+		UpdatedAt:   fixTime(),
+		InstanceDetails: internal.InstanceDetails{
+			RuntimeID: "runtime-id",
+		},
+	}
+}
+
+func fixTime() time.Time {
+	return time.Date(2020, 11, 10, 9, 8, 7, 0, time.UTC)
+}
@@ -1,31 +1,41 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/kyma-project/control-plane/tools/cli/pkg/command"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
 )
 
 func main() {
-	setupCloseHandler()
+	ctx, cancel := context.WithCancel(context.Background())
+	setupCloseHandler(cancel)
 	cmd := command.New()
 
-	err := cmd.Execute()
+	err := cmd.ExecuteContext(ctx)
 	if err != nil {
-		os.Exit(1)
+		logger.ReportError(err)
+		if ctx.Err() == nil {
+			os.Exit(1)
+		}
 	}
 
 }
 
-func setupCloseHandler() {
+// setupCloseHandler cancels the context of the executed command on the first signal, so the long-running commands,
+// such as kcp runtimes --watch, can exit cleanly. The second signal exits immediately.
+func setupCloseHandler(cancel context.CancelFunc) {
 	c := make(chan os.Signal, 2)
 	signal.Notify(c, syscall.SIGTERM, syscall.SIGINT)
 	go func() {
 		sig := <-c
 		fmt.Printf("\r- Signal '%v' received from Terminal. Exiting...\n ", sig)
+		cancel()
+		<-c
 		os.Exit(0)
 	}()
 }
@@ -3,8 +3,9 @@ module github.com/kyma-project/control-plane/tools/cli
 go 1.14
 
 require (
+	github.com/Masterminds/semver v1.5.0
 	github.com/int128/kubelogin v1.22.0
-	github.com/kyma-project/control-plane v0.0.0-20210126103810-5ca840059a32
+	github.com/kyma-project/control-plane v0.0.0-20261014101920-5b9250de469f
 	github.com/kyma-project/control-plane/components/kubeconfig-service v0.0.0-20201211152036-9bdabffd55fb
 	github.com/kyma-project/control-plane/components/provisioner v0.0.0-20201211152036-9bdabffd55fb // indirect
 	github.com/liggitt/tabwriter v0.0.0-20181228230101-89fcab3d43de
@@ -14,9 +15,12 @@ require (
 	github.com/spf13/cobra v1.1.1
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.7.1
+	github.com/stretchr/testify v1.4.0
 	golang.org/x/oauth2 v0.0.0-20201109201403-9fd604954f58
+	golang.org/x/time v0.0.0-20191024005414-555d28b269f0
 	gopkg.in/yaml.v2 v2.3.0
 	k8s.io/client-go v11.0.1-0.20190409021438-1a26190bd76a+incompatible
+	sigs.k8s.io/yaml v1.2.0
 )
 
 replace (
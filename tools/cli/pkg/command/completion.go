@@ -68,6 +68,11 @@ To load completions for every new session, run:
 ` + "`PS> kcp completion powershell > kcp.ps1`" + `
 
 Source this file from your Powershell profile.
+
+### Option values
+
+The values of the --plan and --region options of the kcp runtimes command are completed with the known service plans, and with the plans and regions of the Runtimes displayed by the previous runs of the command, which are stored in $HOME/.kcp/completion.json.
+The completion does not call Kyma Environment Broker, so it never waits for the login or the network.
 `,
 		Example:               `kcp completion bash                            Display completions in bash.`,
 		DisableFlagsInUseLine: true,
@@ -0,0 +1,162 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// ConfigViewCommand represents an execution of the kcp config view command
+type ConfigViewCommand struct {
+	cobraCmd *cobra.Command
+	output   string
+}
+
+const (
+	sourceFlag   = "flag"
+	sourceConfig = "config file"
+	sourceEnv    = "environment"
+
+	// hiddenValue is displayed instead of the values of the secret global options
+	hiddenValue = "<hidden>"
+)
+
+// configEntry is the effective value of a global option and where it is taken from
+type configEntry struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+var configColumns = []printer.Column{
+	{
+		Header:    "KEY",
+		FieldSpec: "{.Key}",
+	},
+	{
+		Header:    "VALUE",
+		FieldSpec: "{.Value}",
+	},
+	{
+		Header:    "SOURCE",
+		FieldSpec: "{.Source}",
+	},
+}
+
+// NewConfigCmd constructs the config command and all subcommands under the config command
+func NewConfigCmd() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Displays the configuration of the KCP CLI.",
+		Long:  "Displays the configuration of the KCP CLI.",
+		// the configuration is displayed also when the required global options are missing, so that it can be fixed
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error { return ResolveDefaultOutput(cmd) },
+	}
+
+	cobraCmd.AddCommand(NewConfigViewCmd())
+	return cobraCmd
+}
+
+// NewConfigViewCmd constructs a new instance of ConfigViewCommand and configures it in terms of a cobra.Command
+func NewConfigViewCmd() *cobra.Command {
+	cmd := ConfigViewCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "view",
+		Short: "Displays the effective configuration of the KCP CLI.",
+		Long: `Displays the effective values of the global options and where each value is taken from: flag, config file, or environment.
+The values of the options override the values from the config file, which override the values of the KCP_ environment variables.
+The value of the oidc-client-secret option is hidden. The json and yaml outputs display only the options which are set, in the format of the config file.`,
+		Example: `  kcp config view                              Display the effective configuration and the source of each value.
+  kcp config view --config ~/.kcp/dev.yaml     Display the effective configuration with the given config file.
+  kcp config view -o yaml                      Display the effective configuration in the format of the config file.`,
+		Args:    cobra.NoArgs,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+
+	return cobraCmd
+}
+
+// Run executes the config view command
+func (cmd *ConfigViewCommand) Run() error {
+	return cmd.printConfig(os.Stdout, configEntries(cmd.cobraCmd.InheritedFlags()))
+}
+
+// Validate checks the input parameters of the config view command
+func (cmd *ConfigViewCommand) Validate() error {
+	return ValidateOutputOpt(cmd.output)
+}
+
+func (cmd *ConfigViewCommand) printConfig(w io.Writer, entries []configEntry) error {
+	switch cmd.output {
+	case tableOutput:
+		configFile := viper.ConfigFileUsed()
+		if configFile == "" {
+			configFile = "none"
+		}
+		if _, err := fmt.Fprintf(w, "Config file: %s\n\n", configFile); err != nil {
+			return err
+		}
+		tp, err := printer.NewTablePrinterTo(w, configColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(entries)
+	case jsonOutput:
+		return printer.NewJSONPrinterTo(w, "  ").PrintObj(configValues(entries))
+	case yamlOutput:
+		return printer.NewYAMLPrinterTo(w).PrintObj(configValues(entries))
+	}
+
+	return nil
+}
+
+// configEntries returns the effective values of all global options, the flags are the global options of the executed command
+func configEntries(flags *pflag.FlagSet) []configEntry {
+	entries := make([]configEntry, 0, len(GlobalOpts.keys()))
+	for _, key := range GlobalOpts.keys() {
+		entry := configEntry{
+			Key:    key,
+			Value:  viper.GetString(key),
+			Source: configSource(flags, key),
+		}
+		if key == GlobalOpts.oidcClientSecret && entry.Value != "" {
+			entry.Value = hiddenValue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// configSource returns where the value of the global option is taken from, it is empty if the option is not set
+func configSource(flags *pflag.FlagSet, key string) string {
+	if flag := flags.Lookup(key); flag != nil && flag.Changed {
+		return sourceFlag
+	}
+	if viper.InConfig(key) {
+		return sourceConfig
+	}
+	if _, ok := os.LookupEnv(envName(key)); ok {
+		return sourceEnv
+	}
+	return ""
+}
+
+// configValues returns the values of the global options which are set by their keys
+func configValues(entries []configEntry) map[string]string {
+	values := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Source != "" {
+			values[entry.Key] = entry.Value
+		}
+	}
+	return values
+}
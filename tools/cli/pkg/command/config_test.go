@@ -0,0 +1,88 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestConfigEntries(t *testing.T) {
+	// given
+	defer viper.Reset()
+	require.NoError(t, os.Setenv("KCP_OIDC_CLIENT_ID", "env-client"))
+	defer os.Unsetenv("KCP_OIDC_CLIENT_ID")
+	require.NoError(t, os.Setenv("KCP_KEB_API_URL", "https://env.example.com"))
+	defer os.Unsetenv("KCP_KEB_API_URL")
+	SetEnvDefaults()
+	viper.SetConfigType("yaml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader("keb-api-url: https://profile.example.com\noidc-client-secret: secret\noutput: json")))
+
+	cmd := &cobra.Command{}
+	SetGlobalOpts(cmd)
+	require.NoError(t, cmd.ParseFlags([]string{"--oidc-issuer-url", "https://issuer.example.com"}))
+
+	// when
+	entries := configEntries(cmd.Flags())
+
+	// then
+	assert.Equal(t, []configEntry{
+		{Key: GlobalOpts.oidcIssuerURL, Value: "https://issuer.example.com", Source: sourceFlag},
+		{Key: GlobalOpts.oidcClientID, Value: "env-client", Source: sourceEnv},
+		{Key: GlobalOpts.oidcClientSecret, Value: hiddenValue, Source: sourceConfig},
+		{Key: GlobalOpts.kebAPIURL, Value: "https://profile.example.com", Source: sourceConfig},
+		{Key: GlobalOpts.kubeconfigAPIURL},
+		{Key: GlobalOpts.gardenerKubeconfig},
+		{Key: GlobalOpts.gardenerNamespace},
+		{Key: GlobalOpts.output, Value: jsonOutput, Source: sourceConfig},
+	}, entries)
+}
+
+func TestConfigViewCommand_PrintConfig(t *testing.T) {
+	entries := []configEntry{
+		{Key: GlobalOpts.oidcClientSecret, Value: hiddenValue, Source: sourceConfig},
+		{Key: GlobalOpts.kebAPIURL, Value: "https://keb.example.com", Source: sourceEnv},
+		{Key: GlobalOpts.gardenerNamespace},
+	}
+
+	t.Run("should print the options with their sources in the table output", func(t *testing.T) {
+		// given
+		defer viper.Reset()
+		cmd := ConfigViewCommand{output: tableOutput}
+		buf := &bytes.Buffer{}
+
+		// when
+		err := cmd.printConfig(buf, entries)
+
+		// then
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 6)
+		assert.Equal(t, "Config file: none", lines[0])
+		assert.Equal(t, []string{"KEY", "VALUE", "SOURCE"}, strings.Fields(lines[2]))
+		assert.Equal(t, []string{"oidc-client-secret", hiddenValue, "config", "file"}, strings.Fields(lines[3]))
+		assert.Equal(t, []string{"keb-api-url", "https://keb.example.com", "environment"}, strings.Fields(lines[4]))
+		assert.Equal(t, []string{"gardener-namespace"}, strings.Fields(lines[5]))
+	})
+
+	t.Run("should print only the options which are set in the yaml output", func(t *testing.T) {
+		// given
+		cmd := ConfigViewCommand{output: yamlOutput}
+		buf := &bytes.Buffer{}
+
+		// when
+		err := cmd.printConfig(buf, entries)
+
+		// then
+		require.NoError(t, err)
+		var out map[string]string
+		require.NoError(t, yaml.Unmarshal(buf.Bytes(), &out))
+		assert.Equal(t, map[string]string{"keb-api-url": "https://keb.example.com", "oidc-client-secret": hiddenValue}, out)
+	})
+}
@@ -0,0 +1,169 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// OperationCommand represents an execution of the kcp operation command
+type OperationCommand struct {
+	cobraCmd    *cobra.Command
+	log         logger.Logger
+	output      string
+	operationID string
+}
+
+var operationDetailsColumns = []printer.Column{
+	{
+		Header:    "OPERATION ID",
+		FieldSpec: "{.OperationID}",
+	},
+	{
+		Header:    "TYPE",
+		FieldSpec: "{.Type}",
+	},
+	{
+		Header:    "STATE",
+		FieldSpec: "{.State}",
+	},
+	{
+		Header:         "PROGRESS",
+		FieldFormatter: operationProgress,
+	},
+	{
+		Header:         "CREATED",
+		FieldFormatter: operationCreatedAt,
+	},
+	{
+		Header:         "UPDATED",
+		FieldFormatter: operationUpdatedAt,
+	},
+	{
+		Header:    "DESCRIPTION",
+		FieldSpec: "{.Description}",
+	},
+}
+
+var retryHistoryColumns = []printer.Column{
+	{
+		Header:         "RETRIED AT",
+		FieldFormatter: retryTimestamp,
+	},
+	{
+		Header:    "MESSAGE",
+		FieldSpec: "{.Message}",
+	},
+}
+
+// NewOperationCmd constructs a new instance of OperationCommand and configures it in terms of a cobra.Command
+func NewOperationCmd() *cobra.Command {
+	cmd := OperationCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "operation {OPERATION ID}",
+		Aliases: []string{"op"},
+		Short:   "Displays a Kyma Runtime operation.",
+		Long: `Displays the details of a Kyma Runtime operation, such as its type, state, timestamps, and the description, which holds the error message of the failed operation.
+The PROGRESS column displays the estimated progress of a Kyma upgrade operation in percent, which is 100 once the operation succeeded and keeps the last value if the operation failed.
+The table output of a Kyma upgrade operation is followed by its latest retries with the error messages which caused them.
+Use the ID displayed in the OPERATION ID column of the kcp runtimes command, or by the kcp runtimes operation command.`,
+		Example: `  kcp operation 8a7bfd9b-f2f5-43d1-bb67-177d2434053c          Display the given operation.
+  kcp operation 8a7bfd9b-f2f5-43d1-bb67-177d2434053c -o json  Display all details about the given operation in the JSON format.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, args []string) error { return cmd.Validate(args) },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cobraCmd.Flags().StringVarP(&cmd.output, "output", "o", tableOutput, fmt.Sprintf("Output type of displayed operation. The possible values are: %s, %s.", tableOutput, jsonOutput))
+
+	return cobraCmd
+}
+
+// Run executes the operation command
+func (cmd *OperationCommand) Run() error {
+	cmd.log = logger.New()
+	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLITokenSource(cmd.log))
+
+	return cmd.printOperation(client, os.Stdout)
+}
+
+// Validate checks the input parameters of the operation command
+func (cmd *OperationCommand) Validate(args []string) error {
+	if len(args) != 1 || args[0] == "" {
+		return errors.New("operation ID must be specified")
+	}
+	cmd.operationID = args[0]
+
+	switch cmd.output {
+	case tableOutput, jsonOutput:
+		return nil
+	}
+	return fmt.Errorf("invalid value for output: %s", cmd.output)
+}
+
+func (cmd *OperationCommand) printOperation(client runtime.Client, w io.Writer) error {
+	operation, err := client.GetOperation(cmd.operationID)
+	if err != nil {
+		return errors.Wrapf(err, "while fetching operation %s", cmd.operationID)
+	}
+
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinterTo(w, operationDetailsColumns, false)
+		if err != nil {
+			return err
+		}
+		if err := tp.PrintObj(operation); err != nil {
+			return err
+		}
+		return printRetryHistory(w, operation.RetryHistory)
+	case jsonOutput:
+		return printer.NewJSONPrinterTo(w, "  ").PrintObj(operation)
+	}
+
+	return nil
+}
+
+// printRetryHistory displays the retries of the operation in a separate table, nothing is displayed if the operation was not retried
+func printRetryHistory(w io.Writer, history []runtime.RetryEntry) error {
+	if len(history) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprint(w, "\n"); err != nil {
+		return err
+	}
+	tp, err := printer.NewTablePrinterTo(w, retryHistoryColumns, false)
+	if err != nil {
+		return err
+	}
+	return tp.PrintObj(history)
+}
+
+func retryTimestamp(obj interface{}) string {
+	entry := obj.(runtime.RetryEntry)
+	return entry.Timestamp.Format("2006/01/02 15:04:05")
+}
+
+// operationProgress returns the progress of the Kyma upgrade operation, it is empty for the other operation types
+func operationProgress(obj interface{}) string {
+	op := obj.(runtime.Operation)
+	if op.Type != runtime.OperationTypeUpgradeKyma {
+		return ""
+	}
+	return progressPercent(op.State, op.ProgressPercent)
+}
+
+// progressPercent returns the progress in percent, a succeeded operation is always displayed as completed
+func progressPercent(state string, percent int) string {
+	if state == succeeded {
+		percent = 100
+	}
+	return fmt.Sprintf("%d%%", percent)
+}
@@ -0,0 +1,167 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime/runtimefake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationCommand_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args    []string
+		output  string
+		wantErr bool
+	}{
+		"table output": {
+			args:   []string{"op-1"},
+			output: tableOutput,
+		},
+		"json output": {
+			args:   []string{"op-1"},
+			output: jsonOutput,
+		},
+		"csv output": {
+			args:    []string{"op-1"},
+			output:  csvOutput,
+			wantErr: true,
+		},
+		"empty operation ID": {
+			args:    []string{""},
+			output:  tableOutput,
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := OperationCommand{output: tc.output}
+
+			// when
+			err := cmd.Validate(tc.args)
+
+			// then
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.args[0], cmd.operationID)
+			}
+		})
+	}
+}
+
+func TestOperationCommand_PrintOperation(t *testing.T) {
+	created := time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)
+	client := runtimefake.NewClient()
+	client.OperationsByID["op-1"] = runtime.Operation{
+		OperationID:     "op-1",
+		Type:            runtime.OperationTypeUpgradeKyma,
+		State:           failed,
+		Description:     "upgrade failed",
+		CreatedAt:       created,
+		UpdatedAt:       created.Add(90 * time.Second),
+		ProgressPercent: 50,
+	}
+
+	t.Run("should print operation in the table output", func(t *testing.T) {
+		// given
+		cmd := OperationCommand{output: tableOutput, operationID: "op-1"}
+		buf := &bytes.Buffer{}
+
+		// when
+		err := cmd.printOperation(client, buf)
+
+		// then
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{"OPERATION", "ID", "TYPE", "STATE", "PROGRESS", "CREATED", "UPDATED", "DESCRIPTION"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"op-1", "upgradeKyma", "failed", "50%", "2021/01/13", "10:00:00", "2021/01/13", "10:01:30", "upgrade", "failed"}, strings.Fields(lines[1]))
+	})
+
+	t.Run("should print progress only of Kyma upgrade operations", func(t *testing.T) {
+		// given
+		client := runtimefake.NewClient()
+		client.OperationsByID["op-3"] = runtime.Operation{
+			OperationID: "op-3",
+			Type:        runtime.OperationTypeProvision,
+			State:       succeeded,
+			CreatedAt:   created,
+			UpdatedAt:   created,
+		}
+		cmd := OperationCommand{output: tableOutput, operationID: "op-3"}
+		buf := &bytes.Buffer{}
+
+		// when
+		err := cmd.printOperation(client, buf)
+
+		// then
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{"op-3", "provision", "succeeded", "2021/01/13", "10:00:00", "2021/01/13", "10:00:00"}, strings.Fields(lines[1]))
+	})
+
+	t.Run("should print retry history of operation in the table output", func(t *testing.T) {
+		// given
+		client := runtimefake.NewClient()
+		client.OperationsByID["op-2"] = runtime.Operation{
+			OperationID: "op-2",
+			Type:        runtime.OperationTypeUpgradeKyma,
+			State:       inProgress,
+			CreatedAt:   created,
+			RetryHistory: []runtime.RetryEntry{
+				{Timestamp: created.Add(time.Minute), Message: "provisioner unavailable"},
+				{Timestamp: created.Add(2 * time.Minute), Message: "timeout"},
+			},
+		}
+		cmd := OperationCommand{output: tableOutput, operationID: "op-2"}
+		buf := &bytes.Buffer{}
+
+		// when
+		err := cmd.printOperation(client, buf)
+
+		// then
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 6)
+		assert.Equal(t, "", strings.TrimSpace(lines[2]))
+		assert.Equal(t, []string{"RETRIED", "AT", "MESSAGE"}, strings.Fields(lines[3]))
+		assert.Equal(t, []string{"2021/01/13", "10:01:00", "provisioner", "unavailable"}, strings.Fields(lines[4]))
+		assert.Equal(t, []string{"2021/01/13", "10:02:00", "timeout"}, strings.Fields(lines[5]))
+	})
+
+	t.Run("should print operation in the json output", func(t *testing.T) {
+		// given
+		cmd := OperationCommand{output: jsonOutput, operationID: "op-1"}
+		buf := &bytes.Buffer{}
+
+		// when
+		err := cmd.printOperation(client, buf)
+
+		// then
+		require.NoError(t, err)
+		var out runtime.Operation
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		assert.Equal(t, client.OperationsByID["op-1"], out)
+	})
+
+	t.Run("should return error when operation cannot be fetched", func(t *testing.T) {
+		// given
+		cmd := OperationCommand{output: tableOutput, operationID: "op-1"}
+		failing := runtimefake.NewClient().WithError(runtimefake.GetOperation, errors.New("service unavailable"))
+
+		// when
+		err := cmd.printOperation(failing, &bytes.Buffer{})
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -3,23 +3,32 @@ package command
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/timewindow"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var configPath string
 
+// timeParser parses the values of all time options, so that they share the same syntax and clock
+var timeParser = timewindow.NewParser(nil)
+
 const (
 	configEnv string = "KCPCONFIG"
 	configDir string = ".kcp"
+	envPrefix string = "KCP_"
 )
 
 const (
-	tableOutput string = "table"
-	jsonOutput  string = "json"
+	tableOutput     string = "table"
+	jsonOutput      string = "json"
+	jsonLinesOutput string = "jsonl"
+	yamlOutput      string = "yaml"
+	csvOutput       string = "csv"
 )
 
 const (
@@ -47,6 +56,7 @@ type GlobalOptionsKey struct {
 	kubeconfigAPIURL   string
 	gardenerKubeconfig string
 	gardenerNamespace  string
+	output             string
 }
 
 // GlobalOpts is the convenience object for storing the fixed global conifguration (parameter) keys
@@ -58,6 +68,7 @@ var GlobalOpts = GlobalOptionsKey{
 	kubeconfigAPIURL:   "kubeconfig-api-url",
 	gardenerKubeconfig: "gardener-kubeconfig",
 	gardenerNamespace:  "gardener-namespace",
+	output:             "output",
 }
 
 // SetGlobalOpts configures the global parameters on the given root command
@@ -84,6 +95,26 @@ func SetGlobalOpts(cmd *cobra.Command) {
 	viper.BindPFlag(GlobalOpts.gardenerNamespace, cmd.PersistentFlags().Lookup(GlobalOpts.gardenerNamespace))
 }
 
+// keys returns all global configuration keys, in the order in which they are displayed
+func (keys *GlobalOptionsKey) keys() []string {
+	return []string{keys.oidcIssuerURL, keys.oidcClientID, keys.oidcClientSecret, keys.kebAPIURL, keys.kubeconfigAPIURL, keys.gardenerKubeconfig, keys.gardenerNamespace, keys.output}
+}
+
+// envName returns the name of the environment variable of the given global configuration key, e.g. KCP_KEB_API_URL for keb-api-url
+func envName(key string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// SetEnvDefaults sets the global parameters given in the environment variables as defaults,
+// so that the values from the config file override them, and the values of the options override both
+func SetEnvDefaults() {
+	for _, key := range GlobalOpts.keys() {
+		if value, ok := os.LookupEnv(envName(key)); ok {
+			viper.SetDefault(key, value)
+		}
+	}
+}
+
 // ValidateGlobalOpts checks the presence of the required global configuration parameters
 func ValidateGlobalOpts() error {
 	var reqGlobalOpts = []string{GlobalOpts.oidcIssuerURL, GlobalOpts.oidcClientID, GlobalOpts.oidcClientSecret, GlobalOpts.kebAPIURL}
@@ -135,20 +166,50 @@ func (keys *GlobalOptionsKey) GardenerNamespace() string {
 	return viper.GetString(keys.gardenerNamespace)
 }
 
+// DefaultOutput gets the output global parameter, which is the output type of the commands used when the --output option is not set
+func (keys *GlobalOptionsKey) DefaultOutput() string {
+	return viper.GetString(keys.output)
+}
+
 // SetOutputOpt configures the optput type option on the given command
 func SetOutputOpt(cmd *cobra.Command, opt *string) {
-	cmd.Flags().StringVarP(opt, "output", "o", tableOutput, fmt.Sprintf("Output type of displayed Runtime(s). The possible values are: %s, %s.", tableOutput, jsonOutput))
+	cmd.Flags().StringVarP(opt, "output", "o", tableOutput, fmt.Sprintf("Output type of displayed Runtime(s). The possible values are: %s, %s, %s. The default can be changed using the KCP_OUTPUT environment variable or the output key of the config file.", tableOutput, jsonOutput, yamlOutput))
+}
+
+// ResolveDefaultOutput sets the output option of the given command to the configured default output type, unless the option is set explicitly.
+// The default is taken from the KCP_OUTPUT environment variable, or from the output key of the config file. It must be one of the output types supported by all commands.
+func ResolveDefaultOutput(cmd *cobra.Command) error {
+	defaultOutput := GlobalOpts.DefaultOutput()
+	if defaultOutput == "" {
+		return nil
+	}
+	if err := ValidateOutputOpt(defaultOutput); err != nil {
+		return fmt.Errorf("invalid default output %q set using the KCP_OUTPUT environment variable or the output key of the config file. The possible values are: %s, %s, %s", defaultOutput, tableOutput, jsonOutput, yamlOutput)
+	}
+
+	flag := cmd.Flags().Lookup("output")
+	if flag == nil || flag.Changed {
+		return nil
+	}
+	return flag.Value.Set(defaultOutput)
 }
 
 // ValidateOutputOpt checks whether the given optput type is one of the valid values
 func ValidateOutputOpt(opt string) error {
 	switch opt {
-	case tableOutput, jsonOutput:
+	case tableOutput, jsonOutput, yamlOutput:
 		return nil
 	}
 	return fmt.Errorf("invalid value for output: %s", opt)
 }
 
+// SetTimeOpt configures a time option on the given command. The value is parsed with the shared time window parser,
+// which accepts RFC3339 time, relative time (e.g. -2h, -7d), or one of the named windows today, yesterday, last-week
+func SetTimeOpt(cmd *cobra.Command, opt *timewindow.Window, name, usage string) {
+	cmd.Flags().Var(timewindow.NewValue(timeParser, opt), name, fmt.Sprintf("%s The value is an RFC3339 time (e.g. 2021-01-31T10:00:00Z), a time relative to now (e.g. -2h, -7d), or one of: %s, %s, %s.",
+		usage, timewindow.Today, timewindow.Yesterday, timewindow.LastWeek))
+}
+
 // SetRuntimeTargetOpts configures runtime target options on the given command
 func SetRuntimeTargetOpts(cmd *cobra.Command, targetInputs *[]string, targetExcludeInputs *[]string) {
 	cmd.Flags().StringArrayVarP(targetInputs, "target", "t", nil,
@@ -0,0 +1,147 @@
+package command
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDefaultOutput(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args          []string
+		env           string
+		profile       string
+		expected      string
+		expectedError bool
+	}{
+		"built-in default": {
+			expected: tableOutput,
+		},
+		"profile": {
+			profile:  "output: json",
+			expected: jsonOutput,
+		},
+		"profile over env": {
+			env:      tableOutput,
+			profile:  "output: json",
+			expected: jsonOutput,
+		},
+		"flag over env": {
+			args:     []string{"-o", tableOutput},
+			env:      jsonOutput,
+			expected: tableOutput,
+		},
+		"flag over profile": {
+			args:     []string{"--output", tableOutput},
+			profile:  "output: json",
+			expected: tableOutput,
+		},
+		"yaml env": {
+			env:      yamlOutput,
+			expected: yamlOutput,
+		},
+		"invalid env": {
+			env:           "toml",
+			expectedError: true,
+		},
+		"invalid profile with flag": {
+			args:          []string{"-o", jsonOutput},
+			profile:       "output: xml",
+			expectedError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			defer viper.Reset()
+			if tc.env != "" {
+				require.NoError(t, os.Setenv("KCP_OUTPUT", tc.env))
+				defer os.Unsetenv("KCP_OUTPUT")
+			}
+			SetEnvDefaults()
+			if tc.profile != "" {
+				viper.SetConfigType("yaml")
+				require.NoError(t, viper.ReadConfig(strings.NewReader(tc.profile)))
+			}
+
+			var output string
+			cmd := &cobra.Command{}
+			SetOutputOpt(cmd, &output)
+			require.NoError(t, cmd.ParseFlags(tc.args))
+
+			// when
+			err := ResolveDefaultOutput(cmd)
+
+			// then
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+}
+
+func TestGlobalOptsPrecedence(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args     []string
+		env      string
+		profile  string
+		expected string
+	}{
+		"not set": {
+			expected: "",
+		},
+		"env": {
+			env:      "https://env.example.com",
+			expected: "https://env.example.com",
+		},
+		"profile": {
+			profile:  "keb-api-url: https://profile.example.com",
+			expected: "https://profile.example.com",
+		},
+		"profile over env": {
+			env:      "https://env.example.com",
+			profile:  "keb-api-url: https://profile.example.com",
+			expected: "https://profile.example.com",
+		},
+		"flag over profile": {
+			args:     []string{"--keb-api-url", "https://flag.example.com"},
+			profile:  "keb-api-url: https://profile.example.com",
+			expected: "https://flag.example.com",
+		},
+		"flag over profile and env": {
+			args:     []string{"--keb-api-url", "https://flag.example.com"},
+			env:      "https://env.example.com",
+			profile:  "keb-api-url: https://profile.example.com",
+			expected: "https://flag.example.com",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			defer viper.Reset()
+			if tc.env != "" {
+				require.NoError(t, os.Setenv("KCP_KEB_API_URL", tc.env))
+				defer os.Unsetenv("KCP_KEB_API_URL")
+			}
+			SetEnvDefaults()
+			viper.SetConfigType("yaml")
+			require.NoError(t, viper.ReadConfig(strings.NewReader(tc.profile)))
+
+			cmd := &cobra.Command{}
+			SetGlobalOpts(cmd)
+
+			// when
+			err := cmd.ParseFlags(tc.args)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, GlobalOpts.KEBAPIURL())
+		})
+	}
+}
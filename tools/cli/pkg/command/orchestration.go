@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"os/user"
 	"sort"
 	"strings"
 	"text/template"
@@ -18,20 +19,24 @@ import (
 
 const (
 	cancelCommand     = "cancel"
+	failCommand       = "fail"
 	operationsCommand = "operations"
 	opsCommand        = "ops"
 )
 
 // OrchestrationCommand represents an execution of the kcp orchestrations command
 type OrchestrationCommand struct {
-	cobraCmd   *cobra.Command
-	log        logger.Logger
-	client     orchestration.Client
-	output     string
-	states     []string
-	operation  string
-	subCommand string
-	listParams orchestration.ListParameters
+	cobraCmd    *cobra.Command
+	log         logger.Logger
+	client      orchestration.Client
+	output      string
+	states      []string
+	operation   string
+	whyDeferred bool
+	reason      string
+	initiator   string
+	subCommand  string
+	listParams  orchestration.ListParameters
 }
 
 var cliStates = map[string]string{
@@ -41,6 +46,7 @@ var cliStates = map[string]string{
 	"inprogress": orchestration.InProgress,
 	"canceled":   orchestration.Canceled,
 	"canceling":  orchestration.Canceling,
+	"paused":     orchestration.Paused,
 }
 
 var orchestrationColumns = []printer.Column{
@@ -71,6 +77,10 @@ var operationColumns = []printer.Column{
 		Header:    "OPERATION ID",
 		FieldSpec: "{.OperationID}",
 	},
+	{
+		Header:    "RUNTIME ID",
+		FieldSpec: "{.RuntimeID}",
+	},
 	{
 		Header:    "SHOOT",
 		FieldSpec: "{.ShootName}",
@@ -83,10 +93,23 @@ var operationColumns = []printer.Column{
 		Header:    "SUBACCOUNT",
 		FieldSpec: "{.SubAccountID}",
 	},
+	{
+		Header:    "DRY RUN",
+		FieldSpec: "{.DryRun}",
+	},
 	{
 		Header:    "STATE",
 		FieldSpec: "{.State}",
 	},
+	{
+		Header:         "PROGRESS",
+		FieldFormatter: orchestrationOperationProgress,
+	},
+}
+
+var deferralReasonColumn = printer.Column{
+	Header:    "DEFERRAL REASON",
+	FieldSpec: "{.DeferralReason}",
 }
 
 var orchestrationDetailsTpl = `Orchestration ID: {{.OrchestrationID}}
@@ -129,15 +152,22 @@ Service Plan:       {{.ServicePlanName}}
 Maintenance Window: {{.MaintenanceWindowBegin}} - {{.MaintenanceWindowEnd}}
 State:              {{.State}}
 Description:        {{.Description}}
+{{- if .DeferralReason }}
+Deferral Reason:    {{.DeferralReason}}
+{{- end }}
 Kubernetes Version: {{.ClusterConfig.KubernetesVersion}}
 Kyma Version:       {{.KymaConfig.Version}}
 `
 
 // NewOrchestrationCmd constructs a new instance of OrchestrationCommand and configures it in terms of a cobra.Command
 func NewOrchestrationCmd() *cobra.Command {
-	cmd := OrchestrationCommand{}
+	return newOrchestrationCommand().cobraCmd
+}
+
+func newOrchestrationCommand() *OrchestrationCommand {
+	cmd := &OrchestrationCommand{}
 	cobraCmd := &cobra.Command{
-		Use:     "orchestrations [id] [ops|operations] [cancel]",
+		Use:     "orchestrations [id] [ops|operations] [cancel] [fail]",
 		Aliases: []string{"orchestration", "o"},
 		Short:   "Displays Kyma Control Plane (KCP) orchestrations.",
 		Long: `Displays KCP orchestrations and their primary attributes, such as identifiers, type, state, parameters, or Runtime operations.
@@ -145,13 +175,17 @@ The command has the following modes:
   - Without specifying an orchestration ID as an argument. In this mode, the command lists all orchestrations, or orchestrations matching the --state option, if provided.
   - When specifying an orchestration ID as an argument. In this mode, the command displays details about the specific orchestration.
       If the optional --operation flag is provided, it displays details of the specified Runtime operation within the orchestration.
-  - When specifying an orchestration ID and ` + "`operations` or `ops`" + ` as arguments. In this mode, the command displays the Runtime operations for the given orchestration.
-  - When specifying an orchestration ID and ` + "`cancel`" + ` as arguments. In this mode, the command cancels the orchestration and all pending Runtime operations.`,
+  - When specifying an orchestration ID and ` + "`operations` or `ops`" + ` as arguments. In this mode, the command displays the Runtime operations for the given orchestration, or the operations matching the --state option, if provided, together with their progress in percent.
+  - When specifying an orchestration ID and ` + "`cancel`" + ` as arguments. In this mode, the command cancels the orchestration and all pending Runtime operations.
+  - When specifying an orchestration ID and ` + "`fail`" + ` as arguments together with the --operation and --reason flags. In this mode, the command marks the Runtime operation as failed regardless of its state.`,
 		Example: `  kcp orchestrations --state inprogress                                   Display all orchestrations which are in progress.
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00                  Display details about a specific orchestration.
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 --operation OID  Display details of the specified Runtime operation within the orchestration.
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 operations       Display the operations of the given orchestration.
-  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 cancel           Cancel the given orchestration.`,
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 ops --why-deferred  Display the operations of the given orchestration and why they are deferred.
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 ops --state failed  Display the operations of the given orchestration which failed.
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 cancel           Cancel the given orchestration.
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 fail --operation OID --reason "Shoot was deleted"  Mark the specified Runtime operation as failed.`,
 		Args:    cobra.MaximumNArgs(2),
 		PreRunE: func(_ *cobra.Command, args []string) error { return cmd.Validate(args) },
 		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args) },
@@ -161,7 +195,10 @@ The command has the following modes:
 	SetOutputOpt(cobraCmd, &cmd.output)
 	cobraCmd.Flags().StringSliceVarP(&cmd.states, "state", "s", nil, fmt.Sprintf("Filter output by state. You can provide multiple values, either separated by a comma (e.g. failed,inprogress), or by specifying the option multiple times. The possible values are: %s.", strings.Join(cliOrchestrationStates(), ", ")))
 	cobraCmd.Flags().StringVar(&cmd.operation, "operation", "", "Option that displays details of the specified Runtime operation when a given orchestration is selected.")
-	return cobraCmd
+	cobraCmd.Flags().StringVar(&cmd.reason, "reason", "", "Reason why the Runtime operation is marked as failed. It is required by the fail subcommand.")
+	cobraCmd.Flags().StringVar(&cmd.initiator, "initiator", "", "Person who marks the Runtime operation as failed. Defaults to the name of the current user. It is recorded as unverified, the authenticated user is recorded instead when the request is authenticated.")
+	cobraCmd.Flags().BoolVar(&cmd.whyDeferred, "why-deferred", false, "Option that displays the reason why the processing of each Runtime operation is postponed. It can only be used together with the operations subcommand.")
+	return cmd
 }
 
 func cliOrchestrationStates() []string {
@@ -199,7 +236,7 @@ func (cmd *OrchestrationCommand) validateTransformOrchestrationStates() error {
 // Run executes the orchestrations command
 func (cmd *OrchestrationCommand) Run(args []string) error {
 	cmd.log = logger.New()
-	cmd.client = orchestration.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+	cmd.client = orchestration.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLITokenSource(cmd.log))
 
 	switch len(args) {
 	case 0:
@@ -216,6 +253,8 @@ func (cmd *OrchestrationCommand) Run(args []string) error {
 		switch cmd.subCommand {
 		case cancelCommand:
 			return cmd.cancelOrchestration(args[0])
+		case failCommand:
+			return cmd.forceFailOperation(args[0])
 		case operationsCommand, opsCommand:
 			return cmd.showOperations(args[0])
 		}
@@ -246,11 +285,25 @@ func (cmd *OrchestrationCommand) Validate(args []string) error {
 	if len(args) == 2 {
 		cmd.subCommand = args[1]
 		switch cmd.subCommand {
-		case cancelCommand, operationsCommand, opsCommand:
+		case cancelCommand, operationsCommand, opsCommand, failCommand:
 		default:
 			return fmt.Errorf("invalid subcommand: %s", cmd.subCommand)
 		}
 	}
+	if cmd.subCommand == failCommand {
+		if cmd.operation == "" || cmd.reason == "" {
+			return errors.New("both --operation and --reason must be provided to mark an operation as failed")
+		}
+		if cmd.initiator == "" {
+			cmd.initiator = currentUsername()
+		}
+		if cmd.initiator == "" {
+			return errors.New("--initiator must be provided to mark an operation as failed")
+		}
+	}
+	if cmd.whyDeferred && cmd.subCommand != operationsCommand && cmd.subCommand != opsCommand {
+		return errors.New("--why-deferred should only be used together with the operations subcommand")
+	}
 
 	return nil
 }
@@ -271,6 +324,8 @@ func (cmd *OrchestrationCommand) showOrchestrations() error {
 	case jsonOutput:
 		jp := printer.NewJSONPrinter("  ")
 		jp.PrintObj(srl)
+	case yamlOutput:
+		return printer.NewYAMLPrinter().PrintObj(srl)
 	}
 
 	return nil
@@ -300,6 +355,8 @@ func (cmd *OrchestrationCommand) showOneOrchestration(orchestrationID string) er
 	case jsonOutput:
 		jp := printer.NewJSONPrinter("  ")
 		jp.PrintObj(sr)
+	case yamlOutput:
+		return printer.NewYAMLPrinter().PrintObj(sr)
 	}
 
 	return nil
@@ -315,7 +372,11 @@ func (cmd *OrchestrationCommand) showOperations(orchestrationID string) error {
 	case tableOutput:
 		// Print operation table
 		if len(orl.Data) > 0 {
-			tp, err := printer.NewTablePrinter(operationColumns, false)
+			columns := operationColumns
+			if cmd.whyDeferred {
+				columns = append(columns[:len(columns):len(columns)], deferralReasonColumn)
+			}
+			tp, err := printer.NewTablePrinter(columns, false)
 			if err != nil {
 				return err
 			}
@@ -324,6 +385,8 @@ func (cmd *OrchestrationCommand) showOperations(orchestrationID string) error {
 	case jsonOutput:
 		jp := printer.NewJSONPrinter("  ")
 		jp.PrintObj(orl)
+	case yamlOutput:
+		return printer.NewYAMLPrinter().PrintObj(orl)
 	}
 
 	return nil
@@ -348,6 +411,8 @@ func (cmd *OrchestrationCommand) showOperationDetails(orchestrationID string) er
 	case jsonOutput:
 		jp := printer.NewJSONPrinter("  ")
 		jp.PrintObj(odr)
+	case yamlOutput:
+		return printer.NewYAMLPrinter().PrintObj(odr)
 	}
 
 	return nil
@@ -379,6 +444,43 @@ func (cmd *OrchestrationCommand) cancelOrchestration(orchestrationID string) err
 
 }
 
+func (cmd *OrchestrationCommand) forceFailOperation(orchestrationID string) error {
+	odr, err := cmd.client.GetOperation(orchestrationID, cmd.operation)
+	if err != nil {
+		return errors.Wrap(err, "while getting operation details")
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	fmt.Printf("Operation %s of Runtime %s (%s) in state %s will be marked as failed by %s.\n", odr.OperationID, odr.RuntimeID, odr.ShootName, odr.State, cmd.initiator)
+	fmt.Print("Do you want to continue? (Y/N) ")
+	scanner.Scan()
+	if scanner.Text() != "Y" {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	_, err = cmd.client.ForceFailOperation(orchestrationID, cmd.operation, orchestration.ForceFailRequest{
+		Reason:    cmd.reason,
+		Initiator: cmd.initiator,
+	})
+	if err != nil {
+		return errors.Wrap(err, "while marking operation as failed")
+	}
+	fmt.Println("Operation marked as failed.")
+
+	return nil
+}
+
+// currentUsername returns the name of the user running the CLI, or an empty string if it cannot be determined
+func currentUsername() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+
+	return u.Username
+}
+
 // Currently only orchestrations of type "kyma upgrade" are supported,
 // and the type is not reflected in the StatusResponse object
 func orchestrationType(obj interface{}) string {
@@ -390,6 +492,11 @@ func orchestrationCreatedAt(obj interface{}) string {
 	return sr.CreatedAt.Format("2006/01/02 15:04:05")
 }
 
+func orchestrationOperationProgress(obj interface{}) string {
+	op := obj.(orchestration.OperationResponse)
+	return progressPercent(op.State, op.ProgressPercent)
+}
+
 // orchestrationTarget returns the string representation of a orchestration.RuntimeTarget
 func orchestrationTarget(t orchestration.RuntimeTarget) string {
 	targets := []string{}
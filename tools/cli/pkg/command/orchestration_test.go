@@ -0,0 +1,112 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrchestrationCommand_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args    []string
+		flags   []string
+		wantErr bool
+	}{
+		"list orchestrations": {},
+		"operations with deferral reasons": {
+			args:  []string{"id", "ops"},
+			flags: []string{"--why-deferred"},
+		},
+		"failed operations": {
+			args:  []string{"id", "ops"},
+			flags: []string{"--state", "failed"},
+		},
+		"operations with invalid state": {
+			args:    []string{"id", "ops"},
+			flags:   []string{"--state", "upgrading"},
+			wantErr: true,
+		},
+		"deferral reasons without operations": {
+			args:    []string{"id"},
+			flags:   []string{"--why-deferred"},
+			wantErr: true,
+		},
+		"fail operation": {
+			args:  []string{"id", "fail"},
+			flags: []string{"--operation", "op", "--reason", "shoot was deleted", "--initiator", "admin"},
+		},
+		"fail without operation": {
+			args:    []string{"id", "fail"},
+			flags:   []string{"--reason", "shoot was deleted"},
+			wantErr: true,
+		},
+		"fail without reason": {
+			args:    []string{"id", "fail"},
+			flags:   []string{"--operation", "op"},
+			wantErr: true,
+		},
+		"invalid subcommand": {
+			args:    []string{"id", "retry"},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := newOrchestrationCommand()
+			require.NoError(t, cmd.cobraCmd.ParseFlags(tc.flags))
+
+			// when
+			err := cmd.Validate(tc.args)
+
+			// then
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrchestrationOperationColumns(t *testing.T) {
+	// given
+	operations := []orchestration.OperationResponse{
+		{
+			OperationID:     "op-1",
+			RuntimeID:       "rt-1",
+			ShootName:       "c-1",
+			GlobalAccountID: "ga-1",
+			SubAccountID:    "sa-1",
+			DryRun:          true,
+			State:           orchestration.Failed,
+			ProgressPercent: 50,
+		},
+		{
+			OperationID:     "op-2",
+			RuntimeID:       "rt-2",
+			ShootName:       "c-2",
+			GlobalAccountID: "ga-1",
+			SubAccountID:    "sa-2",
+			State:           orchestration.Succeeded,
+		},
+	}
+	buf := &bytes.Buffer{}
+	tp, err := printer.NewTablePrinterTo(buf, operationColumns, false)
+	require.NoError(t, err)
+
+	// when
+	err = tp.PrintObj(operations)
+
+	// then
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"OPERATION", "ID", "RUNTIME", "ID", "SHOOT", "GLOBALACCOUNT", "SUBACCOUNT", "DRY", "RUN", "STATE", "PROGRESS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"op-1", "rt-1", "c-1", "ga-1", "sa-1", "true", "failed", "50%"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"op-2", "rt-2", "c-2", "ga-1", "sa-2", "false", "succeeded", "100%"}, strings.Fields(lines[2]))
+}
@@ -3,12 +3,12 @@ package command
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/kyma-project/control-plane/tools/cli/pkg/credential"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
 )
 
 // Version is the CLI version to be filled in by the build system
@@ -27,7 +27,10 @@ The CLI supports configuration file for common (global) options needed for all c
   - $HOME/.kcp/config.yaml (default path).
 
 The configuration file is in YAML format and supports the following global options: %s, %s, %s, %s, %s, %s.
-See the **Global Options** section of each command for the description of these options.`, GlobalOpts.oidcIssuerURL, GlobalOpts.oidcClientID, GlobalOpts.oidcClientSecret, GlobalOpts.kebAPIURL, GlobalOpts.kubeconfigAPIURL, GlobalOpts.gardenerKubeconfig)
+See the **Global Options** section of each command for the description of these options.
+The values of the options override the values from the configuration file, which override the values of the KCP_ environment variables, e.g. KCP_KEB_API_URL.
+Use the kcp config view command to display the effective configuration.
+The output type of the commands which display data can be changed from the default %s using the output key, or the KCP_OUTPUT environment variable.`, GlobalOpts.oidcIssuerURL, GlobalOpts.oidcClientID, GlobalOpts.oidcClientSecret, GlobalOpts.kebAPIURL, GlobalOpts.kubeconfigAPIURL, GlobalOpts.gardenerKubeconfig, tableOutput)
 
 	cmd := &cobra.Command{
 		Use:     "kcp",
@@ -35,12 +38,19 @@ See the **Global Options** section of each command for the description of these
 		Long:    description,
 		Version: Version,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
-			if cmd.CalledAs() != "help" {
-				return ValidateGlobalOpts()
+			// the completion of the option values must not fail, even if the required global options are missing
+			switch cmd.CalledAs() {
+			case "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+				return nil
 			}
-			return nil
+			if err := ValidateGlobalOpts(); err != nil {
+				return err
+			}
+			return ResolveDefaultOutput(cmd)
 		},
 		SilenceUsage: true,
+		// the error is reported by the caller in the configured log format
+		SilenceErrors: true,
 	}
 
 	cmd.PersistentFlags().StringVar(&configPath, "config", os.Getenv(configEnv), "Path to the KCP CLI config file. Can also be set using the KCPCONFIG environment variable. Defaults to $HOME/.kcp/config.yaml .")
@@ -50,7 +60,9 @@ See the **Global Options** section of each command for the description of these
 
 	cmd.AddCommand(
 		NewLoginCmd(),
+		NewConfigCmd(),
 		NewRuntimeCmd(),
+		NewOperationCmd(),
 		NewOrchestrationCmd(),
 		NewKubeconfigCmd(),
 		NewUpgradeCmd(),
@@ -76,10 +88,7 @@ func initConfig() {
 		viper.SetConfigName("config")
 	}
 	viper.SetConfigType("yaml")
-	replacer := strings.NewReplacer("-", "_")
-	viper.SetEnvKeyReplacer(replacer)
-	viper.SetEnvPrefix("KCP")
-	viper.AutomaticEnv()
+	SetEnvDefaults()
 	err := viper.ReadInConfig()
 	// Ignore when config file is not found to allow config parameters being passed as flags or environment variables
 	// Panic otherwise
@@ -93,3 +102,9 @@ func initConfig() {
 func CLICredentialManager(logger logger.Logger) credential.Manager {
 	return credential.NewManager(GlobalOpts.OIDCIssuerURL(), GlobalOpts.OIDCClientID(), GlobalOpts.OIDCClientSecret(), logger)
 }
+
+// CLITokenSource returns the token source of the KEB API clients. The token is obtained by the CLI credential manager,
+// and the requests wait for its refresh, which is retried before the command fails.
+func CLITokenSource(logger logger.Logger) oauth2.TokenSource {
+	return credential.NewRefreshingTokenSource(CLICredentialManager(logger), credential.DefaultRefreshAttempts, credential.DefaultRefreshBackoff, logger)
+}
@@ -1,27 +1,89 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/Masterminds/semver"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/timewindow"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 // RuntimeCommand represents an execution of the kcp runtimes command
 type RuntimeCommand struct {
-	cobraCmd *cobra.Command
-	log      logger.Logger
-	output   string
-	params   runtime.ListParameters
+	cobraCmd         *cobra.Command
+	log              logger.Logger
+	output           string
+	params           runtime.ListParameters
+	haOnly           bool
+	nonHA            bool
+	errorContains    string
+	shootRegex       string
+	shootPattern     *regexp.Regexp
+	kymaVersion      string
+	kymaConstraint   *semver.Constraints
+	jsonMatchColumns bool
+	customColumns    []printer.Column
+	templatePrinter  printer.TemplatePrinter
+	jsonPathPrinter  printer.JSONPathPrinter
+	columnOrder      []string
+	selectedColumns  []string
+	noHeaders        bool
+	quiet            bool
+	summary          bool
+	maxColumnWidth   int
+	match            string
+	sortBy           string
+	sortOrder        string
+	sortColumn       *printer.Column
+	color            string
+	createdAfter     timewindow.Window
+	count            bool
+	createdBefore    timewindow.Window
+	explainState     bool
+	showOperationID  bool
+	columns          []printer.Column
+	watch            bool
+	watchDiff        bool
+	watchInterval    time.Duration
+	timeout          time.Duration
+	qps              float64
+	checksum         bool
+
+	// the filters which are not supported by KEB are applied to the listed runtimes
+	states                  []string
+	excludeGlobalAccountIDs []string
+	excludeRegions          []string
+	excludePlans            []string
+	providers               []string
+	platformRegions         []string
 }
 
+// customColumnsOutputPrefix is the prefix of the output type which defines the table columns, e.g. custom-columns=SHOOT:{.ShootName}
+const customColumnsOutputPrefix = "custom-columns="
+
+// templateOutputPrefix is the prefix of the output type which defines the Go template executed for each Runtime, e.g. go-template={{.ShootName}}
+const templateOutputPrefix = "go-template="
+
+// jsonPathOutputPrefix is the prefix of the output type which defines the JSONPath expression evaluated against the JSON output, e.g. jsonpath={.data[*].shootName}
+const jsonPathOutputPrefix = "jsonpath="
+
 const (
 	inProgress = "in progress"
 	succeeded  = "succeeded"
 	failed     = "failed"
+	canceled   = "canceled"
 )
 
 type operationType string
@@ -34,6 +96,28 @@ const (
 	unsuspension operationType = "unsuspension"
 )
 
+type availability int
+
+const (
+	availabilityUnknown availability = iota
+	availabilityHA
+	availabilityNonHA
+)
+
+// Cloud providers accepted by the --provider option
+const (
+	azureProvider = "azure"
+	gcpProvider   = "gcp"
+)
+
+// planProviders maps the service plans to the cloud providers of their Runtimes.
+// The trial plan is not listed, because its provider depends on the configuration of Kyma Environment Broker.
+var planProviders = map[string]string{
+	azurePlan:     azureProvider,
+	azureLitePlan: azureProvider,
+	gcpPlan:       gcpProvider,
+}
+
 var tableColumns = []printer.Column{
 	{
 		Header:    "GLOBALACCOUNT ID",
@@ -62,111 +146,1170 @@ var tableColumns = []printer.Column{
 	{
 		Header:         "STATE",
 		FieldFormatter: runtimeStatus,
+		Color:          runtimeStatusColor,
+	},
+	{
+		Header:         "DURATION",
+		FieldFormatter: runtimeLastOperationDuration,
+	},
+}
+
+var operationIDColumn = printer.Column{
+	Header:         "OPERATION ID",
+	FieldFormatter: runtimeLastOperationID,
+}
+
+var kymaVersionColumn = printer.Column{
+	Header:    "KYMA VERSION",
+	FieldSpec: "{.KymaVersion}",
+}
+
+var platformRegionColumn = printer.Column{
+	Header:    "PLATFORM REGION",
+	FieldSpec: "{.SubAccountRegion}",
+}
+
+// optionalColumns are not displayed by default, but can be selected with --columns
+var optionalColumns = []printer.Column{
+	operationIDColumn,
+	{
+		Header:         "OPERATION TYPE",
+		FieldFormatter: runtimeLastOperationType,
+	},
+	kymaVersionColumn,
+	platformRegionColumn,
+}
+
+// explainStateColumns are displayed with --explain-state to show from which operation the STATE column is derived
+var explainStateColumns = []printer.Column{
+	{
+		Header:    "SHOOT",
+		FieldSpec: "{.ShootName}",
+	},
+	{
+		Header:         "STATE",
+		FieldFormatter: runtimeStatus,
+		Color:          runtimeStatusColor,
+	},
+	{
+		Header:         "OPERATION",
+		FieldFormatter: lastOperationType,
+	},
+	{
+		Header:         "OPERATION STATE",
+		FieldFormatter: lastOperationState,
+	},
+	{
+		Header:         "REASON",
+		FieldFormatter: lastOperationReason,
 	},
 }
 
+// Sort orders of the --sort-order option
+const (
+	sortAscending  = "asc"
+	sortDescending = "desc"
+)
+
+// Values of the --match option
+const (
+	matchAll = "all"
+	matchAny = "any"
+)
+
+// Values of the --color option
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// maxPageSize is the maximum number of runtimes KEB returns in one page
+const maxPageSize = 100
+
 // NewRuntimeCmd constructs a new instance of RuntimeCommand and configures it in terms of a cobra.Command
 func NewRuntimeCmd() *cobra.Command {
-	cmd := RuntimeCommand{}
+	return newRuntimeCommand().cobraCmd
+}
+
+func newRuntimeCommand() *RuntimeCommand {
+	cmd := &RuntimeCommand{}
 	cobraCmd := &cobra.Command{
 		Use:     "runtimes",
 		Aliases: []string{"runtime", "rt"},
 		Short:   "Displays Kyma Runtimes.",
 		Long: `Displays Kyma Runtimes and their primary attributes, such as identifiers, region, or states.
-The command supports filtering Runtimes based on various attributes. See the list of options for more details.`,
+The command supports filtering Runtimes based on various attributes. See the list of options for more details.
+The values of the filters which accept multiple values can also be read from a file given as @FILE, e.g. --subaccount @subaccounts.txt. Each line of the file is a value, and the empty lines and the lines starting with # are ignored.`,
 		Example: `  kcp runtimes                                           Display table overview about all Runtimes.
   kcp rt -c c-178e034 -o json                            Display all details about one Runtime identified by a Shoot name in the JSON format.
-  kcp runtimes --account CA4836781TID000000000123456789  Display all Runtimes of a given global account.`,
+  kcp runtimes --shoot-regex '^c-[0-9a-f]{7}$'           Display all Runtimes with a Shoot name following the given naming convention.
+  kcp runtimes -o csv > runtimes.csv                     Save the table overview about all Runtimes in the CSV format.
+  kcp runtimes -o jsonl > runtimes.jsonl                 Save all details about all Runtimes in the JSON Lines format, one Runtime per line.
+  kcp runtimes -o go-template='{{.ShootName}}{{"\n"}}'   Display the Shoot name of each Runtime using a Go template.
+  kcp runtimes -o jsonpath='{.data[*].shootName}'        Display the Shoot names of all Runtimes in one line using a JSONPath expression.
+  kcp runtimes --page 2 --page-size 50                   Display the second page of 50 Runtimes.
+  kcp runtimes --account CA4836781TID000000000123456789  Display all Runtimes of a given global account.
+  kcp runtimes --subaccount @subaccounts.txt             Display all Runtimes of the subaccounts listed in a file, one per line.
+  kcp runtimes --watch --state upgrading                 Display the Runtimes being upgraded, refreshed every 10 seconds.
+  kcp runtimes --watch-diff --watch-interval 1m          Display all Runtimes and then every minute display the Runtimes which changed.
+  kcp runtimes --error-contains "quota exceeded"         Display all Runtimes whose latest failed operation reported the given error.
+  kcp runtimes --state failed,upgrading                  Display all Runtimes which failed or are being upgraded.
+  kcp runtimes -r westeurope -p trial --match any        Display all Runtimes in westeurope and all trial Runtimes.
+  kcp runtimes --created-after -7d                       Display all Runtimes created in the last 7 days.
+  kcp runtimes --exclude-plan trial                      Display all Runtimes except the trial ones.
+  kcp runtimes --provider gcp                            Display all Runtimes running on Google Cloud Platform.
+  kcp runtimes --platform-region cf-eu10                 Display all Runtimes of the subaccounts in the cf-eu10 platform region.
+  kcp runtimes --kyma-version "<1.20"                    Display all Runtimes which are not upgraded to Kyma 1.20 yet.
+  kcp runtimes --plan trial --count                      Display the number of trial Runtimes.
+  kcp runtimes --summary                                 Display table overview about all Runtimes followed by the number of Runtimes in each state.
+  kcp runtimes --column-order state,shoot                Display table overview about all Runtimes with the STATE and SHOOT columns first.
+  kcp runtimes --columns SHOOT,REGION,STATE              Display table overview about all Runtimes with only the SHOOT, REGION, and STATE columns.
+  kcp runtimes --state failed --show-operation-id        Display all failed Runtimes with the ID of the failed operation.
+  kcp runtimes --columns SHOOT --no-headers              Display the Shoot names of all Runtimes without the header row.
+  kcp runtimes --state failed -q | xargs -n 1 echo       Pass the Shoot names of all failed Runtimes to another command.
+  kcp runtimes --sort-by "CREATED AT" --sort-order desc  Display table overview about all Runtimes, starting with the most recently created one.
+  kcp runtimes --explain-state -c c-178e034              Display from which operation the state of a Runtime is derived.
+  kcp runtimes --checksum                                Display all Runtimes and write their SHA-256 checksum to the standard error.`,
 		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
 		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
 	}
 	cmd.cobraCmd = cobraCmd
+	cobraCmd.AddCommand(NewRuntimeQuotaCmd(), NewRuntimeDiffCmd(), NewRuntimeLastErrorsCmd(), NewRuntimeSLACmd(), NewRuntimeOperationCmd())
 
 	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().Lookup("output").Usage = fmt.Sprintf("Output type of displayed Runtime(s). The possible values are: %s, %s, %s, %s, %s, %sHEADER:FIELDSPEC[,HEADER:FIELDSPEC...], where FIELDSPEC is a JSONPath expression, e.g. {.Status.CreatedAt}, %sTEMPLATE, where TEMPLATE is a Go template executed for each Runtime, %sEXPRESSION, where EXPRESSION is a JSONPath expression evaluated against the json output, e.g. {.data[*].shootName}. The %s output displays each Runtime as a JSON document in a separate line, as soon as it is encoded.", tableOutput, jsonOutput, jsonLinesOutput, yamlOutput, csvOutput, customColumnsOutputPrefix, templateOutputPrefix, jsonPathOutputPrefix, jsonLinesOutput)
+	cobraCmd.Flags().StringSliceVar(&cmd.selectedColumns, "columns", nil, "Columns of the table output, given as a comma-separated list of column headers (e.g. SHOOT,REGION,STATE). Only the listed columns are displayed, in the given order. By default, all columns except OPERATION ID, OPERATION TYPE, KYMA VERSION, and PLATFORM REGION are displayed.")
+	cobraCmd.Flags().StringVar(&cmd.sortBy, "sort-by", "", "Sort the Runtimes by the values of the given table column, identified by its header (e.g. \"CREATED AT\", REGION). The Runtimes with the same value are sorted by the Runtime ID. The column does not need to be displayed.")
+	cobraCmd.Flags().StringVar(&cmd.sortOrder, "sort-order", sortAscending, fmt.Sprintf("Order of the Runtimes sorted with --sort-by. The possible values are: %s, %s.", sortAscending, sortDescending))
+	cobraCmd.Flags().StringVar(&cmd.color, "color", colorAuto, fmt.Sprintf("Colorize the STATE column of the table output. The possible values are: %s, %s, %s. With %s, the colors are used only when the output is a terminal.", colorAuto, colorAlways, colorNever, colorAuto))
+	cobraCmd.Flags().BoolVar(&cmd.showOperationID, "show-operation-id", false, "Display the OPERATION ID column with the ID of the operation from which the STATE of each Runtime is derived. The OPERATION ID and OPERATION TYPE columns can also be selected with --columns.")
+	cobraCmd.Flags().BoolVar(&cmd.noHeaders, "no-headers", false, "Do not display the header row of the table output.")
+	cobraCmd.Flags().BoolVarP(&cmd.quiet, "quiet", "q", false, "Display only the Shoot names of the Runtimes matching the filters, one per line, without the header row. The output type is ignored.")
+	cobraCmd.Flags().IntVar(&cmd.maxColumnWidth, "max-column-width", 0, "Truncate the values of the table output which are longer than the given number of characters, e.g. long global account IDs, with an ellipsis. The other outputs always display the full values. By default, the values are not truncated.")
+	cobraCmd.Flags().StringSliceVar(&cmd.columnOrder, "column-order", nil, "Order of the table columns, given as a comma-separated list of column keys, which are the lowercase headers with spaces replaced by dashes (e.g. state,shoot). The columns which are not listed are displayed after the listed ones in the default order.")
+	cobraCmd.Flags().BoolVar(&cmd.explainState, "explain-state", false, "Display the operation from which the STATE of each Runtime is derived, together with the operation state and the reason why the operation was chosen. Supported only with the table output.")
+	cobraCmd.Flags().BoolVar(&cmd.jsonMatchColumns, "json-match-columns", false, fmt.Sprintf("Display the Runtimes in the JSON format containing only the fields referenced by the %s output. Each FIELDSPEC must reference a single field.", customColumnsOutputPrefix))
 	cobraCmd.Flags().StringSliceVarP(&cmd.params.Shoots, "shoot", "c", nil, "Filter by Shoot cluster name. You can provide multiple values, either separated by a comma (e.g. shoot1,shoot2), or by specifying the option multiple times.")
+	cobraCmd.Flags().StringVar(&cmd.shootRegex, "shoot-regex", "", "Filter by Shoot cluster name matching the given regular expression, e.g. \"^c-[0-9a-f]{7}$\". The expression is not anchored, so it matches any part of the name unless ^ and $ are used. It can be used together with --shoot, and then a Runtime must match both.")
 	cobraCmd.Flags().StringSliceVarP(&cmd.params.GlobalAccountIDs, "account", "g", nil, "Filter by global account ID. You can provide multiple values, either separated by a comma (e.g. GAID1,GAID2), or by specifying the option multiple times.")
 	cobraCmd.Flags().StringSliceVarP(&cmd.params.SubAccountIDs, "subaccount", "s", nil, "Filter by subaccount ID. You can provide multiple values, either separated by a comma (e.g. SAID1,SAID2), or by specifying the option multiple times.")
 	cobraCmd.Flags().StringSliceVarP(&cmd.params.RuntimeIDs, "runtime-id", "i", nil, "Filter by Runtime ID. You can provide multiple values, either separated by a comma (e.g. ID1,ID2), or by specifying the option multiple times.")
 	cobraCmd.Flags().StringSliceVarP(&cmd.params.Regions, "region", "r", nil, "Filter by provider region. You can provide multiple values, either separated by a comma (e.g. westeurope,northeurope), or by specifying the option multiple times.")
 	cobraCmd.Flags().StringSliceVarP(&cmd.params.Plans, "plan", "p", nil, "Filter by service plan name. You can provide multiple values, either separated by a comma (e.g. azure,trial), or by specifying the option multiple times.")
+	cobraCmd.Flags().IntVar(&cmd.params.PageSize, "page-size", maxPageSize, fmt.Sprintf("Number of Runtimes to fetch from Kyma Environment Broker in one request. The value must be between 1 and %d.", maxPageSize))
+	cobraCmd.Flags().IntVar(&cmd.params.Page, "page", 0, "Number of the page of Runtimes to fetch from Kyma Environment Broker, starting from 1. The size of the page is set with --page-size. By default, all pages are fetched.")
+	cobraCmd.Flags().IntVar(&cmd.params.MaxResults, "max-results", 0, "Maximum number of Runtimes to display. By default, all Runtimes matching the filters are displayed.")
+	cobraCmd.Flags().StringVar(&cmd.match, "match", matchAll, fmt.Sprintf("Combination of the --shoot, --account, --subaccount, --runtime-id, --region, and --plan filters. The possible values are: %s, %s. With %s, a Runtime is displayed if it matches all of these filters, and with %s, if it matches at least one of them. The values of a single filter are always combined with OR. The other filters always apply to the matching Runtimes.", matchAll, matchAny, matchAll, matchAny))
+	cobraCmd.Flags().BoolVar(&cmd.haOnly, "ha-only", false, "Display only highly available Runtimes, which are spread across multiple zones. Fails if the number of zones of a Runtime is not reported by Kyma Environment Broker.")
+	cobraCmd.Flags().BoolVar(&cmd.nonHA, "non-ha", false, "Display only Runtimes which are not highly available, which run in a single zone. Fails if the number of zones of a Runtime is not reported by Kyma Environment Broker.")
+	cobraCmd.Flags().StringSliceVar(&cmd.states, "state", nil, "Filter by the state displayed in the STATE column, e.g. failed, provisioning, upgrading, succeeded, suspended. A state matches also the states with details in parentheses, e.g. failed matches \"failed (upgradeKyma)\". You can provide multiple values, either separated by a comma (e.g. failed,upgrading), or by specifying the option multiple times.")
+	cobraCmd.Flags().StringSliceVar(&cmd.excludeGlobalAccountIDs, "exclude-account", nil, "Exclude the Runtimes of the given global account IDs. You can provide multiple values, either separated by a comma (e.g. GAID1,GAID2), or by specifying the option multiple times.")
+	cobraCmd.Flags().StringSliceVar(&cmd.excludeRegions, "exclude-region", nil, "Exclude the Runtimes in the given provider regions. You can provide multiple values, either separated by a comma (e.g. westeurope,northeurope), or by specifying the option multiple times.")
+	cobraCmd.Flags().StringSliceVar(&cmd.excludePlans, "exclude-plan", nil, "Exclude the Runtimes with the given service plan names. The exclusions are applied to the Runtimes matching the other filters, e.g. --plan azure,trial --exclude-plan trial displays only the azure Runtimes. You can provide multiple values, either separated by a comma (e.g. azure,trial), or by specifying the option multiple times.")
+	cobraCmd.Flags().StringSliceVar(&cmd.providers, "provider", nil, fmt.Sprintf("Filter by cloud provider. The possible values are: %s, %s. The provider is inferred from the service plan: the %s and %s plans run on %s, and the %s plan runs on %s. The provider of the %s Runtimes cannot be inferred, so they are never displayed with this option. You can provide multiple values, either separated by a comma (e.g. azure,gcp), or by specifying the option multiple times.", azureProvider, gcpProvider, azurePlan, azureLitePlan, azureProvider, gcpPlan, gcpProvider, trialPlan))
+	cobraCmd.Flags().StringSliceVar(&cmd.platformRegions, "platform-region", nil, "Filter by the platform region of the subaccount, e.g. cf-eu10, as opposed to the provider region of --region. With the table and csv outputs, the PLATFORM REGION column is displayed. You can provide multiple values, either separated by a comma (e.g. cf-eu10,cf-us10), or by specifying the option multiple times.")
+	SetTimeOpt(cobraCmd, &cmd.createdAfter, "created-after", "Display only Runtimes created at or after the given time.")
+	SetTimeOpt(cobraCmd, &cmd.createdBefore, "created-before", "Display only Runtimes created before the given time.")
+	cobraCmd.Flags().StringVar(&cmd.kymaVersion, "kyma-version", "", "Display only Runtimes with the given Kyma version, or with a Kyma version in the given semantic version range (e.g. \"<1.20\", \">=1.19.0, <1.20.0\"). The Runtimes without a known Kyma version are not displayed. With the table and csv outputs, the KYMA VERSION column is displayed.")
+	cobraCmd.Flags().StringVar(&cmd.errorContains, "error-contains", "", "Display only Runtimes whose latest failed operation has a description containing the given text. The match is case-insensitive.")
+	cobraCmd.Flags().BoolVar(&cmd.watchDiff, "watch-diff", false, "After displaying the Runtimes, keep polling Kyma Environment Broker and display only the added, removed, and changed Runtimes with timestamps. Supported only with the table output.")
+	cobraCmd.Flags().BoolVarP(&cmd.watch, "watch", "w", false, fmt.Sprintf("Keep polling Kyma Environment Broker and display the Runtimes after every poll until interrupted. The %s output is refreshed on the screen only if Kyma Environment Broker reports that the Runtimes changed, the %s output displays one document per line for each poll, and the %s output displays the lines of the Runtimes for each poll.", tableOutput, jsonOutput, jsonLinesOutput))
+	cobraCmd.Flags().DurationVar(&cmd.watchInterval, "watch-interval", 10*time.Second, "Interval of polling Kyma Environment Broker in the watch mode.")
+	cobraCmd.Flags().DurationVar(&cmd.timeout, "timeout", runtime.DefaultTimeout, fmt.Sprintf("Time limit of each request to Kyma Environment Broker, e.g. 1m. A request failing with a network error or a 5xx status is retried up to %d times in total, with the wait time doubling after each failure. Zero means no time limit.", runtime.DefaultAttempts))
+	cobraCmd.Flags().Float64Var(&cmd.qps, "qps", 0, "Maximum number of requests per second to Kyma Environment Broker, e.g. 0.5 for one request every two seconds. The limit applies to the pages, retries, and the polls in the watch mode of the command. By default, the requests are not limited.")
+	cobraCmd.Flags().BoolVar(&cmd.count, "count", false, "Display only the number of Runtimes matching the filters instead of the Runtimes. With the json and yaml outputs, the number is displayed as the count field.")
+	cobraCmd.Flags().BoolVar(&cmd.summary, "summary", false, "After displaying the table, display the number of Runtimes in each state, e.g. 42 Runtimes: 38 succeeded, 3 upgrading, 1 failed. The states are displayed without the details in parentheses. The summary is not displayed with the other outputs.")
+	cobraCmd.Flags().BoolVar(&cmd.checksum, "checksum", false, "After displaying the Runtimes, display the SHA-256 checksum of their attributes and operations on the standard error. The checksum does not depend on the order of the Runtimes and ignores volatile attributes, such as the Kyma version or the operation update times.")
+
+	cobraCmd.RegisterFlagCompletionFunc("plan", completePlans)
+	cobraCmd.RegisterFlagCompletionFunc("region", completeRegions)
 
-	return cobraCmd
+	return cmd
 }
 
 // Run executes the runtimes command
 func (cmd *RuntimeCommand) Run() error {
-	cmd.log = logger.New()
-	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+	client := runtime.NewClientWithOptions(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLITokenSource(cmd.log), runtime.ClientOptions{
+		Timeout: cmd.timeout,
+		QPS:     cmd.qps,
+		Log:     cmd.log,
+	})
+	params, _ := cmd.requestParams()
+	cmd.log.V(2).Infof("Listing runtimes with parameters: %v", params.EncodeQuery())
+	cmd.log.V(3).Infof("Requesting %s/runtimes?%s", GlobalOpts.KEBAPIURL(), params.EncodeQuery().Encode())
+	if cmd.watch {
+		return cmd.runWatch(client)
+	}
 
-	rp, err := client.ListRuntimes(cmd.params)
+	rp, err := cmd.listRuntimes(client)
 	if err != nil {
-		return errors.Wrap(err, "while listing runtimes")
+		return err
+	}
+	cmd.log.V(1).Infof("%d runtimes match the filters out of %d listed by Kyma Environment Broker", rp.Count, rp.TotalCount)
+	cmd.cacheCompletionValues(rp.Data)
+	if cmd.count {
+		return cmd.printCount(os.Stdout, len(rp.Data))
+	}
+	if cmd.sortColumn != nil {
+		if err := cmd.sortRuntimes(rp.Data); err != nil {
+			return errors.Wrap(err, "while sorting runtimes")
+		}
 	}
 	err = cmd.printRuntimes(rp)
 	if err != nil {
 		return errors.Wrap(err, "while printing runtimes")
 	}
+	if cmd.summary && cmd.tableDisplayed() {
+		if err := printSummary(os.Stdout, rp.Data); err != nil {
+			return errors.Wrap(err, "while printing summary")
+		}
+	}
+	if cmd.checksum {
+		checksum, err := runtime.Checksum(rp.Data)
+		if err != nil {
+			return errors.Wrap(err, "while computing checksum of runtimes")
+		}
+		// the standard output holds only the runtimes, so it can still be parsed in the json, yaml, jsonl and csv outputs
+		fmt.Fprintf(os.Stderr, "SHA-256: %s\n", checksum)
+	}
+	if cmd.watchDiff {
+		return cmd.runWatchDiff(client, rp)
+	}
 
 	return nil
 }
 
+// requestParams returns the parameters of the request listing the Runtimes, and whether the attribute filters are applied after listing them instead
+func (cmd *RuntimeCommand) requestParams() (runtime.ListParameters, bool) {
+	params := cmd.params
+	matchAnyAttribute := cmd.matchAnyAttribute()
+	if matchAnyAttribute {
+		params = withoutAttributeFilters(params)
+	}
+	// The filters applied after listing drop some of the listed Runtimes, so the number of results is limited after them
+	if cmd.filtersListedRuntimes() {
+		params.MaxResults = 0
+	}
+	return params, matchAnyAttribute
+}
+
+// matchAnyAttribute checks if the attribute filters are applied after listing the Runtimes.
+// KEB combines the attribute filters with AND, so with --match any they are applied after listing all Runtimes.
+func (cmd *RuntimeCommand) matchAnyAttribute() bool {
+	return cmd.match == matchAny && countAttributeFilters(cmd.params) > 1
+}
+
+// filtersListedRuntimes checks if any of the filters is applied to the Runtimes listed by Kyma Environment Broker
+func (cmd *RuntimeCommand) filtersListedRuntimes() bool {
+	return cmd.matchAnyAttribute() ||
+		cmd.haOnly || cmd.nonHA ||
+		cmd.errorContains != "" ||
+		cmd.shootPattern != nil ||
+		len(cmd.states) > 0 ||
+		!cmd.createdAfter.From.IsZero() || !cmd.createdBefore.From.IsZero() ||
+		len(cmd.excludeGlobalAccountIDs) > 0 || len(cmd.excludeRegions) > 0 || len(cmd.excludePlans) > 0 ||
+		len(cmd.providers) > 0 ||
+		len(cmd.platformRegions) > 0 ||
+		cmd.kymaVersion != ""
+}
+
+func (cmd *RuntimeCommand) listRuntimes(client runtime.Client) (runtime.RuntimesPage, error) {
+	params, matchAnyAttribute := cmd.requestParams()
+	rp, err := client.ListRuntimes(params)
+	if err != nil {
+		return runtime.RuntimesPage{}, errors.Wrap(err, "while listing runtimes")
+	}
+	if matchAnyAttribute {
+		rp = cmd.filterByAnyAttribute(rp)
+	}
+	if cmd.haOnly || cmd.nonHA {
+		rp, err = cmd.filterByAvailability(rp)
+		if err != nil {
+			return runtime.RuntimesPage{}, err
+		}
+	}
+	if cmd.errorContains != "" {
+		rp = cmd.filterByErrorContains(rp)
+	}
+	if cmd.shootPattern != nil {
+		rp = cmd.filterByShootRegex(rp)
+	}
+	if len(cmd.states) > 0 {
+		rp = cmd.filterByState(rp)
+	}
+	if !cmd.createdAfter.From.IsZero() || !cmd.createdBefore.From.IsZero() {
+		rp = cmd.filterByCreatedAt(rp)
+	}
+	if len(cmd.excludeGlobalAccountIDs) > 0 || len(cmd.excludeRegions) > 0 || len(cmd.excludePlans) > 0 {
+		rp = cmd.filterByExclusions(rp)
+	}
+	if len(cmd.providers) > 0 {
+		rp = cmd.filterByProvider(rp)
+	}
+	if len(cmd.platformRegions) > 0 {
+		rp = cmd.filterByPlatformRegion(rp)
+	}
+	if cmd.kymaVersion != "" {
+		rp = cmd.filterByKymaVersion(rp)
+	}
+	if cmd.params.MaxResults > 0 && len(rp.Data) > cmd.params.MaxResults {
+		rp.Data = rp.Data[:cmd.params.MaxResults]
+		rp.Count = cmd.params.MaxResults
+	}
+
+	return rp, nil
+}
+
 // Validate checks the input parameters of the runtimes command
 func (cmd *RuntimeCommand) Validate() error {
-	err := ValidateOutputOpt(cmd.output)
+	cmd.log = logger.New()
+	err := cmd.validateOutput()
+	if err != nil {
+		return err
+	}
+	err = cmd.expandFilterFiles()
 	if err != nil {
 		return err
 	}
+	err = cmd.validateColumnOrder()
+	if err != nil {
+		return err
+	}
+	if cmd.haOnly && cmd.nonHA {
+		return errors.New("--ha-only and --non-ha cannot be used together")
+	}
+	if cmd.explainState && cmd.output != tableOutput {
+		return fmt.Errorf("--explain-state can be used only with the %s output", tableOutput)
+	}
+	if cmd.noHeaders && (cmd.output == jsonOutput || cmd.output == jsonLinesOutput || cmd.output == yamlOutput || cmd.output == csvOutput || cmd.templatePrinter != nil || cmd.jsonPathPrinter != nil || cmd.jsonMatchColumns) {
+		return fmt.Errorf("--no-headers can be used only with the %s and %s outputs", tableOutput, customColumnsOutputPrefix)
+	}
+	if err := cmd.validateMaxColumnWidth(); err != nil {
+		return err
+	}
+	if cmd.watchDiff && cmd.output != tableOutput {
+		return fmt.Errorf("--watch-diff can be used only with the %s output", tableOutput)
+	}
+	if cmd.watch && cmd.output != tableOutput && cmd.output != jsonOutput && cmd.output != jsonLinesOutput {
+		return fmt.Errorf("--watch can be used only with the %s, %s, and %s outputs", tableOutput, jsonOutput, jsonLinesOutput)
+	}
+	if cmd.watch && (cmd.watchDiff || cmd.checksum) {
+		return errors.New("--watch cannot be used with --watch-diff or --checksum")
+	}
+	if cmd.count && (cmd.checksum || cmd.watch || cmd.watchDiff || cmd.explainState || cmd.customColumns != nil || cmd.templatePrinter != nil || cmd.jsonPathPrinter != nil) {
+		return errors.New("--count cannot be used with --checksum, --watch, --watch-diff, --explain-state, or the custom-columns, go-template, and jsonpath outputs")
+	}
+	if cmd.summary && (cmd.count || cmd.watch) {
+		return errors.New("--summary cannot be used with --count or --watch")
+	}
+	if cmd.quiet && (cmd.count || cmd.checksum || cmd.watch || cmd.watchDiff || cmd.explainState) {
+		return errors.New("--quiet cannot be used with --count, --checksum, --watch, --watch-diff, or --explain-state")
+	}
+	if cmd.kymaVersion != "" {
+		constraint, err := semver.NewConstraint(cmd.kymaVersion)
+		if err != nil {
+			return fmt.Errorf("invalid value for kyma-version: %s. The value must be a version or a semantic version range, e.g. 1.20.0 or \"<1.20\"", cmd.kymaVersion)
+		}
+		cmd.kymaConstraint = constraint
+	}
+	for i, provider := range cmd.providers {
+		cmd.providers[i] = strings.ToLower(provider)
+		switch cmd.providers[i] {
+		case azureProvider, gcpProvider:
+		default:
+			return fmt.Errorf("invalid value for provider: %s. The possible values are: %s, %s", provider, azureProvider, gcpProvider)
+		}
+	}
+	if !cmd.createdAfter.From.IsZero() && !cmd.createdBefore.From.IsZero() && !cmd.createdAfter.From.Before(cmd.createdBefore.From) {
+		return fmt.Errorf("invalid time window: --created-after %s must be before --created-before %s", cmd.createdAfter.From.Format(time.RFC3339), cmd.createdBefore.From.Format(time.RFC3339))
+	}
+	if cmd.shootRegex != "" {
+		pattern, err := regexp.Compile(cmd.shootRegex)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for shoot-regex: %s", cmd.shootRegex)
+		}
+		cmd.shootPattern = pattern
+	}
+	switch cmd.match {
+	case matchAll, matchAny:
+	default:
+		return fmt.Errorf("invalid value for match: %s. The possible values are: %s, %s", cmd.match, matchAll, matchAny)
+	}
+	switch cmd.color {
+	case colorAuto, colorAlways, colorNever:
+	default:
+		return fmt.Errorf("invalid value for color: %s. The possible values are: %s, %s, %s", cmd.color, colorAuto, colorAlways, colorNever)
+	}
+	if cmd.qps < 0 {
+		return fmt.Errorf("invalid value for qps: %v. The value must not be negative", cmd.qps)
+	}
+	if cmd.timeout < 0 {
+		return fmt.Errorf("invalid value for timeout: %s. The value must not be negative", cmd.timeout)
+	}
+	if cmd.watchInterval <= 0 {
+		return fmt.Errorf("invalid value for watch-interval: %s. The value must be positive", cmd.watchInterval)
+	}
+
+	return cmd.validatePaging()
+}
+
+// validateMaxColumnWidth limits the width of all columns of the table output. The columns are copied, so the shared column definitions are not modified.
+func (cmd *RuntimeCommand) validateMaxColumnWidth() error {
+	if cmd.maxColumnWidth == 0 {
+		return nil
+	}
+	if cmd.maxColumnWidth < 0 {
+		return fmt.Errorf("invalid value for max-column-width: %d. The value must be a positive number", cmd.maxColumnWidth)
+	}
+	if (cmd.output != tableOutput && cmd.customColumns == nil) || cmd.jsonMatchColumns {
+		return fmt.Errorf("--max-column-width can be used only with the %s and %s outputs", tableOutput, customColumnsOutputPrefix)
+	}
+
+	columns := make([]printer.Column, len(cmd.columns))
+	copy(columns, cmd.columns)
+	for i := range columns {
+		columns[i].MaxWidth = cmd.maxColumnWidth
+	}
+	cmd.columns = columns
+	return nil
+}
+
+func (cmd *RuntimeCommand) validateOutput() error {
+	if strings.HasPrefix(cmd.output, templateOutputPrefix) {
+		if cmd.jsonMatchColumns {
+			return fmt.Errorf("--json-match-columns can be used only with the %s output", customColumnsOutputPrefix)
+		}
+		// Fail early on malformed templates, before the Runtimes are fetched
+		tp, err := printer.NewTemplatePrinter(strings.TrimPrefix(cmd.output, templateOutputPrefix))
+		if err != nil {
+			return errors.Wrap(err, "invalid value for output")
+		}
+		cmd.templatePrinter = tp
+		return nil
+	}
+	if strings.HasPrefix(cmd.output, jsonPathOutputPrefix) {
+		if cmd.jsonMatchColumns {
+			return fmt.Errorf("--json-match-columns can be used only with the %s output", customColumnsOutputPrefix)
+		}
+		// Fail early on malformed expressions, before the Runtimes are fetched
+		jp, err := printer.NewJSONPathPrinter(strings.TrimPrefix(cmd.output, jsonPathOutputPrefix))
+		if err != nil {
+			return errors.Wrap(err, "invalid value for output")
+		}
+		cmd.jsonPathPrinter = jp
+		return nil
+	}
+	if !strings.HasPrefix(cmd.output, customColumnsOutputPrefix) {
+		if cmd.jsonMatchColumns {
+			return fmt.Errorf("--json-match-columns can be used only with the %s output", customColumnsOutputPrefix)
+		}
+		// the CSV and JSON Lines outputs are supported only by the commands which print the runtime tables
+		if cmd.output == csvOutput || cmd.output == jsonLinesOutput {
+			return nil
+		}
+		return ValidateOutputOpt(cmd.output)
+	}
+
+	columns, err := printer.ParseCustomColumns(strings.TrimPrefix(cmd.output, customColumnsOutputPrefix))
+	if err != nil {
+		return errors.Wrap(err, "invalid value for output")
+	}
+	if cmd.jsonMatchColumns {
+		// Fail early if the columns cannot be projected
+		if _, err := printer.ProjectColumns([]runtime.RuntimeDTO{}, columns); err != nil {
+			return err
+		}
+	}
+	cmd.customColumns = columns
+
+	return nil
+}
+
+// validateColumnOrder prepares the columns of the table output selected by --columns, in the order given by --column-order,
+// and the column of --sort-by, which is looked up among all columns of the output
+func (cmd *RuntimeCommand) validateColumnOrder() error {
+	columns := tableColumns
+	selectable := append(append([]printer.Column{}, tableColumns...), optionalColumns...)
+	switch {
+	case cmd.customColumns != nil:
+		columns, selectable = cmd.customColumns, cmd.customColumns
+	case cmd.explainState:
+		columns, selectable = explainStateColumns, explainStateColumns
+	}
+	if cmd.showOperationID {
+		if cmd.customColumns != nil || cmd.explainState || len(cmd.selectedColumns) > 0 {
+			return fmt.Errorf("--show-operation-id cannot be used with --columns, --explain-state, or the %s output", customColumnsOutputPrefix)
+		}
+		if cmd.output != tableOutput && cmd.output != csvOutput {
+			return fmt.Errorf("--show-operation-id can be used only with the %s and %s outputs", tableOutput, csvOutput)
+		}
+		columns = append(append([]printer.Column{}, columns...), operationIDColumn)
+	}
+	// The filtered Kyma version and platform region are displayed, unless the columns are chosen explicitly
+	if cmd.customColumns == nil && !cmd.explainState && len(cmd.selectedColumns) == 0 {
+		if cmd.kymaVersion != "" {
+			columns = append(append([]printer.Column{}, columns...), kymaVersionColumn)
+		}
+		if len(cmd.platformRegions) > 0 {
+			columns = append(append([]printer.Column{}, columns...), platformRegionColumn)
+		}
+	}
+	if cmd.sortBy != "" {
+		sortColumns, err := printer.SelectColumns(selectable, []string{cmd.sortBy})
+		if err != nil {
+			return errors.Wrap(err, "invalid value for sort-by")
+		}
+		cmd.sortColumn = &sortColumns[0]
+	}
+	if cmd.sortOrder != sortAscending && cmd.sortOrder != sortDescending {
+		return fmt.Errorf("invalid value for sort-order: %s. The possible values are: %s, %s", cmd.sortOrder, sortAscending, sortDescending)
+	}
+	if len(cmd.selectedColumns) > 0 {
+		if cmd.customColumns != nil {
+			return fmt.Errorf("--columns cannot be used with the %s output", customColumnsOutputPrefix)
+		}
+		if cmd.output == jsonOutput || cmd.output == jsonLinesOutput || cmd.output == yamlOutput || cmd.templatePrinter != nil || cmd.jsonPathPrinter != nil {
+			return fmt.Errorf("--columns can be used only with the %s and %s outputs", tableOutput, csvOutput)
+		}
+		selected, err := printer.SelectColumns(selectable, cmd.selectedColumns)
+		if err != nil {
+			return errors.Wrap(err, "invalid value for columns")
+		}
+		columns = selected
+	}
+	if len(cmd.columnOrder) == 0 {
+		cmd.columns = columns
+		return nil
+	}
+	if cmd.output == jsonOutput || cmd.output == jsonLinesOutput || cmd.output == yamlOutput || cmd.templatePrinter != nil || cmd.jsonPathPrinter != nil || cmd.jsonMatchColumns {
+		return fmt.Errorf("--column-order can be used only with the %s and %s outputs", tableOutput, customColumnsOutputPrefix)
+	}
+
+	ordered, err := printer.ReorderColumns(columns, cmd.columnOrder)
+	if err != nil {
+		return errors.Wrap(err, "invalid value for column-order")
+	}
+	cmd.columns = ordered
+
+	return nil
+}
+
+// sortRuntimes sorts the Runtimes by the values of the --sort-by column, and the Runtimes with the same value by the Runtime ID
+func (cmd *RuntimeCommand) sortRuntimes(runtimes []runtime.RuntimeDTO) error {
+	values, err := printer.ColumnValues(*cmd.sortColumn, runtimes)
+	if err != nil {
+		return err
+	}
+
+	order := make([]int, len(runtimes))
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if values[a] != values[b] {
+			if cmd.sortOrder == sortDescending {
+				return values[a] > values[b]
+			}
+			return values[a] < values[b]
+		}
+		return runtimes[a].RuntimeID < runtimes[b].RuntimeID
+	})
+
+	sorted := make([]runtime.RuntimeDTO, 0, len(runtimes))
+	for _, idx := range order {
+		sorted = append(sorted, runtimes[idx])
+	}
+	copy(runtimes, sorted)
+
 	return nil
 }
 
+// expandFilterFiles replaces the @FILE values of the repeatable filter options with the values read from the files
+func (cmd *RuntimeCommand) expandFilterFiles() error {
+	for _, opt := range []struct {
+		name   string
+		values *[]string
+	}{
+		{"shoot", &cmd.params.Shoots},
+		{"account", &cmd.params.GlobalAccountIDs},
+		{"subaccount", &cmd.params.SubAccountIDs},
+		{"runtime-id", &cmd.params.RuntimeIDs},
+		{"region", &cmd.params.Regions},
+		{"plan", &cmd.params.Plans},
+		{"state", &cmd.states},
+		{"exclude-account", &cmd.excludeGlobalAccountIDs},
+		{"exclude-region", &cmd.excludeRegions},
+		{"exclude-plan", &cmd.excludePlans},
+		{"provider", &cmd.providers},
+		{"platform-region", &cmd.platformRegions},
+	} {
+		values, err := expandFileValues(*opt.values)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for %s", opt.name)
+		}
+		*opt.values = values
+	}
+
+	return nil
+}
+
+// expandFileValues returns the given values with each @FILE value replaced by the lines of the file.
+// The lines are trimmed, and the empty lines and the lines starting with # are ignored.
+func expandFileValues(values []string) ([]string, error) {
+	var expanded []string
+	for _, value := range values {
+		if !strings.HasPrefix(value, "@") {
+			expanded = append(expanded, value)
+			continue
+		}
+		path := strings.TrimPrefix(value, "@")
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading values from %s", path)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			expanded = append(expanded, line)
+		}
+	}
+
+	return expanded, nil
+}
+
+func (cmd *RuntimeCommand) validatePaging() error {
+	if cmd.params.PageSize < 1 || cmd.params.PageSize > maxPageSize {
+		return fmt.Errorf("invalid value for page-size: %d. The value must be between 1 and %d", cmd.params.PageSize, maxPageSize)
+	}
+	if cmd.cobraCmd.Flags().Changed("page") && cmd.params.Page < 1 {
+		return fmt.Errorf("invalid value for page: %d. The value must be at least 1", cmd.params.Page)
+	}
+	if cmd.params.Page > 0 && cmd.cobraCmd.Flags().Changed("max-results") {
+		return errors.New("--page and --max-results cannot be used together")
+	}
+	if cmd.cobraCmd.Flags().Changed("max-results") && cmd.params.MaxResults < 1 {
+		return fmt.Errorf("invalid value for max-results: %d. The value must be at least 1", cmd.params.MaxResults)
+	}
+	// Fetching a page bigger than the number of displayed results is wasteful, unless the filters drop some of the listed Runtimes
+	if cmd.params.MaxResults > 0 && cmd.params.PageSize > cmd.params.MaxResults && !cmd.filtersListedRuntimes() {
+		if cmd.cobraCmd.Flags().Changed("page-size") {
+			cmd.log.Warnf("page-size %d exceeds max-results %d, using page size %d", cmd.params.PageSize, cmd.params.MaxResults, cmd.params.MaxResults)
+		}
+		cmd.params.PageSize = cmd.params.MaxResults
+	}
+
+	return nil
+}
+
+func (cmd *RuntimeCommand) filterByAvailability(runtimes runtime.RuntimesPage) (runtime.RuntimesPage, error) {
+	expected := availabilityHA
+	if cmd.nonHA {
+		expected = availabilityNonHA
+	}
+
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	var unknown []string
+	for _, rt := range runtimes.Data {
+		switch runtimeAvailability(rt) {
+		case availabilityUnknown:
+			unknown = append(unknown, rt.ShootName)
+		case expected:
+			filtered = append(filtered, rt)
+		}
+	}
+	if len(unknown) > 0 {
+		return runtime.RuntimesPage{}, fmt.Errorf("high availability information is not available for Runtimes: %s", strings.Join(unknown, ", "))
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes, nil
+}
+
+// filterByErrorContains keeps the Runtimes whose latest failed operation description contains the text given by --error-contains.
+// The descriptions are part of the listed Runtimes, so no additional requests are needed.
+func (cmd *RuntimeCommand) filterByErrorContains(runtimes runtime.RuntimesPage) runtime.RuntimesPage {
+	text := strings.ToLower(cmd.errorContains)
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		op, found := findLastFailedOperation(rt)
+		if found && strings.Contains(strings.ToLower(op.Description), text) {
+			filtered = append(filtered, rt)
+		}
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes
+}
+
+// filterByShootRegex keeps the Runtimes whose Shoot name matches the regular expression given by --shoot-regex
+func (cmd *RuntimeCommand) filterByShootRegex(runtimes runtime.RuntimesPage) runtime.RuntimesPage {
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		if cmd.shootPattern.MatchString(rt.ShootName) {
+			filtered = append(filtered, rt)
+		}
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes
+}
+
+// filterByState keeps the Runtimes whose state derived from the last operation matches one of the states given by --state.
+// KEB does not support filtering by state, so the filter is applied to the fetched Runtimes.
+func (cmd *RuntimeCommand) filterByState(runtimes runtime.RuntimesPage) runtime.RuntimesPage {
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		status := strings.ToLower(operationStatusToString(findLastOperation(rt)))
+		for _, state := range cmd.states {
+			state = strings.ToLower(strings.TrimSpace(state))
+			if status == state || strings.HasPrefix(status, state+" (") {
+				filtered = append(filtered, rt)
+				break
+			}
+		}
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes
+}
+
+// attributeFilter is a filter applied by KEB together with the attribute of the Runtime compared with its values
+type attributeFilter struct {
+	values    []string
+	attribute string
+}
+
+func attributeFilters(params runtime.ListParameters, rt runtime.RuntimeDTO) []attributeFilter {
+	return []attributeFilter{
+		{params.Shoots, rt.ShootName},
+		{params.GlobalAccountIDs, rt.GlobalAccountID},
+		{params.SubAccountIDs, rt.SubAccountID},
+		{params.InstanceIDs, rt.InstanceID},
+		{params.RuntimeIDs, rt.RuntimeID},
+		{params.Regions, rt.ProviderRegion},
+		{params.Plans, rt.ServicePlanName},
+	}
+}
+
+// countAttributeFilters returns the number of the filters applied by KEB which have any values
+func countAttributeFilters(params runtime.ListParameters) int {
+	count := 0
+	for _, filter := range attributeFilters(params, runtime.RuntimeDTO{}) {
+		if len(filter.values) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// withoutAttributeFilters returns the parameters without the filters applied by KEB, so all Runtimes are listed
+func withoutAttributeFilters(params runtime.ListParameters) runtime.ListParameters {
+	params.Shoots = nil
+	params.GlobalAccountIDs = nil
+	params.SubAccountIDs = nil
+	params.InstanceIDs = nil
+	params.RuntimeIDs = nil
+	params.Regions = nil
+	params.Plans = nil
+	return params
+}
+
+// filterByAnyAttribute keeps the Runtimes which match at least one of the filters given by --shoot, --account, --subaccount, --runtime-id, --region, and --plan
+func (cmd *RuntimeCommand) filterByAnyAttribute(runtimes runtime.RuntimesPage) runtime.RuntimesPage {
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		for _, filter := range attributeFilters(cmd.params, rt) {
+			if len(filter.values) > 0 && matchesAny(filter.attribute, filter.values) {
+				filtered = append(filtered, rt)
+				break
+			}
+		}
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes
+}
+
+// filterByCreatedAt keeps the Runtimes created within the window given by --created-after and --created-before
+func (cmd *RuntimeCommand) filterByCreatedAt(runtimes runtime.RuntimesPage) runtime.RuntimesPage {
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		if !cmd.createdAfter.From.IsZero() && rt.Status.CreatedAt.Before(cmd.createdAfter.From) {
+			continue
+		}
+		if !cmd.createdBefore.From.IsZero() && !rt.Status.CreatedAt.Before(cmd.createdBefore.From) {
+			continue
+		}
+		filtered = append(filtered, rt)
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes
+}
+
+// filterByExclusions drops the Runtimes matching any of the values given by --exclude-account, --exclude-region, or --exclude-plan.
+// The exclusions are applied after the other filters, so a Runtime is displayed only if it matches all other filters and none of the exclusions.
+func (cmd *RuntimeCommand) filterByExclusions(runtimes runtime.RuntimesPage) runtime.RuntimesPage {
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		if containsString(cmd.excludeGlobalAccountIDs, rt.GlobalAccountID) ||
+			containsString(cmd.excludeRegions, rt.ProviderRegion) ||
+			containsString(cmd.excludePlans, rt.ServicePlanName) {
+			continue
+		}
+		filtered = append(filtered, rt)
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes
+}
+
+// filterByProvider keeps the Runtimes whose cloud provider, inferred from the service plan, is one of the --provider values
+func (cmd *RuntimeCommand) filterByProvider(runtimes runtime.RuntimesPage) runtime.RuntimesPage {
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		provider, known := planProviders[rt.ServicePlanName]
+		if known && containsString(cmd.providers, provider) {
+			filtered = append(filtered, rt)
+		}
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes
+}
+
+// filterByPlatformRegion keeps the Runtimes whose subaccount is in one of the --platform-region values
+func (cmd *RuntimeCommand) filterByPlatformRegion(runtimes runtime.RuntimesPage) runtime.RuntimesPage {
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		if containsString(cmd.platformRegions, rt.SubAccountRegion) {
+			filtered = append(filtered, rt)
+		}
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes
+}
+
+// filterByKymaVersion keeps the Runtimes whose Kyma version is equal to the --kyma-version value, or satisfies it as a range
+func (cmd *RuntimeCommand) filterByKymaVersion(runtimes runtime.RuntimesPage) runtime.RuntimesPage {
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes.Data))
+	for _, rt := range runtimes.Data {
+		if rt.KymaVersion == "" {
+			continue
+		}
+		if rt.KymaVersion == cmd.kymaVersion {
+			filtered = append(filtered, rt)
+			continue
+		}
+		version, err := semver.NewVersion(rt.KymaVersion)
+		if err == nil && cmd.kymaConstraint.Check(version) {
+			filtered = append(filtered, rt)
+		}
+	}
+
+	runtimes.Data = filtered
+	runtimes.Count = len(filtered)
+	return runtimes
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// findLastFailedOperation returns the most recently created failed operation of the Runtime
+func findLastFailedOperation(rt runtime.RuntimeDTO) (runtime.Operation, bool) {
+	var ops []runtime.Operation
+	if rt.Status.Provisioning != nil {
+		ops = append(ops, *rt.Status.Provisioning)
+	}
+	if rt.Status.Deprovisioning != nil {
+		ops = append(ops, *rt.Status.Deprovisioning)
+	}
+	ops = append(ops, rt.Status.UpgradingKyma.Data...)
+	ops = append(ops, rt.Status.Suspension.Data...)
+	ops = append(ops, rt.Status.Unsuspension.Data...)
+
+	var last runtime.Operation
+	found := false
+	for _, op := range ops {
+		if op.State != failed {
+			continue
+		}
+		if !found || op.CreatedAt.After(last.CreatedAt) {
+			last = op
+			found = true
+		}
+	}
+
+	return last, found
+}
+
+// runtimeAvailability derives the high availability of a Runtime from the number of zones of its cluster.
+// Kyma Environment Broker versions which do not report the zone count leave it unknown.
+func runtimeAvailability(rt runtime.RuntimeDTO) availability {
+	switch {
+	case rt.ZoneCount > 1:
+		return availabilityHA
+	case rt.ZoneCount == 1:
+		return availabilityNonHA
+	}
+	return availabilityUnknown
+}
+
 func (cmd *RuntimeCommand) printRuntimes(runtimes runtime.RuntimesPage) error {
+	if cmd.quiet {
+		return printShootNames(os.Stdout, runtimes.Data)
+	}
+	if cmd.customColumns != nil {
+		return cmd.printCustomColumns(runtimes)
+	}
+	if cmd.templatePrinter != nil {
+		return cmd.templatePrinter.PrintObj(runtimes.Data)
+	}
+	if cmd.jsonPathPrinter != nil {
+		return cmd.jsonPathPrinter.PrintObj(runtimes)
+	}
+
 	switch cmd.output {
 	case tableOutput:
-		tp, err := printer.NewTablePrinter(tableColumns, false)
+		newTablePrinter := printer.NewTablePrinter
+		if cmd.colorsEnabled() {
+			newTablePrinter = printer.NewColorTablePrinter
+		}
+		tp, err := newTablePrinter(cmd.columns, cmd.noHeaders)
 		if err != nil {
 			return err
 		}
-		return tp.PrintObj(runtimes.Data)
+		if err := tp.PrintObj(runtimes.Data); err != nil {
+			return err
+		}
+		if cmd.params.Page > 0 && !cmd.noHeaders {
+			return printPageFooter(os.Stdout, cmd.params.Page, cmd.params.PageSize, runtimes)
+		}
 	case jsonOutput:
 		jp := printer.NewJSONPrinter("  ")
 		jp.PrintObj(runtimes)
+	case jsonLinesOutput:
+		return printer.NewJSONLinesPrinter().PrintObj(runtimes.Data)
+	case yamlOutput:
+		return printer.NewYAMLPrinter().PrintObj(runtimes)
+	case csvOutput:
+		return printRuntimesCSV(os.Stdout, cmd.columns, runtimes.Data)
 	}
 
 	return nil
 }
 
+// tableDisplayed checks if the Runtimes are displayed in a table, so a summary can follow it
+func (cmd *RuntimeCommand) tableDisplayed() bool {
+	if cmd.quiet || cmd.templatePrinter != nil || cmd.jsonPathPrinter != nil {
+		return false
+	}
+	if cmd.customColumns != nil {
+		return !cmd.jsonMatchColumns
+	}
+	return cmd.output == tableOutput
+}
+
+// stateCount is the number of Runtimes in a state
+type stateCount struct {
+	state string
+	count int
+}
+
+// summarizeStates counts the Runtimes by the state derived from their last operation, without the details in parentheses, e.g. failed instead of failed (upgradeKyma).
+// The states are ordered from the most frequent one, the states with the same count are ordered by name.
+func summarizeStates(runtimes []runtime.RuntimeDTO) []stateCount {
+	counts := make(map[string]int)
+	for _, rt := range runtimes {
+		state := operationStatusToString(findLastOperation(rt))
+		if idx := strings.Index(state, " ("); idx >= 0 {
+			state = state[:idx]
+		}
+		counts[state]++
+	}
+
+	summary := make([]stateCount, 0, len(counts))
+	for state, count := range counts {
+		summary = append(summary, stateCount{state: state, count: count})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].count != summary[j].count {
+			return summary[i].count > summary[j].count
+		}
+		return summary[i].state < summary[j].state
+	})
+
+	return summary
+}
+
+// printSummary displays the number of Runtimes in each state in one line, e.g. 42 Runtimes: 38 succeeded, 3 upgrading, 1 failed
+func printSummary(w io.Writer, runtimes []runtime.RuntimeDTO) error {
+	noun := "Runtimes"
+	if len(runtimes) == 1 {
+		noun = "Runtime"
+	}
+	counts := make([]string, 0)
+	for _, sc := range summarizeStates(runtimes) {
+		counts = append(counts, fmt.Sprintf("%d %s", sc.count, sc.state))
+	}
+
+	line := fmt.Sprintf("%d %s", len(runtimes), noun)
+	if len(counts) > 0 {
+		line += ": " + strings.Join(counts, ", ")
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", line)
+	return err
+}
+
+// colorsEnabled checks if the table output is colorized, by default only when the standard output is a terminal
+func (cmd *RuntimeCommand) colorsEnabled() bool {
+	switch cmd.color {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// printCount displays the number of Runtimes matching the filters, which is computed after the client-side filters are applied
+func (cmd *RuntimeCommand) printCount(w io.Writer, count int) error {
+	switch cmd.output {
+	case jsonOutput, jsonLinesOutput:
+		return json.NewEncoder(w).Encode(map[string]int{"count": count})
+	case yamlOutput:
+		return printer.NewYAMLPrinterTo(w).PrintObj(map[string]int{"count": count})
+	}
+	_, err := fmt.Fprintln(w, count)
+	return err
+}
+
+// printPageFooter displays the position of the fetched page. The total count is reported by KEB,
+// so it does not account for the client-side filters, such as --state or --error-contains.
+func printPageFooter(w io.Writer, page, pageSize int, runtimes runtime.RuntimesPage) error {
+	pages := (runtimes.TotalCount + pageSize - 1) / pageSize
+	_, err := fmt.Fprintf(w, "\nPage %d of %d, displayed %d of %d Runtimes in total\n", page, pages, len(runtimes.Data), runtimes.TotalCount)
+	return err
+}
+
+// printShootNames displays only the Shoot name of each Runtime in a separate line, so the output can be passed to other commands
+func printShootNames(w io.Writer, runtimes []runtime.RuntimeDTO) error {
+	for _, rt := range runtimes {
+		if _, err := fmt.Fprintln(w, rt.ShootName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printRuntimesCSV(w io.Writer, columns []printer.Column, runtimes []runtime.RuntimeDTO) error {
+	cp, err := printer.NewCSVPrinterTo(w, columns)
+	if err != nil {
+		return err
+	}
+	return cp.PrintObj(runtimes)
+}
+
+func (cmd *RuntimeCommand) printCustomColumns(runtimes runtime.RuntimesPage) error {
+	if !cmd.jsonMatchColumns {
+		tp, err := printer.NewTablePrinter(cmd.columns, cmd.noHeaders)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(runtimes.Data)
+	}
+
+	data, err := printer.ProjectColumns(runtimes.Data, cmd.customColumns)
+	if err != nil {
+		return err
+	}
+	jp := printer.NewJSONPrinter("  ")
+	return jp.PrintObj(map[string]interface{}{
+		"data":       data,
+		"count":      runtimes.Count,
+		"totalCount": runtimes.TotalCount,
+	})
+}
+
 func runtimeStatus(obj interface{}) string {
 	rt := obj.(runtime.RuntimeDTO)
 	return operationStatusToString(findLastOperation(rt))
 }
 
 func findLastOperation(rt runtime.RuntimeDTO) (runtime.Operation, operationType) {
+	op, opType, _ := explainLastOperation(rt)
+	return op, opType
+}
+
+// explainLastOperation returns the operation from which the state of the Runtime is derived, and the reason why it was chosen
+func explainLastOperation(rt runtime.RuntimeDTO) (runtime.Operation, operationType, string) {
 	op := *rt.Status.Provisioning
 	opType := provision
+	reason := "no upgrade, suspension, unsuspension, or deprovisioning operation overrides the provisioning"
 	// Take the first upgrade operation, assuming that Data is sorted by CreatedAt DESC.
 	if rt.Status.UpgradingKyma.Count > 0 {
 		op = rt.Status.UpgradingKyma.Data[0]
 		opType = upgradeKyma
+		reason = "the latest kyma upgrade overrides the provisioning"
 	}
 
 	// Take the first unsuspension operation, assuming that Data is sorted by CreatedAt DESC.
 	if rt.Status.Unsuspension.Count > 0 && rt.Status.Unsuspension.Data[0].CreatedAt.After(op.CreatedAt) {
+		reason = fmt.Sprintf("the latest unsuspension was created after the %s", opType)
 		op = rt.Status.Unsuspension.Data[0]
 		opType = unsuspension
 	}
 
 	// Take the first suspension operation, assuming that Data is sorted by CreatedAt DESC.
 	if rt.Status.Suspension.Count > 0 && rt.Status.Suspension.Data[0].CreatedAt.After(op.CreatedAt) {
+		reason = fmt.Sprintf("the latest suspension was created after the %s", opType)
 		op = rt.Status.Suspension.Data[0]
 		opType = suspension
 	}
 
 	if rt.Status.Deprovisioning != nil && rt.Status.Deprovisioning.CreatedAt.After(op.CreatedAt) {
+		reason = fmt.Sprintf("the deprovisioning was created after the %s", opType)
 		op = *rt.Status.Deprovisioning
 		opType = deprovision
 	}
 
-	return op, opType
+	return op, opType, reason
+}
+
+func lastOperationType(obj interface{}) string {
+	_, opType, _ := explainLastOperation(obj.(runtime.RuntimeDTO))
+	return string(opType)
+}
+
+func lastOperationState(obj interface{}) string {
+	op, _, _ := explainLastOperation(obj.(runtime.RuntimeDTO))
+	return op.State
+}
+
+func lastOperationReason(obj interface{}) string {
+	_, _, reason := explainLastOperation(obj.(runtime.RuntimeDTO))
+	return reason
 }
 
 func operationStatusToString(op runtime.Operation, t operationType) string {
@@ -181,6 +1324,8 @@ func operationStatusToString(op runtime.Operation, t operationType) string {
 		return "succeeded"
 	case failed:
 		return fmt.Sprintf("%s (%s)", "failed", t)
+	case canceled:
+		return fmt.Sprintf("%s (%s)", "canceled", t)
 	case inProgress:
 		switch t {
 		case provision:
@@ -199,6 +1344,48 @@ func operationStatusToString(op runtime.Operation, t operationType) string {
 	return "succeeded"
 }
 
+// runtimeStatusColor highlights the failed Runtimes in red, the Runtimes with an operation in progress in yellow, and the successfully provisioned ones in green
+func runtimeStatusColor(status string) printer.Color {
+	switch {
+	case strings.HasPrefix(status, "failed"):
+		return printer.ColorRed
+	case strings.HasPrefix(status, "provisioning"), strings.HasPrefix(status, "deprovisioning"), status == "upgrading":
+		return printer.ColorYellow
+	case status == "succeeded", status == "provisioned":
+		return printer.ColorGreen
+	}
+	return printer.ColorDefault
+}
+
+func runtimeLastOperationID(obj interface{}) string {
+	op, _ := findLastOperation(obj.(runtime.RuntimeDTO))
+	return op.OperationID
+}
+
+func runtimeLastOperationType(obj interface{}) string {
+	_, opType := findLastOperation(obj.(runtime.RuntimeDTO))
+	return string(opType)
+}
+
+func runtimeLastOperationDuration(obj interface{}) string {
+	op, _ := findLastOperation(obj.(runtime.RuntimeDTO))
+	return elapsedDuration(op, time.Now())
+}
+
+// elapsedDuration returns how long the finished operation took, or how long the operation in progress has been running until now.
+// The duration is empty if the operation does not have the needed timestamps.
+func elapsedDuration(op runtime.Operation, now time.Time) string {
+	end := now
+	switch op.State {
+	case succeeded, failed, canceled:
+		end = op.UpdatedAt
+	}
+	if op.CreatedAt.IsZero() || end.IsZero() || end.Before(op.CreatedAt) {
+		return ""
+	}
+	return end.Sub(op.CreatedAt).Round(time.Second).String()
+}
+
 func runtimeCreatedAt(obj interface{}) string {
 	rt := obj.(runtime.RuntimeDTO)
 	return rt.Status.CreatedAt.Format("2006/01/02 15:04:05")
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/spf13/cobra"
+)
+
+// completionCacheFile is the file in the config directory holding the values suggested by the shell completion of the runtimes command
+const completionCacheFile = "completion.json"
+
+// completionCache holds the distinct values of the Runtime attributes displayed by the previous runs of the runtimes command.
+// The shell completion reads the values from the file instead of calling Kyma Environment Broker, so that it never waits for the login or the network.
+type completionCache struct {
+	Plans   []string `json:"plans"`
+	Regions []string `json:"regions"`
+}
+
+// completionCachePath returns the path of the completion cache in the config directory, it is empty if the home directory is unknown
+func completionCachePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, configDir, completionCacheFile)
+}
+
+// loadCompletionCache reads the completion cache, an empty cache is returned if the file does not exist or cannot be read
+func loadCompletionCache(path string) completionCache {
+	cache := completionCache{}
+	if path == "" {
+		return cache
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return completionCache{}
+	}
+	return cache
+}
+
+// saveCompletionCache adds the plans and regions of the given Runtimes to the completion cache, keeping the values stored before
+func saveCompletionCache(path string, runtimes []runtime.RuntimeDTO) error {
+	cache := loadCompletionCache(path)
+	for _, rt := range runtimes {
+		cache.Plans = append(cache.Plans, rt.ServicePlanName)
+		cache.Regions = append(cache.Regions, rt.ProviderRegion)
+	}
+	cache.Plans = distinctValues(cache.Plans)
+	cache.Regions = distinctValues(cache.Regions)
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// cacheCompletionValues stores the plans and regions of the listed Runtimes for the shell completion, the command does not fail if they cannot be stored
+func (cmd *RuntimeCommand) cacheCompletionValues(runtimes []runtime.RuntimeDTO) {
+	path := completionCachePath()
+	if path == "" {
+		return
+	}
+	if err := saveCompletionCache(path, runtimes); err != nil {
+		cmd.log.Debugf("while saving the completion cache %s: %s", path, err)
+	}
+}
+
+// distinctValues returns the sorted non-empty values without duplicates
+func distinctValues(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	distinct := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		distinct = append(distinct, value)
+	}
+	sort.Strings(distinct)
+	return distinct
+}
+
+// completePlans suggests the values of the --plan option, which are the known plans and the plans of the previously displayed Runtimes
+func completePlans(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	plans := []string{trialPlan}
+	for plan := range planProviders {
+		plans = append(plans, plan)
+	}
+	plans = append(plans, loadCompletionCache(completionCachePath()).Plans...)
+	return completionCandidates(plans, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRegions suggests the values of the --region option, which are the regions of the previously displayed Runtimes
+func completeRegions(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	regions := loadCompletionCache(completionCachePath()).Regions
+	return completionCandidates(regions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionCandidates returns the distinct values starting with the completed part of the last value of the comma-separated list,
+// each prefixed with the values before it, e.g. azure,tr completes to azure,trial. The values already given in the list are not suggested again.
+func completionCandidates(values []string, toComplete string) []string {
+	prefix, last := "", toComplete
+	given := make(map[string]bool)
+	if idx := strings.LastIndex(toComplete, ","); idx >= 0 {
+		prefix, last = toComplete[:idx+1], toComplete[idx+1:]
+		for _, value := range strings.Split(toComplete[:idx], ",") {
+			given[value] = true
+		}
+	}
+
+	candidates := make([]string, 0, len(values))
+	for _, value := range distinctValues(values) {
+		if strings.HasPrefix(value, last) && !given[value] {
+			candidates = append(candidates, prefix+value)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionCache(t *testing.T) {
+	// given
+	dir, err := ioutil.TempDir("", "kcp-completion")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, configDir, completionCacheFile)
+
+	// when
+	err = saveCompletionCache(path, []runtime.RuntimeDTO{
+		{ServicePlanName: "azure", ProviderRegion: "westeurope"},
+		{ServicePlanName: "trial", ProviderRegion: "westeurope"},
+	})
+	require.NoError(t, err)
+	err = saveCompletionCache(path, []runtime.RuntimeDTO{
+		{ServicePlanName: "gcp", ProviderRegion: "europe-west4"},
+		{ServicePlanName: "azure"},
+	})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, completionCache{
+		Plans:   []string{"azure", "gcp", "trial"},
+		Regions: []string{"europe-west4", "westeurope"},
+	}, loadCompletionCache(path))
+}
+
+func TestLoadCompletionCache_Missing(t *testing.T) {
+	// when
+	cache := loadCompletionCache(filepath.Join(os.TempDir(), "kcp-missing", completionCacheFile))
+
+	// then
+	assert.Empty(t, cache.Plans)
+	assert.Empty(t, cache.Regions)
+}
+
+func TestCompleteRegions(t *testing.T) {
+	// given
+	home, err := ioutil.TempDir("", "kcp-home")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	require.NoError(t, os.Setenv("HOME", home))
+
+	t.Run("should suggest nothing without the cache", func(t *testing.T) {
+		// when
+		candidates, directive := completeRegions(nil, nil, "")
+
+		// then
+		assert.Empty(t, candidates)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+
+	t.Run("should suggest the cached regions", func(t *testing.T) {
+		// given
+		err := saveCompletionCache(completionCachePath(), []runtime.RuntimeDTO{
+			{ProviderRegion: "westeurope"},
+			{ProviderRegion: "northeurope"},
+			{ProviderRegion: "eastus"},
+		})
+		require.NoError(t, err)
+
+		// when
+		candidates, directive := completeRegions(nil, nil, "westeurope,")
+
+		// then
+		assert.Equal(t, []string{"westeurope,eastus", "westeurope,northeurope"}, candidates)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+}
+
+func TestCompletionCandidates(t *testing.T) {
+	values := []string{"trial", "azure_lite", "azure", "gcp", "azure"}
+
+	for name, tc := range map[string]struct {
+		toComplete string
+		expected   []string
+	}{
+		"all values": {
+			toComplete: "",
+			expected:   []string{"azure", "azure_lite", "gcp", "trial"},
+		},
+		"prefix": {
+			toComplete: "az",
+			expected:   []string{"azure", "azure_lite"},
+		},
+		"last value of list": {
+			toComplete: "gcp,tr",
+			expected:   []string{"gcp,trial"},
+		},
+		"given values are skipped": {
+			toComplete: "azure,az",
+			expected:   []string{"azure,azure_lite"},
+		},
+		"no match": {
+			toComplete: "aws",
+			expected:   []string{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			candidates := completionCandidates(values, tc.toComplete)
+
+			// then
+			assert.Equal(t, tc.expected, candidates)
+		})
+	}
+}
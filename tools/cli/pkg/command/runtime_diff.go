@@ -0,0 +1,288 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RuntimeDiffCommand represents an execution of the kcp runtimes diff command
+type RuntimeDiffCommand struct {
+	cobraCmd  *cobra.Command
+	log       logger.Logger
+	snapshot  string
+	allFields bool
+	params    runtime.ListParameters
+}
+
+type runtimeChangeKind string
+
+const (
+	runtimeAdded   runtimeChangeKind = "+"
+	runtimeRemoved runtimeChangeKind = "-"
+	runtimeChanged runtimeChangeKind = "~"
+)
+
+// missingField is displayed instead of the value of a field which does not exist in one of the compared Runtimes
+const missingField = "<none>"
+
+// runtimeDiff describes the difference of a single Runtime between the snapshot and the current state
+type runtimeDiff struct {
+	kind    runtimeChangeKind
+	runtime runtime.RuntimeDTO
+	// fields contains the flattened fields of the current Runtime, or of the snapshot one if the Runtime was removed
+	fields map[string]string
+	// changes contains the flattened fields whose values differ, mapped to the value from the snapshot
+	changes map[string]string
+}
+
+// NewRuntimeDiffCmd constructs a new instance of RuntimeDiffCommand and configures it in terms of a cobra.Command
+func NewRuntimeDiffCmd() *cobra.Command {
+	cmd := RuntimeDiffCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "diff --snapshot {FILE}",
+		Short: "Displays changes of Kyma Runtimes compared to a snapshot.",
+		Long: `Compares the current Kyma Runtimes with a snapshot saved earlier using the "kcp runtimes -o json" command.
+Added, removed, and changed Runtimes are displayed together with the changed fields. Use the --all-fields option to display also the unchanged fields of the changed Runtimes.`,
+		Example: `  kcp runtimes -o json > snapshot.json                    Save the current state of all Runtimes.
+  kcp runtimes diff --snapshot snapshot.json               Display the Runtimes which changed since the snapshot was saved.
+  kcp runtimes diff --snapshot snapshot.json --all-fields  Display the changed Runtimes together with their unchanged fields.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cobraCmd.Flags().StringVarP(&cmd.snapshot, "snapshot", "f", "", "Path to the file with the Runtimes snapshot in the JSON format.")
+	cobraCmd.Flags().BoolVar(&cmd.allFields, "all-fields", false, "Display all fields of the changed Runtimes, highlighting the changed ones.")
+	cobraCmd.Flags().StringSliceVarP(&cmd.params.Shoots, "shoot", "c", nil, "Filter by Shoot cluster name. You can provide multiple values, either separated by a comma (e.g. shoot1,shoot2), or by specifying the option multiple times.")
+	cobraCmd.Flags().StringSliceVarP(&cmd.params.GlobalAccountIDs, "account", "g", nil, "Filter by global account ID. You can provide multiple values, either separated by a comma (e.g. GAID1,GAID2), or by specifying the option multiple times.")
+
+	return cobraCmd
+}
+
+// Run executes the runtimes diff command
+func (cmd *RuntimeDiffCommand) Run() error {
+	cmd.log = logger.New()
+	snapshot, err := readRuntimesSnapshot(cmd.snapshot)
+	if err != nil {
+		return err
+	}
+
+	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLITokenSource(cmd.log))
+	rp, err := client.ListRuntimes(cmd.params)
+	if err != nil {
+		return errors.Wrap(err, "while listing runtimes")
+	}
+
+	diffs, err := diffRuntimes(filterSnapshot(snapshot.Data, cmd.params), rp.Data)
+	if err != nil {
+		return errors.Wrap(err, "while comparing runtimes")
+	}
+	writeRuntimeDiffs(os.Stdout, diffs, cmd.allFields)
+
+	return nil
+}
+
+// Validate checks the input parameters of the runtimes diff command
+func (cmd *RuntimeDiffCommand) Validate() error {
+	if cmd.snapshot == "" {
+		return errors.New("missing required option: --snapshot")
+	}
+
+	return nil
+}
+
+func readRuntimesSnapshot(path string) (runtime.RuntimesPage, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return runtime.RuntimesPage{}, errors.Wrap(err, "while reading snapshot")
+	}
+	var rp runtime.RuntimesPage
+	err = json.Unmarshal(data, &rp)
+	if err != nil {
+		return runtime.RuntimesPage{}, errors.Wrapf(err, "while decoding snapshot %s", path)
+	}
+
+	return rp, nil
+}
+
+// filterSnapshot applies the filters of the command to the snapshot, so Runtimes which were not requested are not reported as removed
+func filterSnapshot(runtimes []runtime.RuntimeDTO, params runtime.ListParameters) []runtime.RuntimeDTO {
+	filtered := make([]runtime.RuntimeDTO, 0, len(runtimes))
+	for _, rt := range runtimes {
+		if matchesAny(rt.ShootName, params.Shoots) && matchesAny(rt.GlobalAccountID, params.GlobalAccountIDs) {
+			filtered = append(filtered, rt)
+		}
+	}
+
+	return filtered
+}
+
+func matchesAny(value string, values []string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffRuntimes compares the Runtimes by the instance ID. Unchanged Runtimes are not returned.
+func diffRuntimes(snapshot, current []runtime.RuntimeDTO) ([]runtimeDiff, error) {
+	previous := make(map[string]runtime.RuntimeDTO, len(snapshot))
+	for _, rt := range snapshot {
+		previous[rt.InstanceID] = rt
+	}
+
+	var diffs []runtimeDiff
+	seen := make(map[string]struct{}, len(current))
+	for _, rt := range current {
+		seen[rt.InstanceID] = struct{}{}
+		fields, err := flattenRuntime(rt)
+		if err != nil {
+			return nil, err
+		}
+		old, found := previous[rt.InstanceID]
+		if !found {
+			diffs = append(diffs, runtimeDiff{kind: runtimeAdded, runtime: rt, fields: fields})
+			continue
+		}
+		oldFields, err := flattenRuntime(old)
+		if err != nil {
+			return nil, err
+		}
+		changes := changedFields(oldFields, fields)
+		if len(changes) > 0 {
+			diffs = append(diffs, runtimeDiff{kind: runtimeChanged, runtime: rt, fields: fields, changes: changes})
+		}
+	}
+	for _, rt := range snapshot {
+		if _, found := seen[rt.InstanceID]; found {
+			continue
+		}
+		fields, err := flattenRuntime(rt)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, runtimeDiff{kind: runtimeRemoved, runtime: rt, fields: fields})
+	}
+
+	return diffs, nil
+}
+
+func changedFields(old, current map[string]string) map[string]string {
+	changes := make(map[string]string)
+	for path, value := range current {
+		oldValue, found := old[path]
+		if !found {
+			changes[path] = missingField
+			continue
+		}
+		if oldValue != value {
+			changes[path] = oldValue
+		}
+	}
+	for path, oldValue := range old {
+		if _, found := current[path]; !found {
+			changes[path] = oldValue
+		}
+	}
+
+	return changes
+}
+
+// flattenRuntime returns the JSON representation of the Runtime as a map of field paths, e.g. status.provisioning.state, to values
+func flattenRuntime(rt runtime.RuntimeDTO) (map[string]string, error) {
+	data, err := json.Marshal(rt)
+	if err != nil {
+		return nil, err
+	}
+	var obj interface{}
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	flatten("", obj, fields)
+	return fields, nil
+}
+
+func flatten(path string, value interface{}, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			fields[path] = "{}"
+		}
+		for key, nested := range v {
+			if path == "" {
+				flatten(key, nested, fields)
+			} else {
+				flatten(path+"."+key, nested, fields)
+			}
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			fields[path] = "[]"
+		}
+		for i, nested := range v {
+			flatten(fmt.Sprintf("%s[%d]", path, i), nested, fields)
+		}
+	default:
+		data, _ := json.Marshal(v)
+		fields[path] = string(data)
+	}
+}
+
+// writeRuntimeDiffs prints the changed fields of the Runtimes. With allFields, also the unchanged fields of changed Runtimes are printed.
+func writeRuntimeDiffs(w io.Writer, diffs []runtimeDiff, allFields bool) {
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "No changes found.")
+		return
+	}
+
+	for _, d := range diffs {
+		fmt.Fprintf(w, "%s %s (%s)\n", d.kind, d.runtime.ShootName, d.runtime.InstanceID)
+		if d.kind != runtimeChanged {
+			continue
+		}
+
+		paths := make([]string, 0, len(d.fields))
+		for path := range d.fields {
+			if _, changed := d.changes[path]; allFields || changed {
+				paths = append(paths, path)
+			}
+		}
+		// Fields removed from the current Runtime exist only in the changes
+		for path := range d.changes {
+			if _, found := d.fields[path]; !found {
+				paths = append(paths, path)
+			}
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			value, found := d.fields[path]
+			if !found {
+				value = missingField
+			}
+			if old, changed := d.changes[path]; changed {
+				fmt.Fprintf(w, "  * %s: %s -> %s\n", path, old, value)
+				continue
+			}
+			fmt.Fprintf(w, "    %s: %s\n", path, value)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRuntimes(t *testing.T) {
+	// given
+	unchanged := fixDiffRuntime("unchanged", succeeded)
+	removed := fixDiffRuntime("removed", succeeded)
+	added := fixDiffRuntime("added", inProgress)
+	changedOld := fixDiffRuntime("changed", inProgress)
+	changed := fixDiffRuntime("changed", succeeded)
+
+	// when
+	diffs, err := diffRuntimes([]runtime.RuntimeDTO{unchanged, removed, changedOld}, []runtime.RuntimeDTO{unchanged, added, changed})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, diffs, 3)
+	assert.Equal(t, runtimeAdded, diffs[0].kind)
+	assert.Equal(t, added.InstanceID, diffs[0].runtime.InstanceID)
+	assert.Equal(t, runtimeChanged, diffs[1].kind)
+	assert.Equal(t, changed.InstanceID, diffs[1].runtime.InstanceID)
+	assert.Equal(t, map[string]string{"status.provisioning.state": `"in progress"`}, diffs[1].changes)
+	assert.Equal(t, runtimeRemoved, diffs[2].kind)
+	assert.Equal(t, removed.InstanceID, diffs[2].runtime.InstanceID)
+}
+
+func TestWriteRuntimeDiffs(t *testing.T) {
+	diffs, err := diffRuntimes([]runtime.RuntimeDTO{fixDiffRuntime("changed", inProgress)}, []runtime.RuntimeDTO{fixDiffRuntime("changed", succeeded)})
+	require.NoError(t, err)
+
+	t.Run("concise mode should print only changed fields", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+
+		// when
+		writeRuntimeDiffs(buf, diffs, false)
+
+		// then
+		assert.Equal(t, "~ shoot-changed (changed)\n  * status.provisioning.state: \"in progress\" -> \"succeeded\"\n", buf.String())
+	})
+
+	t.Run("should print also unchanged fields with all fields", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+
+		// when
+		writeRuntimeDiffs(buf, diffs, true)
+
+		// then
+		assert.Contains(t, buf.String(), "  * status.provisioning.state: \"in progress\" -> \"succeeded\"\n")
+		assert.Contains(t, buf.String(), "    shootName: \"shoot-changed\"\n")
+		assert.Contains(t, buf.String(), "    globalAccountID: \"ga\"\n")
+	})
+
+	t.Run("should report no changes", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+
+		// when
+		writeRuntimeDiffs(buf, nil, true)
+
+		// then
+		assert.Equal(t, "No changes found.\n", buf.String())
+	})
+}
+
+func TestFilterSnapshot(t *testing.T) {
+	// given
+	rt1 := runtime.RuntimeDTO{ShootName: "shoot1", GlobalAccountID: "ga1"}
+	rt2 := runtime.RuntimeDTO{ShootName: "shoot2", GlobalAccountID: "ga1"}
+	rt3 := runtime.RuntimeDTO{ShootName: "shoot3", GlobalAccountID: "ga2"}
+
+	// when
+	filtered := filterSnapshot([]runtime.RuntimeDTO{rt1, rt2, rt3}, runtime.ListParameters{GlobalAccountIDs: []string{"ga1"}, Shoots: []string{"shoot2", "shoot3"}})
+
+	// then
+	assert.Equal(t, []runtime.RuntimeDTO{rt2}, filtered)
+}
+
+func fixDiffRuntime(id, state string) runtime.RuntimeDTO {
+	return runtime.RuntimeDTO{
+		InstanceID:      id,
+		GlobalAccountID: "ga",
+		ShootName:       "shoot-" + id,
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{
+				State: state,
+			},
+		},
+	}
+}
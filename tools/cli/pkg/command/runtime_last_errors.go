@@ -0,0 +1,97 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RuntimeLastErrorsCommand represents an execution of the kcp runtimes last-errors command
+type RuntimeLastErrorsCommand struct {
+	cobraCmd *cobra.Command
+	log      logger.Logger
+	output   string
+	top      int
+}
+
+var lastErrorsTableColumns = []printer.Column{
+	{
+		Header:    "COUNT",
+		FieldSpec: "{.Count}",
+	},
+	{
+		Header:    "MESSAGE",
+		FieldSpec: "{.Message}",
+	},
+	{
+		Header:         "EXAMPLE RUNTIME IDS",
+		FieldFormatter: failureGroupRuntimeIDs,
+	},
+}
+
+// NewRuntimeLastErrorsCmd constructs a new instance of RuntimeLastErrorsCommand and configures it in terms of a cobra.Command
+func NewRuntimeLastErrorsCmd() *cobra.Command {
+	cmd := RuntimeLastErrorsCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "last-errors",
+		Short: "Displays the most frequent failures of Runtime operations.",
+		Long: `Displays the most frequent failure messages of Runtime operations across all Runtimes, together with the number of failed operations and example Runtime IDs.
+Messages which differ only by identifiers or numbers are grouped together.`,
+		Example: `  kcp runtimes last-errors           Display the 10 most frequent failure messages.
+  kcp runtimes last-errors --top 3   Display the 3 most frequent failure messages.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().IntVarP(&cmd.top, "top", "n", 10, "Number of the most frequent failure messages to display.")
+
+	return cobraCmd
+}
+
+// Run executes the runtimes last-errors command
+func (cmd *RuntimeLastErrorsCommand) Run() error {
+	cmd.log = logger.New()
+	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLITokenSource(cmd.log))
+
+	groups, err := client.ListFailureGroups(cmd.top)
+	if err != nil {
+		return errors.Wrap(err, "while listing failures")
+	}
+
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinter(lastErrorsTableColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(groups)
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(groups)
+	case yamlOutput:
+		return printer.NewYAMLPrinter().PrintObj(groups)
+	}
+
+	return nil
+}
+
+// Validate checks the input parameters of the runtimes last-errors command
+func (cmd *RuntimeLastErrorsCommand) Validate() error {
+	if cmd.top < 1 {
+		return fmt.Errorf("invalid value for top: %d. The value must be at least 1", cmd.top)
+	}
+
+	return ValidateOutputOpt(cmd.output)
+}
+
+func failureGroupRuntimeIDs(obj interface{}) string {
+	group := obj.(runtime.FailureGroupDTO)
+	return strings.Join(group.RuntimeIDs, ", ")
+}
@@ -0,0 +1,277 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RuntimeOperationCommand represents an execution of the kcp runtimes operation command
+type RuntimeOperationCommand struct {
+	cobraCmd  *cobra.Command
+	log       logger.Logger
+	output    string
+	runtimeID string
+	artifacts bool
+	download  string
+}
+
+var runtimeOperationColumns = []printer.Column{
+	{
+		Header:    "TYPE",
+		FieldSpec: "{.Type}",
+	},
+	{
+		Header:    "STATE",
+		FieldSpec: "{.State}",
+	},
+	{
+		Header:         "CREATED",
+		FieldFormatter: operationCreatedAt,
+	},
+	{
+		Header:         "UPDATED",
+		FieldFormatter: operationUpdatedAt,
+	},
+	{
+		Header:         "DURATION",
+		FieldFormatter: operationDuration,
+	},
+	{
+		Header:         "ERROR",
+		FieldFormatter: operationError,
+	},
+}
+
+var operationArtifactColumns = []printer.Column{
+	{
+		Header:    "OPERATION ID",
+		FieldSpec: "{.OperationID}",
+	},
+	{
+		Header:    "NAME",
+		FieldSpec: "{.Name}",
+	},
+	{
+		Header:    "SIZE",
+		FieldSpec: "{.Size}",
+	},
+	{
+		Header:         "CREATED",
+		FieldFormatter: artifactCreatedAt,
+	},
+}
+
+// NewRuntimeOperationCmd constructs a new instance of RuntimeOperationCommand and configures it in terms of a cobra.Command
+func NewRuntimeOperationCmd() *cobra.Command {
+	cmd := RuntimeOperationCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "operation {RUNTIME ID}",
+		Aliases: []string{"operations", "ops"},
+		Short:   "Displays the operation history of a Runtime.",
+		Long: `Displays all operations of a Runtime in chronological order, together with their type, state, timestamps, duration, and the error of the failed ones.
+Use the CSV output to attach the operation history to a ticket.
+Use the --artifacts flag to list the artifacts attached to the operations, such as the logs of the failed steps, and the --download flag to print the content of an artifact.`,
+		Example: `  kcp runtimes operation 2a75a63a-1f8c-4c59-a54a-b4c4d3e4f1e6          Display the operations of the given Runtime.
+  kcp runtimes operation 2a75a63a-1f8c-4c59-a54a-b4c4d3e4f1e6 -o csv   Display the operations of the given Runtime in the CSV format.
+  kcp runtimes operation 2a75a63a-1f8c-4c59-a54a-b4c4d3e4f1e6 --artifacts
+                                                                  Display the artifacts attached to the operations of the given Runtime.
+  kcp runtimes operation 2a75a63a-1f8c-4c59-a54a-b4c4d3e4f1e6 --download 8a7bfd9b-f2f5-43d1-bb67-177d2434053c/Create_Runtime.log > step.log
+                                                                  Save the given artifact of the operation to a file.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, args []string) error { return cmd.Validate(args) },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cobraCmd.Flags().StringVarP(&cmd.output, "output", "o", tableOutput, fmt.Sprintf("Output type of displayed operations. The possible values are: %s, %s, %s.", tableOutput, jsonOutput, csvOutput))
+	cobraCmd.Flags().BoolVar(&cmd.artifacts, "artifacts", false, "Displays the artifacts attached to the operations of the Runtime instead of the operations.")
+	cobraCmd.Flags().StringVar(&cmd.download, "download", "", "Prints the content of the artifact to the standard output. The artifact is specified as {OPERATION ID}/{NAME}, as displayed by the --artifacts flag.")
+
+	return cobraCmd
+}
+
+// Run executes the runtimes operation command
+func (cmd *RuntimeOperationCommand) Run() error {
+	cmd.log = logger.New()
+	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLITokenSource(cmd.log))
+
+	switch {
+	case cmd.download != "":
+		return cmd.downloadArtifact(client, os.Stdout)
+	case cmd.artifacts:
+		return cmd.printArtifacts(client)
+	}
+
+	operations, err := client.ListRuntimeOperations(cmd.runtimeID)
+	if err != nil {
+		return errors.Wrapf(err, "while listing operations of runtime %s", cmd.runtimeID)
+	}
+	sortOperationsByCreatedAt(operations)
+
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinter(runtimeOperationColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(operations)
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(operations)
+	case csvOutput:
+		return printOperationsCSV(os.Stdout, operations)
+	}
+
+	return nil
+}
+
+// Validate checks the input parameters of the runtimes operation command
+func (cmd *RuntimeOperationCommand) Validate(args []string) error {
+	if len(args) != 1 || args[0] == "" {
+		return errors.New("runtime ID must be specified")
+	}
+	cmd.runtimeID = args[0]
+
+	if cmd.download != "" {
+		if cmd.artifacts {
+			return errors.New("--artifacts and --download flags cannot be used together")
+		}
+		if _, _, err := splitArtifactRef(cmd.download); err != nil {
+			return err
+		}
+	}
+
+	switch cmd.output {
+	case tableOutput, jsonOutput, csvOutput:
+		return nil
+	}
+	return fmt.Errorf("invalid value for output: %s", cmd.output)
+}
+
+func (cmd *RuntimeOperationCommand) printArtifacts(client runtime.Client) error {
+	artifacts, err := cmd.listArtifacts(client)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinter(operationArtifactColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(artifacts)
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(artifacts)
+	case csvOutput:
+		cp, err := printer.NewCSVPrinterTo(os.Stdout, operationArtifactColumns)
+		if err != nil {
+			return err
+		}
+		return cp.PrintObj(artifacts)
+	}
+
+	return nil
+}
+
+// listArtifacts returns the artifacts of all operations of the runtime, in the chronological order of the operations
+func (cmd *RuntimeOperationCommand) listArtifacts(client runtime.Client) ([]runtime.ArtifactDTO, error) {
+	operations, err := client.ListRuntimeOperations(cmd.runtimeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while listing operations of runtime %s", cmd.runtimeID)
+	}
+	sortOperationsByCreatedAt(operations)
+
+	artifacts := make([]runtime.ArtifactDTO, 0)
+	for _, op := range operations {
+		opArtifacts, err := client.ListOperationArtifacts(op.OperationID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while listing artifacts of operation %s", op.OperationID)
+		}
+		artifacts = append(artifacts, opArtifacts...)
+	}
+
+	return artifacts, nil
+}
+
+func (cmd *RuntimeOperationCommand) downloadArtifact(client runtime.Client, w io.Writer) error {
+	operationID, name, err := splitArtifactRef(cmd.download)
+	if err != nil {
+		return err
+	}
+
+	data, err := client.GetOperationArtifact(operationID, name)
+	if err != nil {
+		return errors.Wrapf(err, "while fetching artifact %s of operation %s", name, operationID)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// splitArtifactRef splits the {OPERATION ID}/{NAME} reference of the artifact
+func splitArtifactRef(ref string) (string, string, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid value for download: %s, the artifact must be specified as {OPERATION ID}/{NAME}", ref)
+	}
+	return parts[0], parts[1], nil
+}
+
+func printOperationsCSV(w io.Writer, operations []runtime.Operation) error {
+	cp, err := printer.NewCSVPrinterTo(w, runtimeOperationColumns)
+	if err != nil {
+		return err
+	}
+	return cp.PrintObj(operations)
+}
+
+func sortOperationsByCreatedAt(operations []runtime.Operation) {
+	sort.SliceStable(operations, func(i, j int) bool {
+		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
+	})
+}
+
+func operationCreatedAt(obj interface{}) string {
+	op := obj.(runtime.Operation)
+	return op.CreatedAt.Format("2006/01/02 15:04:05")
+}
+
+func operationUpdatedAt(obj interface{}) string {
+	op := obj.(runtime.Operation)
+	if op.UpdatedAt.IsZero() {
+		return ""
+	}
+	return op.UpdatedAt.Format("2006/01/02 15:04:05")
+}
+
+func operationDuration(obj interface{}) string {
+	op := obj.(runtime.Operation)
+	if op.UpdatedAt.IsZero() || op.UpdatedAt.Before(op.CreatedAt) {
+		return ""
+	}
+	return op.UpdatedAt.Sub(op.CreatedAt).Round(time.Second).String()
+}
+
+func artifactCreatedAt(obj interface{}) string {
+	a := obj.(runtime.ArtifactDTO)
+	return a.CreatedAt.Format("2006/01/02 15:04:05")
+}
+
+func operationError(obj interface{}) string {
+	op := obj.(runtime.Operation)
+	if op.State != failed {
+		return ""
+	}
+	return op.Description
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime/runtimefake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeOperationCommand_PrintCSV(t *testing.T) {
+	// given
+	created := time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)
+	operations := []runtime.Operation{
+		{Type: runtime.OperationTypeUpgradeKyma, State: failed, Description: "upgrade failed, retrying", CreatedAt: created.Add(2 * time.Hour), UpdatedAt: created.Add(2*time.Hour + 90*time.Second)},
+		{Type: runtime.OperationTypeProvision, State: succeeded, Description: "provisioned", CreatedAt: created, UpdatedAt: created.Add(25 * time.Minute)},
+		{Type: runtime.OperationTypeSuspension, State: inProgress, CreatedAt: created.Add(time.Hour)},
+	}
+	buf := &bytes.Buffer{}
+
+	// when
+	sortOperationsByCreatedAt(operations)
+	err := printOperationsCSV(buf, operations)
+
+	// then
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "TYPE,STATE,CREATED,UPDATED,DURATION,ERROR", lines[0])
+	assert.Equal(t, "provision,succeeded,2021/01/13 10:00:00,2021/01/13 10:25:00,25m0s,", lines[1])
+	assert.Equal(t, "suspension,in progress,2021/01/13 11:00:00,,,", lines[2])
+	assert.Equal(t, `upgradeKyma,failed,2021/01/13 12:00:00,2021/01/13 12:01:30,1m30s,"upgrade failed, retrying"`, lines[3])
+}
+
+func TestRuntimeOperationCommand_Validate(t *testing.T) {
+	t.Run("should accept CSV output", func(t *testing.T) {
+		// given
+		cmd := RuntimeOperationCommand{output: csvOutput}
+
+		// when
+		err := cmd.Validate([]string{"runtime-id"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "runtime-id", cmd.runtimeID)
+	})
+
+	t.Run("should reject invalid artifact reference", func(t *testing.T) {
+		// given
+		cmd := RuntimeOperationCommand{output: tableOutput, download: "Create_Runtime.log"}
+
+		// when
+		err := cmd.Validate([]string{"runtime-id"})
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject artifacts and download flags together", func(t *testing.T) {
+		// given
+		cmd := RuntimeOperationCommand{output: tableOutput, artifacts: true, download: "op-1/Create_Runtime.log"}
+
+		// when
+		err := cmd.Validate([]string{"runtime-id"})
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject unknown output", func(t *testing.T) {
+		// given
+		cmd := RuntimeOperationCommand{output: "yaml"}
+
+		// when
+		err := cmd.Validate([]string{"runtime-id"})
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestRuntimeOperationCommand_Artifacts(t *testing.T) {
+	created := time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)
+	client := runtimefake.NewClient()
+	client.Operations["runtime-id"] = []runtime.Operation{
+		{OperationID: "op-2", Type: runtime.OperationTypeUpgradeKyma, State: failed, CreatedAt: created.Add(time.Hour)},
+		{OperationID: "op-1", Type: runtime.OperationTypeProvision, State: succeeded, CreatedAt: created},
+	}
+	client.Artifacts["op-1"] = []runtime.ArtifactDTO{{OperationID: "op-1", Name: "Create_Runtime.log", Size: 10}}
+	client.Artifacts["op-2"] = []runtime.ArtifactDTO{{OperationID: "op-2", Name: "Upgrade_Kyma.log", Size: 20}}
+	client.ArtifactContents["op-2/Upgrade_Kyma.log"] = []byte("upgrade failed")
+
+	t.Run("should list artifacts of all operations", func(t *testing.T) {
+		// given
+		cmd := RuntimeOperationCommand{runtimeID: "runtime-id", artifacts: true}
+
+		// when
+		artifacts, err := cmd.listArtifacts(client)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []runtime.ArtifactDTO{
+			{OperationID: "op-1", Name: "Create_Runtime.log", Size: 10},
+			{OperationID: "op-2", Name: "Upgrade_Kyma.log", Size: 20},
+		}, artifacts)
+	})
+
+	t.Run("should download artifact", func(t *testing.T) {
+		// given
+		cmd := RuntimeOperationCommand{runtimeID: "runtime-id", download: "op-2/Upgrade_Kyma.log"}
+		buf := &bytes.Buffer{}
+
+		// when
+		err := cmd.downloadArtifact(client, buf)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "upgrade failed", buf.String())
+		assert.Equal(t, []runtimefake.Call{{Method: runtimefake.GetOperationArtifact, Args: []interface{}{"op-2", "Upgrade_Kyma.log"}}}, client.Calls(runtimefake.GetOperationArtifact))
+	})
+
+	t.Run("should return error of missing artifact", func(t *testing.T) {
+		// given
+		cmd := RuntimeOperationCommand{runtimeID: "runtime-id", download: "op-1/Upgrade_Kyma.log"}
+
+		// when
+		err := cmd.downloadArtifact(client, &bytes.Buffer{})
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RuntimeQuotaCommand represents an execution of the kcp runtimes quota command
+type RuntimeQuotaCommand struct {
+	cobraCmd        *cobra.Command
+	log             logger.Logger
+	output          string
+	globalAccountID string
+}
+
+const (
+	quotaOK        = "ok"
+	quotaNearLimit = "NEAR LIMIT"
+	quotaAtLimit   = "AT LIMIT"
+	quotaOverLimit = "OVER LIMIT"
+)
+
+// nearLimitRatio is the ratio of used to limit from which the plan quota is considered near the limit
+const nearLimitRatio = 0.8
+
+var quotaTableColumns = []printer.Column{
+	{
+		Header:    "PLAN",
+		FieldSpec: "{.ServicePlanName}",
+	},
+	{
+		Header:    "USED",
+		FieldSpec: "{.Used}",
+	},
+	{
+		Header:         "LIMIT",
+		FieldFormatter: quotaLimit,
+	},
+	{
+		Header:         "STATUS",
+		FieldFormatter: quotaStatus,
+	},
+}
+
+// NewRuntimeQuotaCmd constructs a new instance of RuntimeQuotaCommand and configures it in terms of a cobra.Command
+func NewRuntimeQuotaCmd() *cobra.Command {
+	cmd := RuntimeQuotaCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "quota --account {GLOBAL ACCOUNT ID}",
+		Short: "Displays the Runtime quota of a global account.",
+		Long: `Displays the number of Runtimes of a global account per service plan compared to the limit of the plan.
+Plans which are near, at, or over the limit are highlighted in the STATUS column.
+Kyma Environment Broker limits only the trial plan, to one Runtime per global account. The other plans are displayed as unlimited, as the limits enforced outside of Kyma Environment Broker are not known to it.`,
+		Example: `  kcp runtimes quota --account CA4836781TID000000000123456789  Display the quota usage of the given global account.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringVarP(&cmd.globalAccountID, "account", "g", "", "Global account ID to display the quota for.")
+
+	return cobraCmd
+}
+
+// Run executes the runtimes quota command
+func (cmd *RuntimeQuotaCommand) Run() error {
+	cmd.log = logger.New()
+	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLITokenSource(cmd.log))
+
+	quota, err := client.GetAccountQuota(cmd.globalAccountID)
+	if err != nil {
+		return errors.Wrap(err, "while getting account quota")
+	}
+
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinter(quotaTableColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(quota.Plans)
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(quota)
+	case yamlOutput:
+		return printer.NewYAMLPrinter().PrintObj(quota)
+	}
+
+	return nil
+}
+
+// Validate checks the input parameters of the runtimes quota command
+func (cmd *RuntimeQuotaCommand) Validate() error {
+	if cmd.globalAccountID == "" {
+		return errors.New("missing required option: --account")
+	}
+
+	return ValidateOutputOpt(cmd.output)
+}
+
+// unlimited returns whether the plan has no limit, the Kyma Environment Broker versions which do not report the unlimited plans return a zero limit for them
+func unlimited(plan runtime.PlanQuotaDTO) bool {
+	return plan.Unlimited || plan.Limit == 0
+}
+
+func quotaLimit(obj interface{}) string {
+	plan := obj.(runtime.PlanQuotaDTO)
+	if unlimited(plan) {
+		return "unlimited"
+	}
+	return strconv.Itoa(plan.Limit)
+}
+
+func quotaStatus(obj interface{}) string {
+	plan := obj.(runtime.PlanQuotaDTO)
+	switch {
+	case unlimited(plan):
+		return quotaOK
+	case plan.Used > plan.Limit:
+		return fmt.Sprintf("%s (+%d)", quotaOverLimit, plan.Used-plan.Limit)
+	case plan.Used == plan.Limit:
+		return quotaAtLimit
+	case float64(plan.Used) >= nearLimitRatio*float64(plan.Limit):
+		return quotaNearLimit
+	}
+
+	return quotaOK
+}
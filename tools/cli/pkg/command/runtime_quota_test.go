@@ -0,0 +1,52 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaStatus(t *testing.T) {
+	for name, tc := range map[string]struct {
+		plan           runtime.PlanQuotaDTO
+		expectedLimit  string
+		expectedStatus string
+	}{
+		"unlimited": {
+			plan:           runtime.PlanQuotaDTO{Used: 20, Unlimited: true},
+			expectedLimit:  "unlimited",
+			expectedStatus: quotaOK,
+		},
+		"unlimited reported without the flag": {
+			plan:           runtime.PlanQuotaDTO{Used: 20},
+			expectedLimit:  "unlimited",
+			expectedStatus: quotaOK,
+		},
+		"under limit": {
+			plan:           runtime.PlanQuotaDTO{Used: 1, Limit: 10},
+			expectedLimit:  "10",
+			expectedStatus: quotaOK,
+		},
+		"near limit": {
+			plan:           runtime.PlanQuotaDTO{Used: 8, Limit: 10},
+			expectedLimit:  "10",
+			expectedStatus: quotaNearLimit,
+		},
+		"at limit": {
+			plan:           runtime.PlanQuotaDTO{Used: 1, Limit: 1},
+			expectedLimit:  "1",
+			expectedStatus: quotaAtLimit,
+		},
+		"over limit": {
+			plan:           runtime.PlanQuotaDTO{Used: 3, Limit: 1},
+			expectedLimit:  "1",
+			expectedStatus: "OVER LIMIT (+2)",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedLimit, quotaLimit(tc.plan))
+			assert.Equal(t, tc.expectedStatus, quotaStatus(tc.plan))
+		})
+	}
+}
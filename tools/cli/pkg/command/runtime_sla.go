@@ -0,0 +1,97 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RuntimeSLACommand represents an execution of the kcp runtimes sla command
+type RuntimeSLACommand struct {
+	cobraCmd *cobra.Command
+	log      logger.Logger
+	output   string
+}
+
+var slaTableColumns = []printer.Column{
+	{
+		Header:    "TYPE",
+		FieldSpec: "{.Type}",
+	},
+	{
+		Header:    "COMPLETED",
+		FieldSpec: "{.Total}",
+	},
+	{
+		Header:    "BREACHED",
+		FieldSpec: "{.Breached}",
+	},
+	{
+		Header:         "BREACH RATE",
+		FieldFormatter: slaBreachRate,
+	},
+}
+
+// NewRuntimeSLACmd constructs a new instance of RuntimeSLACommand and configures it in terms of a cobra.Command
+func NewRuntimeSLACmd() *cobra.Command {
+	cmd := RuntimeSLACommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "sla",
+		Short: "Displays the SLA breach rates of Runtime operations.",
+		Long: `Displays the number of completed Runtime operations and the number of operations which took longer than the SLA, per operation type.
+The duration of an operation is measured from its creation until it succeeds or fails.`,
+		Example: `  kcp runtimes sla             Display the SLA breach rates of all operation types.
+  kcp runtimes sla -o json     Display the SLA breach rates in the JSON format.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+
+	return cobraCmd
+}
+
+// Run executes the runtimes sla command
+func (cmd *RuntimeSLACommand) Run() error {
+	cmd.log = logger.New()
+	client := runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLITokenSource(cmd.log))
+
+	report, err := client.GetSLAReport()
+	if err != nil {
+		return errors.Wrap(err, "while fetching SLA report")
+	}
+
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinter(slaTableColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(report)
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(report)
+	case yamlOutput:
+		return printer.NewYAMLPrinter().PrintObj(report)
+	}
+
+	return nil
+}
+
+// Validate checks the input parameters of the runtimes sla command
+func (cmd *RuntimeSLACommand) Validate() error {
+	return ValidateOutputOpt(cmd.output)
+}
+
+func slaBreachRate(obj interface{}) string {
+	report := obj.(runtime.SLAReportDTO)
+	if report.Total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(report.Breached)*100/float64(report.Total))
+}
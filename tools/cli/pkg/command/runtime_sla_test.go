@@ -0,0 +1,24 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLABreachRate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		report   runtime.SLAReportDTO
+		expected string
+	}{
+		"no breaches":   {report: runtime.SLAReportDTO{Total: 4}, expected: "0.0%"},
+		"some breaches": {report: runtime.SLAReportDTO{Total: 3, Breached: 1}, expected: "33.3%"},
+		"no completed":  {report: runtime.SLAReportDTO{}, expected: "-"},
+		"all breached":  {report: runtime.SLAReportDTO{Total: 2, Breached: 2}, expected: "100.0%"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, slaBreachRate(tc.report))
+		})
+	}
+}
@@ -0,0 +1,1390 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime/runtimefake"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/timewindow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeCommand_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args             []string
+		wantErr          bool
+		expectedPageSize int
+	}{
+		"default values": {
+			args:             []string{},
+			expectedPageSize: maxPageSize,
+		},
+		"minimal page size": {
+			args:             []string{"--page-size", "1"},
+			expectedPageSize: 1,
+		},
+		"maximal page size": {
+			args:             []string{"--page-size", "100"},
+			expectedPageSize: maxPageSize,
+		},
+		"zero page size": {
+			args:    []string{"--page-size", "0"},
+			wantErr: true,
+		},
+		"too big page size": {
+			args:    []string{"--page-size", "101"},
+			wantErr: true,
+		},
+		"zero max results": {
+			args:    []string{"--max-results", "0"},
+			wantErr: true,
+		},
+		"negative max results": {
+			args:    []string{"--max-results", "-1"},
+			wantErr: true,
+		},
+		"page size adjusted to max results": {
+			args:             []string{"--max-results", "10", "--page-size", "50"},
+			expectedPageSize: 10,
+		},
+		"default page size adjusted to max results": {
+			args:             []string{"--max-results", "10"},
+			expectedPageSize: 10,
+		},
+		"default page size kept with max results and filters of the listed runtimes": {
+			args:             []string{"--max-results", "10", "--state", "failed"},
+			expectedPageSize: maxPageSize,
+		},
+		"ha-only and non-ha together": {
+			args:    []string{"--ha-only", "--non-ha"},
+			wantErr: true,
+		},
+		"yaml output": {
+			args:             []string{"-o", "yaml"},
+			expectedPageSize: maxPageSize,
+		},
+		"csv output": {
+			args:             []string{"-o", "csv", "--column-order", "state"},
+			expectedPageSize: maxPageSize,
+		},
+		"custom columns": {
+			args:             []string{"-o", "custom-columns=SHOOT:{.ShootName},STATE:.Status.Provisioning.State"},
+			expectedPageSize: maxPageSize,
+		},
+		"custom columns with json projection": {
+			args:             []string{"-o", "custom-columns=SHOOT:{.ShootName}", "--json-match-columns"},
+			expectedPageSize: maxPageSize,
+		},
+		"invalid custom columns": {
+			args:    []string{"-o", "custom-columns=SHOOT"},
+			wantErr: true,
+		},
+		"json projection without custom columns": {
+			args:    []string{"-o", "json", "--json-match-columns"},
+			wantErr: true,
+		},
+		"json projection of complex field spec": {
+			args:    []string{"-o", "custom-columns=STATE:{.Status.UpgradingKyma.Data[0].State}", "--json-match-columns"},
+			wantErr: true,
+		},
+		"watch diff": {
+			args:             []string{"--watch-diff", "--watch-interval", "1m"},
+			expectedPageSize: maxPageSize,
+		},
+		"watch diff with json output": {
+			args:    []string{"--watch-diff", "-o", "json"},
+			wantErr: true,
+		},
+		"watch": {
+			args:             []string{"-w", "--watch-interval", "5s"},
+			expectedPageSize: maxPageSize,
+		},
+		"watch with json output": {
+			args:             []string{"--watch", "-o", "json"},
+			expectedPageSize: maxPageSize,
+		},
+		"watch with json lines output": {
+			args:             []string{"--watch", "-o", "jsonl"},
+			expectedPageSize: maxPageSize,
+		},
+		"watch with yaml output": {
+			args:    []string{"--watch", "-o", "yaml"},
+			wantErr: true,
+		},
+		"json lines output": {
+			args:             []string{"-o", "jsonl"},
+			expectedPageSize: maxPageSize,
+		},
+		"json lines output with columns": {
+			args:    []string{"-o", "jsonl", "--columns", "SHOOT"},
+			wantErr: true,
+		},
+		"json lines output without headers": {
+			args:    []string{"-o", "jsonl", "--no-headers"},
+			wantErr: true,
+		},
+		"watch with watch diff": {
+			args:    []string{"--watch", "--watch-diff"},
+			wantErr: true,
+		},
+		"provider": {
+			args:             []string{"--provider", "GCP,azure"},
+			expectedPageSize: maxPageSize,
+		},
+		"kyma version range": {
+			args:             []string{"--kyma-version", ">=1.19.0, <1.20.0"},
+			expectedPageSize: maxPageSize,
+		},
+		"invalid kyma version range": {
+			args:    []string{"--kyma-version", ">=x.y"},
+			wantErr: true,
+		},
+		"unknown provider": {
+			args:    []string{"--provider", "alicloud"},
+			wantErr: true,
+		},
+		"provider without plan": {
+			args:    []string{"--provider", "aws"},
+			wantErr: true,
+		},
+		"zero watch interval": {
+			args:    []string{"--watch-diff", "--watch-interval", "0s"},
+			wantErr: true,
+		},
+		"column order": {
+			args:             []string{"--column-order", "state,shoot"},
+			expectedPageSize: maxPageSize,
+		},
+		"column order of custom columns": {
+			args:             []string{"-o", "custom-columns=SHOOT:{.ShootName},REGION:{.ProviderRegion}", "--column-order", "region"},
+			expectedPageSize: maxPageSize,
+		},
+		"unknown column in column order": {
+			args:    []string{"--column-order", "state,unknown"},
+			wantErr: true,
+		},
+		"duplicated column in column order": {
+			args:    []string{"--column-order", "state,STATE"},
+			wantErr: true,
+		},
+		"selected columns": {
+			args:             []string{"--columns", "SHOOT,region,created-at"},
+			expectedPageSize: maxPageSize,
+		},
+		"unknown selected column": {
+			args:    []string{"--columns", "SHOOT,ZONE"},
+			wantErr: true,
+		},
+		"selected columns with json output": {
+			args:    []string{"-o", "json", "--columns", "SHOOT"},
+			wantErr: true,
+		},
+		"selected columns with custom columns": {
+			args:    []string{"-o", "custom-columns=SHOOT:{.ShootName}", "--columns", "SHOOT"},
+			wantErr: true,
+		},
+		"no headers": {
+			args:             []string{"--no-headers"},
+			expectedPageSize: maxPageSize,
+		},
+		"no headers with custom columns": {
+			args:             []string{"-o", "custom-columns=SHOOT:{.ShootName}", "--no-headers"},
+			expectedPageSize: maxPageSize,
+		},
+		"no headers with json output": {
+			args:    []string{"-o", "json", "--no-headers"},
+			wantErr: true,
+		},
+		"go template output": {
+			args:             []string{"-o", "go-template={{.ShootName}} {{.Status.Provisioning.State}}"},
+			expectedPageSize: maxPageSize,
+		},
+		"malformed go template": {
+			args:    []string{"-o", "go-template={{.ShootName"},
+			wantErr: true,
+		},
+		"go template with column order": {
+			args:    []string{"-o", "go-template={{.ShootName}}", "--column-order", "state"},
+			wantErr: true,
+		},
+		"max column width": {
+			args:             []string{"--max-column-width", "12"},
+			expectedPageSize: maxPageSize,
+		},
+		"max column width with custom columns": {
+			args:             []string{"--max-column-width", "12", "-o", "custom-columns=SHOOT:{.ShootName}"},
+			expectedPageSize: maxPageSize,
+		},
+		"negative max column width": {
+			args:    []string{"--max-column-width", "-1"},
+			wantErr: true,
+		},
+		"max column width with json output": {
+			args:    []string{"--max-column-width", "12", "-o", "json"},
+			wantErr: true,
+		},
+		"jsonpath output": {
+			args:             []string{"-o", "jsonpath={.data[*].shootName}"},
+			expectedPageSize: maxPageSize,
+		},
+		"malformed jsonpath": {
+			args:    []string{"-o", "jsonpath={.data[*].shootName"},
+			wantErr: true,
+		},
+		"jsonpath with columns": {
+			args:    []string{"-o", "jsonpath={.data[*].shootName}", "--columns", "SHOOT"},
+			wantErr: true,
+		},
+		"jsonpath with count": {
+			args:    []string{"-o", "jsonpath={.count}", "--count"},
+			wantErr: true,
+		},
+		"sort by column": {
+			args:             []string{"--sort-by", "CREATED AT", "--sort-order", "desc", "--columns", "SHOOT"},
+			expectedPageSize: maxPageSize,
+		},
+		"sort by unknown column": {
+			args:    []string{"--sort-by", "ZONE"},
+			wantErr: true,
+		},
+		"invalid sort order": {
+			args:    []string{"--sort-by", "REGION", "--sort-order", "random"},
+			wantErr: true,
+		},
+		"color always": {
+			args:             []string{"--color", "always"},
+			expectedPageSize: maxPageSize,
+		},
+		"invalid color": {
+			args:    []string{"--color", "sometimes"},
+			wantErr: true,
+		},
+		"created time window": {
+			args:             []string{"--created-after", "2021-01-01T00:00:00Z", "--created-before", "2021-02-01T00:00:00Z"},
+			expectedPageSize: maxPageSize,
+		},
+		"unparsable created after": {
+			args:    []string{"--created-after", "last-month"},
+			wantErr: true,
+		},
+		"created after later than created before": {
+			args:    []string{"--created-after", "2021-02-01T00:00:00Z", "--created-before", "2021-01-01T00:00:00Z"},
+			wantErr: true,
+		},
+		"count": {
+			args:             []string{"--count", "-o", "json"},
+			expectedPageSize: maxPageSize,
+		},
+		"count with checksum": {
+			args:    []string{"--count", "--checksum"},
+			wantErr: true,
+		},
+		"column order with yaml output": {
+			args:    []string{"-o", "yaml", "--column-order", "state"},
+			wantErr: true,
+		},
+		"column order with json output": {
+			args:    []string{"-o", "json", "--column-order", "state"},
+			wantErr: true,
+		},
+		"explain state": {
+			args:             []string{"--explain-state"},
+			expectedPageSize: maxPageSize,
+		},
+		"explain state with json output": {
+			args:    []string{"--explain-state", "-o", "json"},
+			wantErr: true,
+		},
+		"page size smaller than max results": {
+			args:             []string{"--max-results", "50", "--page-size", "10"},
+			expectedPageSize: 10,
+		},
+		"page with page size": {
+			args:             []string{"--page", "2", "--page-size", "50"},
+			expectedPageSize: 50,
+		},
+		"zero page": {
+			args:    []string{"--page", "0"},
+			wantErr: true,
+		},
+		"page with max results": {
+			args:    []string{"--page", "1", "--max-results", "10"},
+			wantErr: true,
+		},
+		"match any": {
+			args:             []string{"--match", "any", "--region", "westeurope", "--plan", "trial"},
+			expectedPageSize: maxPageSize,
+		},
+		"invalid match": {
+			args:    []string{"--match", "none"},
+			wantErr: true,
+		},
+		"valid shoot regex": {
+			args:             []string{"--shoot-regex", "^c-[0-9a-f]+$"},
+			expectedPageSize: maxPageSize,
+		},
+		"invalid shoot regex": {
+			args:    []string{"--shoot-regex", "c-[0-9"},
+			wantErr: true,
+		},
+		"summary with count": {
+			args:    []string{"--summary", "--count"},
+			wantErr: true,
+		},
+		"quiet with json output": {
+			args:             []string{"-q", "-o", "json"},
+			expectedPageSize: maxPageSize,
+		},
+		"quiet with count": {
+			args:    []string{"--quiet", "--count"},
+			wantErr: true,
+		},
+		"quiet with watch": {
+			args:    []string{"--quiet", "--watch"},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := newRuntimeCommand()
+			err := cmd.cobraCmd.ParseFlags(tc.args)
+			if err == nil {
+				// when
+				err = cmd.Validate()
+			}
+
+			// then
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPageSize, cmd.params.PageSize)
+		})
+	}
+}
+
+func TestRuntimeCommand_ValidateFilterFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kcp-runtimes")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "subaccounts.txt")
+	err = ioutil.WriteFile(path, []byte("# failed in the last upgrade\nSAID1\n\n  SAID2  \n"), 0644)
+	require.NoError(t, err)
+
+	t.Run("should append values read from the file", func(t *testing.T) {
+		// given
+		cmd := newRuntimeCommand()
+		err := cmd.cobraCmd.ParseFlags([]string{"--subaccount", "SAID0", "--subaccount", "@" + path})
+		require.NoError(t, err)
+
+		// when
+		err = cmd.Validate()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SAID0", "SAID1", "SAID2"}, cmd.params.SubAccountIDs)
+	})
+
+	t.Run("should fail if the file does not exist", func(t *testing.T) {
+		// given
+		cmd := newRuntimeCommand()
+		err := cmd.cobraCmd.ParseFlags([]string{"--subaccount", "@" + filepath.Join(dir, "missing.txt")})
+		require.NoError(t, err)
+
+		// when
+		err = cmd.Validate()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid value for subaccount")
+	})
+}
+
+func TestRuntimeCommand_ColumnOrder(t *testing.T) {
+	// given
+	cmd := newRuntimeCommand()
+	require.NoError(t, cmd.cobraCmd.ParseFlags([]string{"--column-order", "state,shoot"}))
+
+	// when
+	err := cmd.Validate()
+
+	// then
+	require.NoError(t, err)
+	var headers []string
+	for _, col := range cmd.columns {
+		headers = append(headers, col.Header)
+	}
+	assert.Equal(t, []string{"STATE", "SHOOT", "GLOBALACCOUNT ID", "SUBACCOUNT ID", "REGION", "PLAN", "CREATED AT", "DURATION"}, headers)
+}
+
+func TestRuntimeCommand_SelectedColumns(t *testing.T) {
+	// given
+	cmd := newRuntimeCommand()
+	require.NoError(t, cmd.cobraCmd.ParseFlags([]string{"--columns", "SHOOT,created at,state", "--column-order", "state"}))
+
+	// when
+	err := cmd.Validate()
+
+	// then
+	require.NoError(t, err)
+	var headers []string
+	for _, col := range cmd.columns {
+		headers = append(headers, col.Header)
+	}
+	assert.Equal(t, []string{"STATE", "SHOOT", "CREATED AT"}, headers)
+}
+
+func TestRuntimeCommand_MaxColumnWidth(t *testing.T) {
+	// given
+	cmd := newRuntimeCommand()
+	require.NoError(t, cmd.cobraCmd.ParseFlags([]string{"--max-column-width", "12"}))
+
+	// when
+	err := cmd.Validate()
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, cmd.columns, len(tableColumns))
+	for i, col := range cmd.columns {
+		assert.Equal(t, 12, col.MaxWidth)
+		assert.Zero(t, tableColumns[i].MaxWidth)
+	}
+}
+
+func TestRuntimeCommand_OperationColumns(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args            []string
+		wantErr         bool
+		expectedHeaders []string
+	}{
+		"selected with columns": {
+			args:            []string{"--columns", "SHOOT,operation-type,OPERATION ID"},
+			expectedHeaders: []string{"SHOOT", "OPERATION TYPE", "OPERATION ID"},
+		},
+		"show operation id": {
+			args:            []string{"--show-operation-id"},
+			expectedHeaders: []string{"GLOBALACCOUNT ID", "SUBACCOUNT ID", "SHOOT", "REGION", "PLAN", "CREATED AT", "STATE", "DURATION", "OPERATION ID"},
+		},
+		"kyma version": {
+			args:            []string{"--kyma-version", "1.20.0", "--show-operation-id"},
+			expectedHeaders: []string{"GLOBALACCOUNT ID", "SUBACCOUNT ID", "SHOOT", "REGION", "PLAN", "CREATED AT", "STATE", "DURATION", "OPERATION ID", "KYMA VERSION"},
+		},
+		"platform region": {
+			args:            []string{"--platform-region", "cf-eu10"},
+			expectedHeaders: []string{"GLOBALACCOUNT ID", "SUBACCOUNT ID", "SHOOT", "REGION", "PLAN", "CREATED AT", "STATE", "DURATION", "PLATFORM REGION"},
+		},
+		"platform region selected with columns": {
+			args:            []string{"--columns", "SHOOT,REGION,PLATFORM REGION"},
+			expectedHeaders: []string{"SHOOT", "REGION", "PLATFORM REGION"},
+		},
+		"kyma version with columns": {
+			args:            []string{"--kyma-version", "1.20.0", "--columns", "SHOOT"},
+			expectedHeaders: []string{"SHOOT"},
+		},
+		"show operation id with columns": {
+			args:    []string{"--show-operation-id", "--columns", "SHOOT"},
+			wantErr: true,
+		},
+		"show operation id with json output": {
+			args:    []string{"--show-operation-id", "-o", "json"},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := newRuntimeCommand()
+			require.NoError(t, cmd.cobraCmd.ParseFlags(tc.args))
+
+			// when
+			err := cmd.Validate()
+
+			// then
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var headers []string
+			for _, col := range cmd.columns {
+				headers = append(headers, col.Header)
+			}
+			assert.Equal(t, tc.expectedHeaders, headers)
+		})
+	}
+}
+
+func TestRuntimeLastOperationColumns(t *testing.T) {
+	// given
+	rt := runtime.RuntimeDTO{
+		Status: runtime.RuntimeStatus{
+			Provisioning:  &runtime.Operation{OperationID: "provisioning-id", State: succeeded, CreatedAt: time.Now().Add(-time.Hour)},
+			UpgradingKyma: runtime.OperationsData{Data: []runtime.Operation{{OperationID: "upgrade-id", State: inProgress, CreatedAt: time.Now()}}, Count: 1},
+		},
+	}
+
+	// when
+	id := runtimeLastOperationID(rt)
+	opType := runtimeLastOperationType(rt)
+
+	// then
+	assert.Equal(t, "upgrade-id", id)
+	assert.Equal(t, string(upgradeKyma), opType)
+}
+
+func TestRuntimeCommand_SortRuntimes(t *testing.T) {
+	created := time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)
+	runtimes := []runtime.RuntimeDTO{
+		{RuntimeID: "rt-3", ProviderRegion: "westeurope", Status: runtime.RuntimeStatus{CreatedAt: created}},
+		{RuntimeID: "rt-1", ProviderRegion: "westeurope", Status: runtime.RuntimeStatus{CreatedAt: created.Add(2 * time.Hour)}},
+		{RuntimeID: "rt-2", ProviderRegion: "eastus", Status: runtime.RuntimeStatus{CreatedAt: created.Add(time.Hour)}},
+	}
+
+	for name, tc := range map[string]struct {
+		args     []string
+		expected []string
+	}{
+		"by field spec column with ties broken by runtime ID": {
+			args:     []string{"--sort-by", "REGION"},
+			expected: []string{"rt-2", "rt-1", "rt-3"},
+		},
+		"by formatted column in descending order": {
+			args:     []string{"--sort-by", "created-at", "--sort-order", "desc"},
+			expected: []string{"rt-1", "rt-2", "rt-3"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := newRuntimeCommand()
+			require.NoError(t, cmd.cobraCmd.ParseFlags(tc.args))
+			require.NoError(t, cmd.Validate())
+			data := append([]runtime.RuntimeDTO{}, runtimes...)
+
+			// when
+			err := cmd.sortRuntimes(data)
+
+			// then
+			require.NoError(t, err)
+			var ids []string
+			for _, rt := range data {
+				ids = append(ids, rt.RuntimeID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func TestRuntimeStatusColor(t *testing.T) {
+	for status, expected := range map[string]printer.Color{
+		"failed (upgradeKyma)":        printer.ColorRed,
+		"provisioning":                printer.ColorYellow,
+		"provisioning (unsuspending)": printer.ColorYellow,
+		"deprovisioning (suspending)": printer.ColorYellow,
+		"upgrading":                   printer.ColorYellow,
+		"succeeded":                   printer.ColorGreen,
+		"suspended":                   printer.ColorDefault,
+		"deprovisioned":               printer.ColorDefault,
+		"canceled (kyma upgrade)":     printer.ColorDefault,
+	} {
+		t.Run(status, func(t *testing.T) {
+			assert.Equal(t, expected, runtimeStatusColor(status))
+		})
+	}
+}
+
+func TestOperationStatusToString(t *testing.T) {
+	for expected, tc := range map[string]struct {
+		state  string
+		opType operationType
+	}{
+		"provisioning":            {state: inProgress, opType: provision},
+		"upgrading":               {state: inProgress, opType: upgradeKyma},
+		"failed (kyma upgrade)":   {state: failed, opType: upgradeKyma},
+		"canceled (kyma upgrade)": {state: canceled, opType: upgradeKyma},
+		"suspended":               {state: succeeded, opType: suspension},
+	} {
+		t.Run(expected, func(t *testing.T) {
+			assert.Equal(t, expected, operationStatusToString(runtime.Operation{State: tc.state}, tc.opType))
+		})
+	}
+}
+
+func TestRuntimeAvailability(t *testing.T) {
+	for name, tc := range map[string]struct {
+		runtime  runtime.RuntimeDTO
+		expected availability
+	}{
+		"trial":                   {runtime: runtime.RuntimeDTO{ServicePlanName: trialPlan, ZoneCount: 1}, expected: availabilityNonHA},
+		"azure_lite":              {runtime: runtime.RuntimeDTO{ServicePlanName: azureLitePlan, ZoneCount: 1}, expected: availabilityNonHA},
+		"azure with default zone": {runtime: runtime.RuntimeDTO{ServicePlanName: azurePlan, ZoneCount: 1}, expected: availabilityNonHA},
+		"gcp with default zone":   {runtime: runtime.RuntimeDTO{ServicePlanName: gcpPlan, ZoneCount: 1}, expected: availabilityNonHA},
+		"azure with three zones":  {runtime: runtime.RuntimeDTO{ServicePlanName: azurePlan, ZoneCount: 3}, expected: availabilityHA},
+		"gcp with two zones":      {runtime: runtime.RuntimeDTO{ServicePlanName: gcpPlan, ZoneCount: 2}, expected: availabilityHA},
+		"zone count not reported": {runtime: runtime.RuntimeDTO{ServicePlanName: azurePlan}, expected: availabilityUnknown},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, runtimeAvailability(tc.runtime))
+		})
+	}
+}
+
+func TestRuntimeCommand_FilterByAvailability(t *testing.T) {
+	trial := runtime.RuntimeDTO{ShootName: "c-1", ServicePlanName: trialPlan, ZoneCount: 1}
+	lite := runtime.RuntimeDTO{ShootName: "c-2", ServicePlanName: azureLitePlan, ZoneCount: 1}
+	azure := runtime.RuntimeDTO{ShootName: "c-3", ServicePlanName: azurePlan, ZoneCount: 1}
+	gcp := runtime.RuntimeDTO{ShootName: "c-4", ServicePlanName: gcpPlan, ZoneCount: 1}
+	azureHA := runtime.RuntimeDTO{ShootName: "c-5", ServicePlanName: azurePlan, ZoneCount: 3}
+	gcpHA := runtime.RuntimeDTO{ShootName: "c-6", ServicePlanName: gcpPlan, ZoneCount: 3}
+	unreported := runtime.RuntimeDTO{ShootName: "c-7", ServicePlanName: azurePlan}
+
+	t.Run("should return HA runtimes", func(t *testing.T) {
+		// given
+		cmd := RuntimeCommand{haOnly: true}
+
+		// when
+		rp, err := cmd.filterByAvailability(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial, azure, azureHA, lite, gcp, gcpHA}, Count: 6, TotalCount: 6})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []runtime.RuntimeDTO{azureHA, gcpHA}, rp.Data)
+		assert.Equal(t, 2, rp.Count)
+	})
+
+	t.Run("should return non-HA runtimes", func(t *testing.T) {
+		// given
+		cmd := RuntimeCommand{nonHA: true}
+
+		// when
+		rp, err := cmd.filterByAvailability(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial, azureHA, lite, azure, gcp}, Count: 5, TotalCount: 5})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []runtime.RuntimeDTO{trial, lite, azure, gcp}, rp.Data)
+		assert.Equal(t, 4, rp.Count)
+	})
+
+	t.Run("should not return single zone runtimes for ha-only", func(t *testing.T) {
+		// given
+		cmd := RuntimeCommand{haOnly: true}
+
+		// when
+		rp, err := cmd.filterByAvailability(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial, lite, azure, gcp}, Count: 4, TotalCount: 4})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, rp.Data)
+		assert.Zero(t, rp.Count)
+	})
+
+	t.Run("should report runtimes with unknown availability", func(t *testing.T) {
+		// given
+		cmd := RuntimeCommand{haOnly: true}
+
+		// when
+		_, err := cmd.filterByAvailability(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial, unreported}, Count: 2, TotalCount: 2})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), unreported.ShootName)
+	})
+}
+
+func TestRuntimeCommand_PrintCount(t *testing.T) {
+	for output, expected := range map[string]string{
+		tableOutput:     "3\n",
+		csvOutput:       "3\n",
+		jsonOutput:      "{\"count\":3}\n",
+		jsonLinesOutput: "{\"count\":3}\n",
+		yamlOutput:      "count: 3\n",
+	} {
+		t.Run(output, func(t *testing.T) {
+			// given
+			cmd := RuntimeCommand{output: output}
+			buf := &bytes.Buffer{}
+
+			// when
+			err := cmd.printCount(buf, 3)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, expected, buf.String())
+		})
+	}
+}
+
+func TestPrintPageFooter(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+	runtimes := runtime.RuntimesPage{
+		Data:       []runtime.RuntimeDTO{{RuntimeID: "rt-1"}, {RuntimeID: "rt-2"}},
+		Count:      2,
+		TotalCount: 12,
+	}
+
+	// when
+	err := printPageFooter(buf, 3, 5, runtimes)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "\nPage 3 of 3, displayed 2 of 12 Runtimes in total\n", buf.String())
+}
+
+func TestSummarizeStates(t *testing.T) {
+	// given
+	now := time.Now()
+	provisioned := runtime.RuntimeDTO{
+		Status: runtime.RuntimeStatus{Provisioning: &runtime.Operation{State: succeeded, CreatedAt: now}},
+	}
+	provisionFailed := runtime.RuntimeDTO{
+		Status: runtime.RuntimeStatus{Provisioning: &runtime.Operation{State: failed, CreatedAt: now}},
+	}
+	upgradeFailed := runtime.RuntimeDTO{
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{State: succeeded, CreatedAt: now.Add(-time.Hour)},
+			UpgradingKyma: runtime.OperationsData{
+				Data:  []runtime.Operation{{State: failed, CreatedAt: now}},
+				Count: 1,
+			},
+		},
+	}
+	upgrading := runtime.RuntimeDTO{
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{State: succeeded, CreatedAt: now.Add(-time.Hour)},
+			UpgradingKyma: runtime.OperationsData{
+				Data:  []runtime.Operation{{State: inProgress, CreatedAt: now}},
+				Count: 1,
+			},
+		},
+	}
+	runtimes := []runtime.RuntimeDTO{provisioned, provisionFailed, upgrading, provisioned, upgradeFailed, provisioned}
+
+	// when
+	summary := summarizeStates(runtimes)
+
+	// then
+	assert.Equal(t, []stateCount{{state: "succeeded", count: 3}, {state: "failed", count: 2}, {state: "upgrading", count: 1}}, summary)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, printSummary(buf, runtimes))
+	assert.Equal(t, "\n6 Runtimes: 3 succeeded, 2 failed, 1 upgrading\n", buf.String())
+}
+
+func TestPrintShootNames(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+	runtimes := []runtime.RuntimeDTO{
+		{ShootName: "c-1", RuntimeID: "rt-1"},
+		{ShootName: "c-2", RuntimeID: "rt-2"},
+	}
+
+	// when
+	err := printShootNames(buf, runtimes)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "c-1\nc-2\n", buf.String())
+}
+
+func TestRuntimeCommand_FilterByState(t *testing.T) {
+	// given
+	now := time.Now()
+	provisioned := runtime.RuntimeDTO{
+		ShootName: "c-1",
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{State: succeeded, CreatedAt: now},
+		},
+	}
+	upgradeFailure := runtime.RuntimeDTO{
+		ShootName: "c-2",
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{State: succeeded, CreatedAt: now.Add(-time.Hour)},
+			UpgradingKyma: runtime.OperationsData{
+				Data:  []runtime.Operation{{State: failed, CreatedAt: now}},
+				Count: 1,
+			},
+		},
+	}
+	provisioning := runtime.RuntimeDTO{
+		ShootName: "c-3",
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{State: inProgress, CreatedAt: now},
+		},
+	}
+	runtimes := runtime.RuntimesPage{
+		Data:       []runtime.RuntimeDTO{provisioned, upgradeFailure, provisioning},
+		Count:      3,
+		TotalCount: 3,
+	}
+
+	for name, tc := range map[string]struct {
+		states   []string
+		expected []runtime.RuntimeDTO
+	}{
+		"state with details": {
+			states:   []string{"failed"},
+			expected: []runtime.RuntimeDTO{upgradeFailure},
+		},
+		"exact state with details": {
+			states:   []string{"failed (upgradeKyma)"},
+			expected: []runtime.RuntimeDTO{upgradeFailure},
+		},
+		"multiple states": {
+			states:   []string{"Succeeded", "provisioning"},
+			expected: []runtime.RuntimeDTO{provisioned, provisioning},
+		},
+		"no matching state": {
+			states:   []string{"suspended"},
+			expected: []runtime.RuntimeDTO{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := RuntimeCommand{states: tc.states}
+
+			// when
+			rp := cmd.filterByState(runtimes)
+
+			// then
+			assert.Equal(t, tc.expected, rp.Data)
+			assert.Equal(t, len(tc.expected), rp.Count)
+			assert.Equal(t, 3, rp.TotalCount)
+		})
+	}
+}
+
+func TestRuntimeCommand_FilterByCreatedAt(t *testing.T) {
+	// given
+	created := time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)
+	before := runtime.RuntimeDTO{ShootName: "c-1", Status: runtime.RuntimeStatus{CreatedAt: created.Add(-time.Hour)}}
+	atStart := runtime.RuntimeDTO{ShootName: "c-2", Status: runtime.RuntimeStatus{CreatedAt: created}}
+	within := runtime.RuntimeDTO{ShootName: "c-3", Status: runtime.RuntimeStatus{CreatedAt: created.Add(time.Hour)}}
+	atEnd := runtime.RuntimeDTO{ShootName: "c-4", Status: runtime.RuntimeStatus{CreatedAt: created.Add(2 * time.Hour)}}
+	runtimes := runtime.RuntimesPage{Data: []runtime.RuntimeDTO{before, atStart, within, atEnd}, Count: 4, TotalCount: 4}
+
+	for name, tc := range map[string]struct {
+		cmd      RuntimeCommand
+		expected []runtime.RuntimeDTO
+	}{
+		"created after": {
+			cmd:      RuntimeCommand{createdAfter: timewindow.Window{From: created}},
+			expected: []runtime.RuntimeDTO{atStart, within, atEnd},
+		},
+		"created before": {
+			cmd:      RuntimeCommand{createdBefore: timewindow.Window{From: created}},
+			expected: []runtime.RuntimeDTO{before},
+		},
+		"created within window": {
+			cmd:      RuntimeCommand{createdAfter: timewindow.Window{From: created}, createdBefore: timewindow.Window{From: created.Add(2 * time.Hour)}},
+			expected: []runtime.RuntimeDTO{atStart, within},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			rp := tc.cmd.filterByCreatedAt(runtimes)
+
+			// then
+			assert.Equal(t, tc.expected, rp.Data)
+			assert.Equal(t, len(tc.expected), rp.Count)
+		})
+	}
+}
+
+func TestRuntimeCommand_FilterByExclusions(t *testing.T) {
+	// given
+	trial := runtime.RuntimeDTO{ShootName: "c-1", GlobalAccountID: "ga-1", ProviderRegion: "westeurope", ServicePlanName: trialPlan}
+	azure := runtime.RuntimeDTO{ShootName: "c-2", GlobalAccountID: "ga-1", ProviderRegion: "westeurope", ServicePlanName: azurePlan}
+	otherAccount := runtime.RuntimeDTO{ShootName: "c-3", GlobalAccountID: "ga-2", ProviderRegion: "northeurope", ServicePlanName: azurePlan}
+	runtimes := runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial, azure, otherAccount}, Count: 3, TotalCount: 3}
+
+	for name, tc := range map[string]struct {
+		cmd      RuntimeCommand
+		expected []runtime.RuntimeDTO
+	}{
+		"exclude plan": {
+			cmd:      RuntimeCommand{excludePlans: []string{trialPlan}},
+			expected: []runtime.RuntimeDTO{azure, otherAccount},
+		},
+		"exclude region and account": {
+			cmd:      RuntimeCommand{excludeRegions: []string{"northeurope"}, excludeGlobalAccountIDs: []string{"ga-1"}},
+			expected: []runtime.RuntimeDTO{},
+		},
+		"exclude account": {
+			cmd:      RuntimeCommand{excludeGlobalAccountIDs: []string{"ga-2"}},
+			expected: []runtime.RuntimeDTO{trial, azure},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			rp := tc.cmd.filterByExclusions(runtimes)
+
+			// then
+			assert.Equal(t, tc.expected, rp.Data)
+			assert.Equal(t, len(tc.expected), rp.Count)
+		})
+	}
+}
+
+func TestRuntimeCommand_FilterByProvider(t *testing.T) {
+	// given
+	trial := runtime.RuntimeDTO{ShootName: "c-1", ServicePlanName: trialPlan}
+	azure := runtime.RuntimeDTO{ShootName: "c-2", ServicePlanName: azurePlan}
+	azureLite := runtime.RuntimeDTO{ShootName: "c-3", ServicePlanName: azureLitePlan}
+	gcp := runtime.RuntimeDTO{ShootName: "c-4", ServicePlanName: gcpPlan}
+	runtimes := runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial, azure, azureLite, gcp}, Count: 4, TotalCount: 4}
+
+	for name, tc := range map[string]struct {
+		providers []string
+		expected  []runtime.RuntimeDTO
+	}{
+		"azure": {
+			providers: []string{azureProvider},
+			expected:  []runtime.RuntimeDTO{azure, azureLite},
+		},
+		"azure and gcp": {
+			providers: []string{azureProvider, gcpProvider},
+			expected:  []runtime.RuntimeDTO{azure, azureLite, gcp},
+		},
+		"gcp": {
+			providers: []string{gcpProvider},
+			expected:  []runtime.RuntimeDTO{gcp},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := RuntimeCommand{providers: tc.providers}
+
+			// when
+			rp := cmd.filterByProvider(runtimes)
+
+			// then
+			assert.Equal(t, tc.expected, rp.Data)
+			assert.Equal(t, len(tc.expected), rp.Count)
+		})
+	}
+}
+
+func TestRuntimeCommand_FilterByPlatformRegion(t *testing.T) {
+	// given
+	eu := runtime.RuntimeDTO{ShootName: "c-1", ProviderRegion: "westeurope", SubAccountRegion: "cf-eu10"}
+	us := runtime.RuntimeDTO{ShootName: "c-2", ProviderRegion: "westeurope", SubAccountRegion: "cf-us10"}
+	unknown := runtime.RuntimeDTO{ShootName: "c-3", ProviderRegion: "cf-eu10"}
+	runtimes := runtime.RuntimesPage{Data: []runtime.RuntimeDTO{eu, us, unknown}, Count: 3, TotalCount: 3}
+
+	for name, tc := range map[string]struct {
+		platformRegions []string
+		expected        []runtime.RuntimeDTO
+	}{
+		"one region": {
+			platformRegions: []string{"cf-eu10"},
+			expected:        []runtime.RuntimeDTO{eu},
+		},
+		"multiple regions": {
+			platformRegions: []string{"cf-eu10", "cf-us10"},
+			expected:        []runtime.RuntimeDTO{eu, us},
+		},
+		"provider region": {
+			platformRegions: []string{"westeurope"},
+			expected:        []runtime.RuntimeDTO{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := RuntimeCommand{platformRegions: tc.platformRegions}
+
+			// when
+			rp := cmd.filterByPlatformRegion(runtimes)
+
+			// then
+			assert.Equal(t, tc.expected, rp.Data)
+			assert.Equal(t, len(tc.expected), rp.Count)
+		})
+	}
+}
+
+func TestRuntimeCommand_FilterByKymaVersion(t *testing.T) {
+	// given
+	unknown := runtime.RuntimeDTO{ShootName: "c-1"}
+	old := runtime.RuntimeDTO{ShootName: "c-2", KymaVersion: "1.19.3"}
+	current := runtime.RuntimeDTO{ShootName: "c-3", KymaVersion: "1.20.0"}
+	pr := runtime.RuntimeDTO{ShootName: "c-4", KymaVersion: "PR-1234"}
+	runtimes := runtime.RuntimesPage{Data: []runtime.RuntimeDTO{unknown, old, current, pr}, Count: 4, TotalCount: 4}
+
+	for name, tc := range map[string]struct {
+		version  string
+		expected []runtime.RuntimeDTO
+	}{
+		"exact version": {
+			version:  "1.20.0",
+			expected: []runtime.RuntimeDTO{current},
+		},
+		"version range": {
+			version:  "<1.20",
+			expected: []runtime.RuntimeDTO{old},
+		},
+		"no matching version": {
+			version:  ">=2.0.0",
+			expected: []runtime.RuntimeDTO{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := newRuntimeCommand()
+			require.NoError(t, cmd.cobraCmd.ParseFlags([]string{"--kyma-version", tc.version}))
+			require.NoError(t, cmd.Validate())
+
+			// when
+			rp := cmd.filterByKymaVersion(runtimes)
+
+			// then
+			assert.Equal(t, tc.expected, rp.Data)
+			assert.Equal(t, len(tc.expected), rp.Count)
+		})
+	}
+}
+
+func TestRuntimeCommand_FilterByErrorContains(t *testing.T) {
+	// given
+	now := time.Now()
+	quotaFailure := runtime.RuntimeDTO{
+		ShootName: "c-1",
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{State: failed, Description: "Provisioning failed: Quota Exceeded for region", CreatedAt: now},
+		},
+	}
+	upgradeFailure := runtime.RuntimeDTO{
+		ShootName: "c-2",
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{State: succeeded, CreatedAt: now.Add(-time.Hour)},
+			UpgradingKyma: runtime.OperationsData{
+				Data:  []runtime.Operation{{State: failed, Description: "upgrade failed: quota exceeded", CreatedAt: now}},
+				Count: 1,
+			},
+		},
+	}
+	recoveredFailure := runtime.RuntimeDTO{
+		ShootName: "c-3",
+		Status: runtime.RuntimeStatus{
+			Provisioning:   &runtime.Operation{State: failed, Description: "quota exceeded", CreatedAt: now.Add(-time.Hour)},
+			Deprovisioning: &runtime.Operation{State: failed, Description: "deprovisioning timed out", CreatedAt: now},
+		},
+	}
+	succeededRuntime := runtime.RuntimeDTO{
+		ShootName: "c-4",
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{State: succeeded, Description: "quota exceeded before retry", CreatedAt: now},
+		},
+	}
+	runtimes := runtime.RuntimesPage{
+		Data:       []runtime.RuntimeDTO{quotaFailure, upgradeFailure, recoveredFailure, succeededRuntime},
+		Count:      4,
+		TotalCount: 4,
+	}
+
+	cmd := RuntimeCommand{errorContains: "QUOTA exceeded"}
+
+	// when
+	rp := cmd.filterByErrorContains(runtimes)
+
+	// then
+	assert.Equal(t, []runtime.RuntimeDTO{quotaFailure, upgradeFailure}, rp.Data)
+	assert.Equal(t, 2, rp.Count)
+	assert.Equal(t, 4, rp.TotalCount)
+}
+
+func TestRuntimeCommand_FilterByShootRegex(t *testing.T) {
+	runtimes := runtime.RuntimesPage{
+		Data: []runtime.RuntimeDTO{
+			{ShootName: "c-178e034"},
+			{ShootName: "c-2a9f"},
+			{ShootName: "test-c-178e034"},
+		},
+		Count:      3,
+		TotalCount: 3,
+	}
+
+	for name, tc := range map[string]struct {
+		pattern  string
+		expected []string
+	}{
+		"unanchored pattern": {
+			pattern:  "c-[0-9a-f]{7}",
+			expected: []string{"c-178e034", "test-c-178e034"},
+		},
+		"anchored pattern": {
+			pattern:  "^c-[0-9a-f]+$",
+			expected: []string{"c-178e034", "c-2a9f"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := RuntimeCommand{shootPattern: regexp.MustCompile(tc.pattern)}
+
+			// when
+			rp := cmd.filterByShootRegex(runtimes)
+
+			// then
+			var shoots []string
+			for _, rt := range rp.Data {
+				shoots = append(shoots, rt.ShootName)
+			}
+			assert.Equal(t, tc.expected, shoots)
+			assert.Equal(t, len(tc.expected), rp.Count)
+			assert.Equal(t, 3, rp.TotalCount)
+		})
+	}
+}
+
+func TestRuntimeCommand_ListRuntimes(t *testing.T) {
+	trial := runtime.RuntimeDTO{ShootName: "c-1", ServicePlanName: trialPlan, ZoneCount: 1}
+	lite := runtime.RuntimeDTO{ShootName: "c-2", ServicePlanName: azureLitePlan, ZoneCount: 1}
+
+	t.Run("should list runtimes with the given parameters and filter them", func(t *testing.T) {
+		// given
+		client := runtimefake.NewClient(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial, lite}, Count: 2, TotalCount: 2})
+		params := runtime.ListParameters{Plans: []string{trialPlan, azureLitePlan}}
+		cmd := RuntimeCommand{params: params, nonHA: true}
+
+		// when
+		rp, err := cmd.listRuntimes(client)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []runtime.RuntimeDTO{trial, lite}, rp.Data)
+		assert.Equal(t, []runtimefake.Call{{Method: runtimefake.ListRuntimes, Args: []interface{}{params}}}, client.Calls(""))
+	})
+
+	t.Run("should pass all filters to the client when all filters must match", func(t *testing.T) {
+		// given
+		client := runtimefake.NewClient(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial}, Count: 1, TotalCount: 1})
+		params := runtime.ListParameters{Regions: []string{"westeurope"}, Plans: []string{trialPlan}}
+		cmd := RuntimeCommand{params: params, match: matchAll}
+
+		// when
+		rp, err := cmd.listRuntimes(client)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []runtime.RuntimeDTO{trial}, rp.Data)
+		assert.Equal(t, []runtimefake.Call{{Method: runtimefake.ListRuntimes, Args: []interface{}{params}}}, client.Calls(""))
+	})
+
+	t.Run("should list all runtimes and keep the ones matching any filter", func(t *testing.T) {
+		// given
+		inRegion := runtime.RuntimeDTO{ShootName: "c-3", ServicePlanName: azurePlan, ProviderRegion: "westeurope"}
+		other := runtime.RuntimeDTO{ShootName: "c-4", ServicePlanName: gcpPlan, ProviderRegion: "europe-west4"}
+		client := runtimefake.NewClient(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial, inRegion, other}, Count: 3, TotalCount: 3})
+		params := runtime.ListParameters{Regions: []string{"westeurope"}, Plans: []string{trialPlan}, PageSize: 50}
+		cmd := RuntimeCommand{params: params, match: matchAny}
+
+		// when
+		rp, err := cmd.listRuntimes(client)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []runtime.RuntimeDTO{trial, inRegion}, rp.Data)
+		assert.Equal(t, 2, rp.Count)
+		assert.Equal(t, []runtimefake.Call{{Method: runtimefake.ListRuntimes, Args: []interface{}{runtime.ListParameters{PageSize: 50}}}}, client.Calls(""))
+	})
+
+	t.Run("should pass a single filter to the client when any filter must match", func(t *testing.T) {
+		// given
+		client := runtimefake.NewClient(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial}, Count: 1, TotalCount: 1})
+		params := runtime.ListParameters{Plans: []string{trialPlan}}
+		cmd := RuntimeCommand{params: params, match: matchAny}
+
+		// when
+		rp, err := cmd.listRuntimes(client)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []runtime.RuntimeDTO{trial}, rp.Data)
+		assert.Equal(t, []runtimefake.Call{{Method: runtimefake.ListRuntimes, Args: []interface{}{params}}}, client.Calls(""))
+	})
+
+	t.Run("should limit the number of runtimes after filtering them", func(t *testing.T) {
+		// given
+		gcp := runtime.RuntimeDTO{ShootName: "c-3", ServicePlanName: gcpPlan, ZoneCount: 3}
+		client := runtimefake.NewClient(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{gcp, trial, lite}, Count: 3, TotalCount: 3})
+		cmd := RuntimeCommand{params: runtime.ListParameters{MaxResults: 1}, nonHA: true}
+
+		// when
+		rp, err := cmd.listRuntimes(client)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []runtime.RuntimeDTO{trial}, rp.Data)
+		assert.Equal(t, 1, rp.Count)
+		assert.Equal(t, []runtimefake.Call{{Method: runtimefake.ListRuntimes, Args: []interface{}{runtime.ListParameters{}}}}, client.Calls(""))
+	})
+
+	t.Run("should pass the maximum number of runtimes to the client without filters of the listed runtimes", func(t *testing.T) {
+		// given
+		client := runtimefake.NewClient(runtime.RuntimesPage{Data: []runtime.RuntimeDTO{trial}, Count: 1, TotalCount: 2})
+		params := runtime.ListParameters{MaxResults: 1}
+		cmd := RuntimeCommand{params: params}
+
+		// when
+		rp, err := cmd.listRuntimes(client)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []runtime.RuntimeDTO{trial}, rp.Data)
+		assert.Equal(t, []runtimefake.Call{{Method: runtimefake.ListRuntimes, Args: []interface{}{params}}}, client.Calls(""))
+	})
+
+	t.Run("should return error of the client", func(t *testing.T) {
+		// given
+		client := runtimefake.NewClient().WithError(runtimefake.ListRuntimes, errors.New("service unavailable"))
+		cmd := RuntimeCommand{}
+
+		// when
+		_, err := cmd.listRuntimes(client)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "service unavailable")
+	})
+}
+
+func TestRuntimeCommand_PrintCSV(t *testing.T) {
+	// given
+	created := time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)
+	runtimes := []runtime.RuntimeDTO{
+		{
+			GlobalAccountID: "ga-1",
+			SubAccountID:    "sa-1",
+			ShootName:       "c-1",
+			ProviderRegion:  "westeurope",
+			ServicePlanName: azureLitePlan,
+			Status: runtime.RuntimeStatus{
+				CreatedAt:    created,
+				Provisioning: &runtime.Operation{State: succeeded},
+			},
+		},
+		{
+			GlobalAccountID: "ga-1",
+			SubAccountID:    "sa-2",
+			ShootName:       "c-2",
+			ProviderRegion:  "eu-west-1, zone \"a\"",
+			ServicePlanName: trialPlan,
+			Status: runtime.RuntimeStatus{
+				CreatedAt:    created.Add(time.Hour),
+				Provisioning: &runtime.Operation{State: failed},
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+
+	// when
+	err := printRuntimesCSV(buf, tableColumns, runtimes)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "GLOBALACCOUNT ID,SUBACCOUNT ID,SHOOT,REGION,PLAN,CREATED AT,STATE,DURATION\n"+
+		"ga-1,sa-1,c-1,westeurope,azure_lite,2021/01/13 10:00:00,succeeded,\n"+
+		"ga-1,sa-2,c-2,\"eu-west-1, zone \"\"a\"\"\",trial,2021/01/13 11:00:00,failed (provision),\n", buf.String())
+}
+
+func TestElapsedDuration(t *testing.T) {
+	now := time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)
+	for name, tc := range map[string]struct {
+		op       runtime.Operation
+		expected string
+	}{
+		"finished operation": {
+			op:       runtime.Operation{State: succeeded, CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Hour + 12*time.Minute + 3*time.Second)},
+			expected: "12m3s",
+		},
+		"failed operation": {
+			op:       runtime.Operation{State: failed, CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-30 * time.Minute)},
+			expected: "30m0s",
+		},
+		"operation in progress": {
+			op:       runtime.Operation{State: inProgress, CreatedAt: now.Add(-5*time.Minute - 400*time.Millisecond), UpdatedAt: now.Add(-4 * time.Minute)},
+			expected: "5m0s",
+		},
+		"finished operation without update time": {
+			op:       runtime.Operation{State: succeeded, CreatedAt: now.Add(-time.Hour)},
+			expected: "",
+		},
+		"operation without creation time": {
+			op:       runtime.Operation{State: inProgress},
+			expected: "",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, elapsedDuration(tc.op, now))
+		})
+	}
+}
+
+func TestExplainLastOperation(t *testing.T) {
+	now := time.Now()
+	provisioning := &runtime.Operation{State: succeeded, CreatedAt: now.Add(-4 * time.Hour)}
+	upgrade := runtime.OperationsData{Data: []runtime.Operation{{State: inProgress, CreatedAt: now.Add(-3 * time.Hour)}}, Count: 1}
+	suspension := runtime.OperationsData{Data: []runtime.Operation{{State: succeeded, CreatedAt: now.Add(-2 * time.Hour)}}, Count: 1}
+	unsuspension := runtime.OperationsData{Data: []runtime.Operation{{State: inProgress, CreatedAt: now.Add(-time.Hour)}}, Count: 1}
+	deprovisioning := &runtime.Operation{State: failed, CreatedAt: now}
+
+	for name, tc := range map[string]struct {
+		status         runtime.RuntimeStatus
+		expectedType   operationType
+		expectedState  string
+		expectedReason string
+	}{
+		"provision": {
+			status:         runtime.RuntimeStatus{Provisioning: provisioning},
+			expectedType:   provision,
+			expectedState:  succeeded,
+			expectedReason: "no upgrade, suspension, unsuspension, or deprovisioning operation overrides the provisioning",
+		},
+		"upgrade": {
+			status:         runtime.RuntimeStatus{Provisioning: provisioning, UpgradingKyma: upgrade},
+			expectedType:   upgradeKyma,
+			expectedState:  inProgress,
+			expectedReason: "the latest kyma upgrade overrides the provisioning",
+		},
+		"suspension": {
+			status:         runtime.RuntimeStatus{Provisioning: provisioning, UpgradingKyma: upgrade, Suspension: suspension},
+			expectedType:   suspension,
+			expectedState:  succeeded,
+			expectedReason: "the latest suspension was created after the kyma upgrade",
+		},
+		"unsuspension": {
+			status:         runtime.RuntimeStatus{Provisioning: provisioning, Suspension: suspension, Unsuspension: unsuspension},
+			expectedType:   unsuspension,
+			expectedState:  inProgress,
+			expectedReason: "the latest unsuspension was created after the provision",
+		},
+		"deprovision": {
+			status:         runtime.RuntimeStatus{Provisioning: provisioning, Suspension: suspension, Deprovisioning: deprovisioning},
+			expectedType:   deprovision,
+			expectedState:  failed,
+			expectedReason: "the deprovisioning was created after the suspension",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			rt := runtime.RuntimeDTO{Status: tc.status}
+
+			// when
+			op, opType, reason := explainLastOperation(rt)
+
+			// then
+			assert.Equal(t, tc.expectedType, opType)
+			assert.Equal(t, tc.expectedState, op.State)
+			assert.Equal(t, tc.expectedReason, reason)
+			assert.Equal(t, string(tc.expectedType), lastOperationType(rt))
+			assert.Equal(t, tc.expectedReason, lastOperationReason(rt))
+		})
+	}
+}
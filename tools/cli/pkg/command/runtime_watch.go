@@ -0,0 +1,146 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/pkg/errors"
+)
+
+// watchTimeFormat is the format of the timestamps printed in front of the changes in the watch mode
+const watchTimeFormat = "2006/01/02 15:04:05"
+
+// clearScreen moves the cursor to the top left corner of the terminal and clears the screen
+const clearScreen = "\033[H\033[2J"
+
+// watchChange describes a Runtime added, removed, or changed between two subsequent polls
+type watchChange struct {
+	kind     runtimeChangeKind
+	previous runtime.RuntimeDTO
+	current  runtime.RuntimeDTO
+}
+
+// runWatch polls the Runtimes in the given interval and prints all of them after every poll until the command context is canceled.
+// The table output replaces the previous one on the screen, while the JSON output appends one document per line.
+func (cmd *RuntimeCommand) runWatch(client runtime.Client) error {
+	ctx := cmd.cobraCmd.Context()
+	ticker := time.NewTicker(cmd.watchInterval)
+	defer ticker.Stop()
+
+	now := time.Now()
+	printed := false
+	for {
+		rp, err := cmd.listRuntimes(client)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			return errors.Wrap(err, "while watching runtimes")
+		}
+		if !cmd.skipWatchRefresh(rp, printed) {
+			if cmd.sortColumn != nil {
+				if err := cmd.sortRuntimes(rp.Data); err != nil {
+					return errors.Wrap(err, "while sorting runtimes")
+				}
+			}
+			if err := cmd.printWatchRefresh(rp, now); err != nil {
+				return errors.Wrap(err, "while printing runtimes")
+			}
+			printed = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case now = <-ticker.C:
+		}
+	}
+}
+
+// skipWatchRefresh returns true if the table displayed after the previous poll is still up to date, because KEB reported that the Runtimes did not change.
+// The other outputs display the Runtimes after every poll, so that their consumers can rely on one document or set of lines per poll.
+func (cmd *RuntimeCommand) skipWatchRefresh(runtimes runtime.RuntimesPage, printed bool) bool {
+	return printed && runtimes.NotModified && cmd.output == tableOutput
+}
+
+func (cmd *RuntimeCommand) printWatchRefresh(runtimes runtime.RuntimesPage, now time.Time) error {
+	switch cmd.output {
+	case jsonOutput:
+		return json.NewEncoder(os.Stdout).Encode(runtimes)
+	case jsonLinesOutput:
+		return cmd.printRuntimes(runtimes)
+	}
+	writeWatchHeader(os.Stdout, cmd.watchInterval, now)
+	return cmd.printRuntimes(runtimes)
+}
+
+func writeWatchHeader(w io.Writer, interval time.Duration, now time.Time) {
+	fmt.Fprintf(w, "%sEvery %s: kcp runtimes, refreshed at %s\n\n", clearScreen, interval, now.Format(watchTimeFormat))
+}
+
+// runWatchDiff polls the Runtimes in the given interval and prints only the Runtimes which changed since the previous poll
+func (cmd *RuntimeCommand) runWatchDiff(client runtime.Client, previous runtime.RuntimesPage) error {
+	ctx := cmd.cobraCmd.Context()
+	ticker := time.NewTicker(cmd.watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current, err := cmd.listRuntimes(client)
+			if err != nil {
+				return errors.Wrap(err, "while watching runtimes")
+			}
+			writeWatchChanges(os.Stdout, now, detectWatchChanges(previous.Data, current.Data))
+			previous = current
+		}
+	}
+}
+
+// detectWatchChanges returns the Runtimes which were added, removed, or are not equal to their previous version
+func detectWatchChanges(previous, current []runtime.RuntimeDTO) []watchChange {
+	previousByID := make(map[string]runtime.RuntimeDTO, len(previous))
+	for _, rt := range previous {
+		previousByID[rt.InstanceID] = rt
+	}
+
+	var changes []watchChange
+	seen := make(map[string]struct{}, len(current))
+	for _, rt := range current {
+		seen[rt.InstanceID] = struct{}{}
+		old, found := previousByID[rt.InstanceID]
+		switch {
+		case !found:
+			changes = append(changes, watchChange{kind: runtimeAdded, current: rt})
+		case !runtime.RuntimeEqual(old, rt):
+			changes = append(changes, watchChange{kind: runtimeChanged, previous: old, current: rt})
+		}
+	}
+	for _, rt := range previous {
+		if _, found := seen[rt.InstanceID]; !found {
+			changes = append(changes, watchChange{kind: runtimeRemoved, previous: rt})
+		}
+	}
+
+	return changes
+}
+
+func writeWatchChanges(w io.Writer, now time.Time, changes []watchChange) {
+	timestamp := now.Format(watchTimeFormat)
+	for _, c := range changes {
+		switch c.kind {
+		case runtimeAdded:
+			fmt.Fprintf(w, "%s %s %s (%s) %s\n", timestamp, c.kind, c.current.ShootName, c.current.InstanceID, runtimeStatus(c.current))
+		case runtimeRemoved:
+			fmt.Fprintf(w, "%s %s %s (%s)\n", timestamp, c.kind, c.previous.ShootName, c.previous.InstanceID)
+		case runtimeChanged:
+			fmt.Fprintf(w, "%s %s %s (%s) %s -> %s\n", timestamp, c.kind, c.current.ShootName, c.current.InstanceID, runtimeStatus(c.previous), runtimeStatus(c.current))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime/runtimefake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectWatchChanges(t *testing.T) {
+	// given
+	rt1 := fixWatchRuntime("id-1", inProgress)
+	rt2 := fixWatchRuntime("id-2", succeeded)
+	rt1Succeeded := fixWatchRuntime("id-1", succeeded)
+	rt3 := fixWatchRuntime("id-3", inProgress)
+	snapshots := [][]runtime.RuntimeDTO{
+		{rt1, rt2},
+		{rt1, rt2},
+		{rt1Succeeded, rt2},
+		{rt1Succeeded, rt3},
+	}
+	expected := [][]watchChange{
+		nil,
+		{{kind: runtimeChanged, previous: rt1, current: rt1Succeeded}},
+		{{kind: runtimeAdded, current: rt3}, {kind: runtimeRemoved, previous: rt2}},
+	}
+
+	for i := 1; i < len(snapshots); i++ {
+		// when
+		changes := detectWatchChanges(snapshots[i-1], snapshots[i])
+
+		// then
+		assert.Equal(t, expected[i-1], changes, "poll %d", i)
+	}
+}
+
+func TestWriteWatchChanges(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+	now := time.Date(2020, 11, 10, 9, 8, 7, 0, time.UTC)
+	changes := []watchChange{
+		{kind: runtimeChanged, previous: fixWatchRuntime("id-1", inProgress), current: fixWatchRuntime("id-1", succeeded)},
+		{kind: runtimeAdded, current: fixWatchRuntime("id-3", inProgress)},
+		{kind: runtimeRemoved, previous: fixWatchRuntime("id-2", succeeded)},
+	}
+
+	// when
+	writeWatchChanges(buf, now, changes)
+
+	// then
+	assert.Equal(t, "2020/11/10 09:08:07 ~ shoot-id-1 (id-1) provisioning -> succeeded\n"+
+		"2020/11/10 09:08:07 + shoot-id-3 (id-3) provisioning\n"+
+		"2020/11/10 09:08:07 - shoot-id-2 (id-2)\n", buf.String())
+}
+
+func TestWriteWatchHeader(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+	now := time.Date(2020, 11, 10, 9, 8, 7, 0, time.UTC)
+
+	// when
+	writeWatchHeader(buf, 10*time.Second, now)
+
+	// then
+	assert.Equal(t, clearScreen+"Every 10s: kcp runtimes, refreshed at 2020/11/10 09:08:07\n\n", buf.String())
+}
+
+func TestSkipWatchRefresh(t *testing.T) {
+	for name, tc := range map[string]struct {
+		output      string
+		printed     bool
+		notModified bool
+		expected    bool
+	}{
+		"unchanged table":             {output: tableOutput, printed: true, notModified: true, expected: true},
+		"changed table":               {output: tableOutput, printed: true, notModified: false, expected: false},
+		"first table":                 {output: tableOutput, printed: false, notModified: true, expected: false},
+		"unchanged json":              {output: jsonOutput, printed: true, notModified: true, expected: false},
+		"unchanged json lines output": {output: jsonLinesOutput, printed: true, notModified: true, expected: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			cmd := RuntimeCommand{output: tc.output}
+
+			// when
+			skip := cmd.skipWatchRefresh(runtime.RuntimesPage{NotModified: tc.notModified}, tc.printed)
+
+			// then
+			assert.Equal(t, tc.expected, skip)
+		})
+	}
+}
+
+func TestListRuntimes_NotModified(t *testing.T) {
+	// given
+	client := runtimefake.NewClient(runtime.RuntimesPage{
+		Data:        []runtime.RuntimeDTO{fixWatchRuntime("id-1", "succeeded"), fixWatchRuntime("id-2", "failed")},
+		Count:       2,
+		TotalCount:  2,
+		NotModified: true,
+	})
+	cmd := RuntimeCommand{params: runtime.ListParameters{States: []string{"failed"}}}
+
+	// when
+	rp, err := cmd.listRuntimes(client)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, rp.NotModified)
+	require.Len(t, rp.Data, 1)
+	assert.Equal(t, "id-2", rp.Data[0].InstanceID)
+}
+
+func fixWatchRuntime(id, state string) runtime.RuntimeDTO {
+	return runtime.RuntimeDTO{
+		InstanceID: id,
+		ShootName:  "shoot-" + id,
+		Status: runtime.RuntimeStatus{
+			Provisioning: &runtime.Operation{
+				State: state,
+			},
+		},
+	}
+}
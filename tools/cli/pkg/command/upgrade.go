@@ -41,8 +41,11 @@ func NewUpgradeCmd() *cobra.Command {
 // SetUpgradeOpts configures the upgrade specific options on the given command
 func (cmd *UpgradeCommand) SetUpgradeOpts(cobraCmd *cobra.Command) {
 	SetRuntimeTargetOpts(cobraCmd, &cmd.targetInputs, &cmd.targetExcludeInputs)
-	cobraCmd.Flags().StringVar(&cmd.strategy, "strategy", string(orchestration.ParallelStrategy), "Orchestration strategy to use.")
+	cobraCmd.Flags().StringVar(&cmd.strategy, "strategy", string(orchestration.ParallelStrategy), "Orchestration strategy to use. Possible values: \"parallel\", \"canary\".")
 	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.Parallel.Workers, "parallel-workers", 0, "Number of parallel workers to use in parallel orchestration strategy. By default the amount of workers will be auto-selected on control plane server side.")
+	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.Canary.Size, "canary-size", 0, "Number of canary Runtimes to upgrade first in canary orchestration strategy. By default one canary Runtime is used.")
+	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.Canary.Percentage, "canary-percentage", 0, "Percentage of canary Runtimes to upgrade first in canary orchestration strategy. Used when --canary-size is not set.")
+	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.Canary.MaxFailures, "canary-max-failures", 0, "Number of failed canary upgrade operations for which the rest of the Runtimes is still upgraded in canary orchestration strategy.")
 	cobraCmd.Flags().StringVar(&cmd.schedule, "schedule", "", "Orchestration schedule to use. Possible values: \"immediate\", \"maintenancewindow\". By default the schedule will be auto-selected on control plane server side.")
 	cobraCmd.Flags().BoolVar(&cmd.orchestrationParams.DryRun, "dry-run", false, "Perform the orchestration without executing the actual upgrage operations for the Runtimes. The details can be obtained using the \"kcp orchestrations\" command.")
 }
@@ -63,11 +66,19 @@ func (cmd *UpgradeCommand) ValidateTransformUpgradeOpts() error {
 
 	// Validate strategy type
 	switch cmd.strategy {
-	case string(orchestration.ParallelStrategy):
+	case string(orchestration.ParallelStrategy), string(orchestration.CanaryStrategy):
 		cmd.orchestrationParams.Strategy.Type = orchestration.StrategyType(cmd.strategy)
 	default:
 		return fmt.Errorf("invalid value for strategy: %s", cmd.strategy)
 	}
 
+	canary := cmd.orchestrationParams.Strategy.Canary
+	if canary != (orchestration.CanaryStrategySpec{}) && cmd.orchestrationParams.Strategy.Type != orchestration.CanaryStrategy {
+		return fmt.Errorf("the canary options can only be used with the %s strategy", orchestration.CanaryStrategy)
+	}
+	if canary.Percentage < 0 || canary.Percentage > 100 {
+		return fmt.Errorf("invalid value for canary-percentage: %d. The value must be between 0 and 100", canary.Percentage)
+	}
+
 	return nil
 }
@@ -41,7 +41,7 @@ The Kyma version and configurations to use for the upgrade are taken from Kyma C
 // Run executes the upgrade kyma command
 func (cmd *UpgradeKymaCommand) Run() error {
 	cmd.log = logger.New()
-	client := orchestration.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+	client := orchestration.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLITokenSource(cmd.log))
 	ur, err := client.UpgradeKyma(cmd.orchestrationParams)
 	if err != nil {
 		return errors.Wrap(err, "while triggering kyma upgrade")
@@ -0,0 +1,68 @@
+package credential
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// DefaultRefreshAttempts is the number of attempts to refresh the token before the request fails
+	DefaultRefreshAttempts = 3
+	// DefaultRefreshBackoff is the wait time after the first failed refresh attempt, which doubles after each subsequent failure
+	DefaultRefreshBackoff = 2 * time.Second
+)
+
+type refreshingTokenSource struct {
+	source   oauth2.TokenSource
+	attempts int
+	backoff  time.Duration
+	log      logger.Logger
+	token    *oauth2.Token
+	mux      sync.Mutex
+}
+
+// NewRefreshingTokenSource returns an oauth2.TokenSource which caches the token of the given source until it expires.
+// The token is refreshed under a lock, so the requests issued meanwhile wait for the refresh instead of triggering their own.
+// A failed refresh is retried with exponential backoff, and only the last failure is returned to the requests.
+func NewRefreshingTokenSource(source oauth2.TokenSource, attempts int, backoff time.Duration, log logger.Logger) oauth2.TokenSource {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return &refreshingTokenSource{
+		source:   source,
+		attempts: attempts,
+		backoff:  backoff,
+		log:      log,
+	}
+}
+
+// Token returns the cached token if it is still valid, or refreshes it otherwise
+func (s *refreshingTokenSource) Token() (*oauth2.Token, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	wait := s.backoff
+	var err error
+	for attempt := 1; attempt <= s.attempts; attempt++ {
+		var token *oauth2.Token
+		token, err = s.source.Token()
+		if err == nil {
+			s.token = token
+			return token, nil
+		}
+		if attempt < s.attempts {
+			s.log.Warnf("token refresh attempt %d of %d failed: %v, retrying in %v", attempt, s.attempts, err, wait)
+			time.Sleep(wait)
+			wait *= 2
+		}
+	}
+
+	return nil, errors.Wrapf(err, "while refreshing token after %d attempts", s.attempts)
+}
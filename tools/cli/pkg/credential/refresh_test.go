@@ -0,0 +1,109 @@
+package credential
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// flakyTokenSource fails the configured number of first token requests and then returns tokens valid for one hour
+type flakyTokenSource struct {
+	failures int
+	calls    int
+	mux      sync.Mutex
+}
+
+func (s *flakyTokenSource) Token() (*oauth2.Token, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, errors.New("identity provider unavailable")
+	}
+	return &oauth2.Token{AccessToken: fmt.Sprintf("token-%d", s.calls), Expiry: time.Now().Add(time.Hour)}, nil
+}
+
+func TestRefreshingTokenSource_Token(t *testing.T) {
+	t.Run("should retry failed refresh and cache the token", func(t *testing.T) {
+		// given
+		source := &flakyTokenSource{failures: 1}
+		ts := NewRefreshingTokenSource(source, DefaultRefreshAttempts, time.Millisecond, logger.New())
+
+		// when
+		first, err := ts.Token()
+		require.NoError(t, err)
+		second, err := ts.Token()
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "token-2", first.AccessToken)
+		assert.Equal(t, first, second)
+		assert.Equal(t, 2, source.calls)
+	})
+
+	t.Run("should fail after all attempts failed", func(t *testing.T) {
+		// given
+		source := &flakyTokenSource{failures: DefaultRefreshAttempts}
+		ts := NewRefreshingTokenSource(source, DefaultRefreshAttempts, time.Millisecond, logger.New())
+
+		// when
+		_, err := ts.Token()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "identity provider unavailable")
+		assert.Equal(t, DefaultRefreshAttempts, source.calls)
+	})
+}
+
+func TestRefreshingTokenSource_BulkRequests(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	source := &flakyTokenSource{failures: 1}
+	client := oauth2.NewClient(context.Background(), NewRefreshingTokenSource(source, DefaultRefreshAttempts, time.Millisecond, logger.New()))
+
+	// when
+	const requests = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				errs <- fmt.Errorf("unexpected status %d", resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// then
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, source.calls)
+}
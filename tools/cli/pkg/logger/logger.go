@@ -1,24 +1,122 @@
 package logger
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/int128/kubelogin/pkg/adaptors/logger"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 )
 
+// Formats of the log entries
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// errorChainKey is the field of the JSON log entries holding the messages of the error and of all errors wrapped in it
+const errorChainKey = "errorChain"
+
 // CfgLevel is the configured logging level
 var CfgLevel int
 
-// New returns a Logger with the standard log.Logger
+// CfgFormat is the configured format of the log entries
+var CfgFormat = TextFormat
+
+// New returns a Logger with the standard log.Logger, which writes the entries in the configured format
 func New() Logger {
-	log := logrus.New()
+	return newLogger(os.Stderr, CfgFormat)
+}
+
+// NewJSON returns a Logger which writes structured JSON entries with the level, timestamp, message, and fields, regardless of the configured format
+func NewJSON() Logger {
+	return newLogger(os.Stderr, JSONFormat)
+}
+
+func newLogger(output io.Writer, format string) Logger {
+	log := newLogrus(output, format)
 	log.Level = logrus.Level(CfgLevel)
+	// the verbose messages are gated only by the verbosity, so they are displayed also below the info level of logrus
+	verboseLog := newLogrus(output, format)
+	verboseLog.Level = logrus.InfoLevel
 	return &logging{
 		FieldLogger: log,
+		verboseLog:  verboseLog,
 		verbosity:   CfgLevel,
 	}
 }
 
+func newLogrus(output io.Writer, format string) *logrus.Logger {
+	log := logrus.New()
+	log.Out = output
+	if format == JSONFormat {
+		log.Formatter = &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+			FieldMap: logrus.FieldMap{
+				logrus.FieldKeyTime: "timestamp",
+				logrus.FieldKeyMsg:  "message",
+			},
+		}
+		log.AddHook(errorChainHook{})
+	}
+	return log
+}
+
+// ReportError writes the error returned by a command to the standard error output regardless of the verbosity.
+// With the json format, the error is written as a structured entry with the chain of its wrapped errors, otherwise as the Error: line.
+func ReportError(err error) {
+	reportError(os.Stderr, CfgFormat, err)
+}
+
+func reportError(output io.Writer, format string, err error) {
+	if format != JSONFormat {
+		fmt.Fprintln(output, "Error:", err.Error())
+		return
+	}
+	log := newLogrus(output, format)
+	log.Level = logrus.ErrorLevel
+	log.WithError(err).Error("command failed")
+}
+
+// errorChainHook adds the messages of the wrapped errors to the entries logged with an error, so that they can be searched separately
+type errorChainHook struct{}
+
+func (errorChainHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (errorChainHook) Fire(entry *logrus.Entry) error {
+	if err, ok := entry.Data[logrus.ErrorKey].(error); ok {
+		entry.Data[errorChainKey] = errorChain(err)
+	}
+	return nil
+}
+
+// errorChain returns the message of each error in the chain from the outermost one, without the messages of the errors it wraps,
+// e.g. [while listing runtimes, connection refused] for errors.Wrap(err, "while listing runtimes"). The errors which only add a stack trace are skipped.
+func errorChain(err error) []string {
+	var chain []string
+	for err != nil {
+		next := errors.Unwrap(err)
+		msg := err.Error()
+		if next != nil {
+			if msg == next.Error() {
+				err = next
+				continue
+			}
+			msg = strings.TrimSuffix(msg, ": "+next.Error())
+		}
+		chain = append(chain, msg)
+		err = next
+	}
+	return chain
+}
+
 // Logger is the interface to interact with a CLI logging instance
 type Logger interface {
 	logrus.FieldLogger
@@ -30,7 +128,8 @@ type Logger interface {
 // logging provides logging facility using log.Logger and klog.
 type logging struct {
 	logrus.FieldLogger
-	verbosity int
+	verboseLog logrus.FieldLogger
+	verbosity  int
 }
 
 type verbose struct {
@@ -44,7 +143,33 @@ func (l *logging) AddFlags(f *pflag.FlagSet) {
 }
 
 func AddFlags(f *pflag.FlagSet) {
-	f.IntVarP(&CfgLevel, "verbose", "v", 0, "Option that turns verbose logging to stderr. Valid values are 0 (default) - 6 (maximum verbosity).")
+	f.IntVarP(&CfgLevel, "verbose", "v", 0, "Option that turns verbose logging to stderr. Valid values are 0 (default) - 6 (maximum verbosity). The kcp runtimes command displays the numbers of the listed Runtimes from 1, their filter parameters from 2, and the URLs of the requests to Kyma Environment Broker from 3.")
+	f.Var(&formatValue{format: &CfgFormat}, "log-format", fmt.Sprintf("Format of the log entries and of the error of the command written to stderr. The possible values are: %s, %s. With %s, each entry is a JSON document with the level, timestamp, message, and fields, and the messages of the wrapped errors are listed in the %s field.", TextFormat, JSONFormat, JSONFormat, errorChainKey))
+}
+
+// formatValue is the value of the --log-format option, which accepts only the supported formats
+type formatValue struct {
+	format *string
+}
+
+func (v *formatValue) String() string {
+	if v.format == nil {
+		return ""
+	}
+	return *v.format
+}
+
+func (v *formatValue) Set(value string) error {
+	switch value {
+	case TextFormat, JSONFormat:
+		*v.format = value
+		return nil
+	}
+	return fmt.Errorf("the possible values are: %s, %s", TextFormat, JSONFormat)
+}
+
+func (v *formatValue) Type() string {
+	return "string"
 }
 
 // V returns a logger enabled only if the level is enabled.
@@ -61,6 +186,6 @@ func (l *logging) IsEnabled(level int) bool {
 // Infof logs a verbose info message with he given format and arguments based on the configured verbosity
 func (v *verbose) Infof(format string, args ...interface{}) {
 	if v.l.verbosity >= v.level {
-		v.l.Infof(format, args...)
+		v.l.verboseLog.Infof(format, args...)
 	}
 }
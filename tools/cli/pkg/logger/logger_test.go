@@ -0,0 +1,177 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogger_JSONFormat(t *testing.T) {
+	// given
+	defer func(level int) { CfgLevel = level }(CfgLevel)
+	CfgLevel = int(logrus.InfoLevel)
+	buf := &bytes.Buffer{}
+	log := newLogger(buf, JSONFormat)
+
+	// when
+	log.WithField("runtimeID", "rt-1").Infof("fetched %d runtimes", 2)
+
+	// then
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Len(t, entry, 4)
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "fetched 2 runtimes", entry["message"])
+	assert.Equal(t, "rt-1", entry["runtimeID"])
+	timestamp, ok := entry["timestamp"].(string)
+	require.True(t, ok)
+	_, err := time.Parse(time.RFC3339Nano, timestamp)
+	assert.NoError(t, err)
+}
+
+func TestNewLogger_JSONFormatWithError(t *testing.T) {
+	// given
+	defer func(level int) { CfgLevel = level }(CfgLevel)
+	CfgLevel = int(logrus.InfoLevel)
+	buf := &bytes.Buffer{}
+	log := newLogger(buf, JSONFormat)
+	err := errors.Wrap(errors.Wrap(errors.New("connection refused"), "while calling Kyma Environment Broker"), "while listing runtimes")
+
+	// when
+	log.WithError(err).Error("command failed")
+
+	// then
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "error", entry["level"])
+	assert.Equal(t, "command failed", entry["message"])
+	assert.Equal(t, "while listing runtimes: while calling Kyma Environment Broker: connection refused", entry[logrus.ErrorKey])
+	assert.Equal(t, []interface{}{"while listing runtimes", "while calling Kyma Environment Broker", "connection refused"}, entry[errorChainKey])
+}
+
+func TestVerbose(t *testing.T) {
+	for name, tc := range map[string]struct {
+		verbosity int
+		level     int
+		expected  bool
+	}{
+		"quiet by default":         {verbosity: 0, level: 1, expected: false},
+		"enabled level":            {verbosity: 1, level: 1, expected: true},
+		"higher level":             {verbosity: 1, level: 3, expected: false},
+		"lower level":              {verbosity: 3, level: 2, expected: true},
+		"above logrus info levels": {verbosity: 6, level: 3, expected: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			defer func(level int) { CfgLevel = level }(CfgLevel)
+			CfgLevel = tc.verbosity
+			buf := &bytes.Buffer{}
+			log := newLogger(buf, TextFormat)
+
+			// when
+			log.V(tc.level).Infof("fetched %d runtimes", 2)
+
+			// then
+			assert.Equal(t, tc.expected, log.IsEnabled(tc.level))
+			if tc.expected {
+				assert.Contains(t, buf.String(), "fetched 2 runtimes")
+			} else {
+				assert.Empty(t, buf.String())
+			}
+		})
+	}
+}
+
+func TestNewLogger_InfoBelowVerbosity(t *testing.T) {
+	// given
+	defer func(level int) { CfgLevel = level }(CfgLevel)
+	CfgLevel = 3
+	buf := &bytes.Buffer{}
+	log := newLogger(buf, TextFormat)
+
+	// when
+	log.Infof("not verbose")
+
+	// then
+	assert.Empty(t, buf.String())
+}
+
+func TestReportError(t *testing.T) {
+	err := errors.Wrap(errors.New("connection refused"), "while listing runtimes")
+
+	t.Run("should report error in text format", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+
+		// when
+		reportError(buf, TextFormat, err)
+
+		// then
+		assert.Equal(t, "Error: while listing runtimes: connection refused\n", buf.String())
+	})
+
+	t.Run("should report error in json format regardless of verbosity", func(t *testing.T) {
+		// given
+		defer func(level int) { CfgLevel = level }(CfgLevel)
+		CfgLevel = 0
+		buf := &bytes.Buffer{}
+
+		// when
+		reportError(buf, JSONFormat, err)
+
+		// then
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, "error", entry["level"])
+		assert.Equal(t, []interface{}{"while listing runtimes", "connection refused"}, entry[errorChainKey])
+	})
+}
+
+func TestErrorChain(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err      error
+		expected []string
+	}{
+		"plain error": {
+			err:      errors.New("connection refused"),
+			expected: []string{"connection refused"},
+		},
+		"wrapped error": {
+			err:      errors.Wrap(errors.New("connection refused"), "while listing runtimes"),
+			expected: []string{"while listing runtimes", "connection refused"},
+		},
+		"error with stack only": {
+			err:      errors.WithStack(errors.New("connection refused")),
+			expected: []string{"connection refused"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			chain := errorChain(tc.err)
+
+			// then
+			assert.Equal(t, tc.expected, chain)
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	// given
+	format := TextFormat
+	value := formatValue{format: &format}
+
+	// when
+	err := value.Set(JSONFormat)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, JSONFormat, format)
+	assert.Error(t, value.Set("xml"))
+	assert.Equal(t, JSONFormat, format)
+}
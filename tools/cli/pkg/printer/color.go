@@ -0,0 +1,25 @@
+package printer
+
+// Color is the ANSI escape sequence which sets the foreground color of the text
+type Color string
+
+// All colors have the same length, so the tabwriter which counts the escape sequences as text still aligns the colored columns
+const (
+	ColorDefault Color = "\x1b[39m"
+	ColorRed     Color = "\x1b[31m"
+	ColorGreen   Color = "\x1b[32m"
+	ColorYellow  Color = "\x1b[33m"
+
+	colorReset = "\x1b[0m"
+)
+
+// ColorFunc returns the color in which the given value of a column is displayed
+type ColorFunc func(value string) Color
+
+// colorize wraps the value in the escape sequences of the given color, the ColorDefault is used if the color is empty
+func colorize(value string, color Color) string {
+	if color == "" {
+		color = ColorDefault
+	}
+	return string(color) + value + colorReset
+}
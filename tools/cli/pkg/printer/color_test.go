@@ -0,0 +1,60 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTablePrinter_Colors(t *testing.T) {
+	// given
+	columns := []Column{
+		{Header: "NAME", FieldSpec: "{.Name}"},
+		{
+			Header:         "STATE",
+			FieldFormatter: func(obj interface{}) string { return obj.(testObj).Status.State },
+			Color: func(value string) Color {
+				if value == "failed" {
+					return ColorRed
+				}
+				return ""
+			},
+		},
+	}
+	objs := []testObj{
+		{Name: "first", Status: testStatus{State: "failed"}},
+		{Name: "second", Status: testStatus{State: "succeeded"}},
+	}
+
+	t.Run("should colorize values when colors are enabled", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+		printer, err := newTablePrinter(buf, columns, false, true)
+		require.NoError(t, err)
+
+		// when
+		err = printer.PrintObj(objs)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "\x1b[39mSTATE\x1b[0m")
+		assert.Contains(t, buf.String(), "\x1b[31mfailed\x1b[0m")
+		assert.Contains(t, buf.String(), "\x1b[39msucceeded\x1b[0m")
+	})
+
+	t.Run("should not colorize values when colors are disabled", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+		printer, err := newTablePrinter(buf, columns, false, false)
+		require.NoError(t, err)
+
+		// when
+		err = printer.PrintObj(objs)
+
+		// then
+		require.NoError(t, err)
+		assert.NotContains(t, buf.String(), "\x1b[")
+	})
+}
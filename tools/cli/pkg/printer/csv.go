@@ -0,0 +1,73 @@
+package printer
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+)
+
+// CSVPrinter prints objects as comma-separated values, according to the given column definitions.
+// The values are quoted as described in RFC 4180.
+type CSVPrinter interface {
+	PrintObj(obj interface{}) error
+}
+
+type csvPrinter struct {
+	writer         *csv.Writer
+	columns        []Column
+	headersPrinted bool
+}
+
+// NewCSVPrinter creates a new CSVPrinter.
+// The parameter columns holds the non-empty list of Column specifications, the headers of which are printed in the first row.
+func NewCSVPrinter(columns []Column) (CSVPrinter, error) {
+	return NewCSVPrinterTo(os.Stdout, columns)
+}
+
+// NewCSVPrinterTo creates a new CSVPrinter which writes to the given output instead of the standard output.
+func NewCSVPrinterTo(output io.Writer, columns []Column) (CSVPrinter, error) {
+	c := &csvPrinter{
+		writer:  csv.NewWriter(output),
+		columns: columns,
+	}
+	if err := parseColumns(c.columns); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *csvPrinter) PrintObj(obj interface{}) error {
+	defer c.writer.Flush()
+
+	if !c.headersPrinted {
+		headers := make([]string, 0, len(c.columns))
+		for idx := range c.columns {
+			headers = append(headers, c.columns[idx].Header)
+		}
+		if err := c.writer.Write(headers); err != nil {
+			return err
+		}
+		c.headersPrinted = true
+	}
+
+	objs := []interface{}{obj}
+	if isSlice(obj) {
+		objs = toInterfaceSlice(obj)
+	}
+	for _, o := range objs {
+		record := make([]string, 0, len(c.columns))
+		for idx := range c.columns {
+			value, err := c.columns[idx].format(o)
+			if err != nil {
+				return err
+			}
+			record = append(record, value)
+		}
+		if err := c.writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return c.writer.Error()
+}
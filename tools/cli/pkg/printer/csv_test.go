@@ -0,0 +1,31 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVPrinter_PrintObj(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+	columns := []Column{
+		{Header: "NAME", FieldSpec: "{.Name}"},
+		{Header: "MESSAGE", FieldSpec: "{.Status.Message}"},
+		{Header: "STATE", FieldFormatter: func(obj interface{}) string { return obj.(testObj).Status.State }},
+	}
+	printer, err := NewCSVPrinterTo(buf, columns)
+	require.NoError(t, err)
+
+	// when
+	err = printer.PrintObj([]testObj{
+		{Name: "first", Status: testStatus{State: "succeeded", Message: "done"}},
+		{Name: "second", Status: testStatus{State: "failed", Message: `quota "cpu" exceeded, retrying`}},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "NAME,MESSAGE,STATE\nfirst,done,succeeded\nsecond,\"quota \"\"cpu\"\" exceeded, retrying\",failed\n", buf.String())
+}
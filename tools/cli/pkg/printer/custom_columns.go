@@ -0,0 +1,99 @@
+package printer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// simpleFieldPath matches JSONPath expressions which reference a single field, e.g. {.Status.Provisioning.State}
+var simpleFieldPath = regexp.MustCompile(`^\{(\.[A-Za-z0-9_-]+)+\}$`)
+
+// ParseCustomColumns parses the custom columns specification in the HEADER1:FIELDSPEC1,HEADER2:FIELDSPEC2 format.
+// A FieldSpec can be provided either as a JSONPath expression in curly braces (e.g. {.ShootName}) or without them (e.g. .ShootName).
+// The FieldSpecs are validated once, and the returned columns can be used both by the TablePrinter and by ProjectColumns.
+func ParseCustomColumns(spec string) ([]Column, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("custom columns specification must not be empty")
+	}
+
+	parts := strings.Split(spec, ",")
+	columns := make([]Column, 0, len(parts))
+	for idx, part := range parts {
+		colSpec := strings.SplitN(part, ":", 2)
+		if len(colSpec) != 2 || colSpec[0] == "" || colSpec[1] == "" {
+			return nil, fmt.Errorf("invalid custom column %q, expected format is HEADER:FIELDSPEC", part)
+		}
+		fieldSpec := colSpec[1]
+		if !strings.HasPrefix(fieldSpec, "{") {
+			fieldSpec = fmt.Sprintf("{%s}", fieldSpec)
+		}
+
+		parser := jsonpath.New(fmt.Sprintf("column%d", idx)).AllowMissingKeys(true)
+		if err := parser.Parse(fieldSpec); err != nil {
+			return nil, fmt.Errorf("invalid field spec of custom column %s: %s", colSpec[0], err)
+		}
+		columns = append(columns, Column{
+			Header:    colSpec[0],
+			FieldSpec: fieldSpec,
+			parser:    parser,
+		})
+	}
+
+	return columns, nil
+}
+
+// ProjectColumns returns the JSON-friendly representation of the given object (or slice of objects),
+// which contains only the fields referenced by the FieldSpecs of the given columns.
+// Only columns with FieldSpecs referencing a single field (e.g. {.Status.CreatedAt}) can be projected.
+func ProjectColumns(obj interface{}, columns []Column) (interface{}, error) {
+	for _, col := range columns {
+		if col.parser == nil || !simpleFieldPath.MatchString(col.FieldSpec) {
+			return nil, fmt.Errorf("cannot project column %s: field spec %q must reference a single field", col.Header, col.FieldSpec)
+		}
+	}
+
+	if isSlice(obj) {
+		objs := toInterfaceSlice(obj)
+		result := make([]interface{}, 0, len(objs))
+		for _, o := range objs {
+			projected, err := projectOneObj(o, columns)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, projected)
+		}
+		return result, nil
+	}
+
+	return projectOneObj(obj, columns)
+}
+
+func projectOneObj(obj interface{}, columns []Column) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, col := range columns {
+		values, err := col.parser.FindResults(obj)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 || len(values[0]) == 0 {
+			continue
+		}
+
+		path := strings.Split(strings.Trim(col.FieldSpec, "{}."), ".")
+		node := result
+		for _, key := range path[:len(path)-1] {
+			next, ok := node[key].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				node[key] = next
+			}
+			node = next
+		}
+		node[path[len(path)-1]] = values[0][0].Interface()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package printer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStatus struct {
+	State   string
+	Message string
+}
+
+type testObj struct {
+	Name   string
+	Region string
+	Status testStatus
+}
+
+func TestParseCustomColumns(t *testing.T) {
+	t.Run("should parse columns", func(t *testing.T) {
+		// when
+		columns, err := ParseCustomColumns("NAME:{.Name},STATE:.Status.State")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, columns, 2)
+		assert.Equal(t, "NAME", columns[0].Header)
+		assert.Equal(t, "{.Name}", columns[0].FieldSpec)
+		assert.Equal(t, "STATE", columns[1].Header)
+		assert.Equal(t, "{.Status.State}", columns[1].FieldSpec)
+	})
+
+	for name, spec := range map[string]string{
+		"empty specification": "",
+		"missing field spec":  "NAME",
+		"empty header":        ":{.Name}",
+		"invalid field spec":  "NAME:{.Name",
+	} {
+		t.Run("should fail on "+name, func(t *testing.T) {
+			// when
+			_, err := ParseCustomColumns(spec)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestProjectColumns(t *testing.T) {
+	objs := []testObj{
+		{Name: "first", Region: "eu", Status: testStatus{State: "succeeded", Message: "done"}},
+		{Name: "second", Region: "us", Status: testStatus{State: "failed", Message: "error"}},
+	}
+
+	t.Run("should contain only the fields referenced by the columns", func(t *testing.T) {
+		// given
+		columns, err := ParseCustomColumns("NAME:{.Name},STATE:{.Status.State}")
+		require.NoError(t, err)
+
+		// when
+		projected, err := ProjectColumns(objs, columns)
+
+		// then
+		require.NoError(t, err)
+		data, err := json.Marshal(projected)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"Name": "first", "Status": {"State": "succeeded"}},
+			{"Name": "second", "Status": {"State": "failed"}}
+		]`, string(data))
+	})
+
+	t.Run("should project single object", func(t *testing.T) {
+		// given
+		columns, err := ParseCustomColumns("REGION:{.Region}")
+		require.NoError(t, err)
+
+		// when
+		projected, err := ProjectColumns(objs[0], columns)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"Region": "eu"}, projected)
+	})
+
+	t.Run("should fail on field spec not referencing a single field", func(t *testing.T) {
+		// given
+		columns, err := ParseCustomColumns("NAMES:{.Name}{.Region}")
+		require.NoError(t, err)
+
+		// when
+		_, err = ProjectColumns(objs, columns)
+
+		// then
+		assert.Error(t, err)
+	})
+}
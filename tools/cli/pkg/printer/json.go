@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"io"
 	"os"
 
 	"encoding/json"
@@ -18,8 +19,13 @@ type jsonPrinter struct {
 // NewJSONPrinter creates a new JSONPrinter.
 // If indent is set to a non-empty string, the output will be pretty-printed, and the specified string will be applied for each level of indentation.
 func NewJSONPrinter(indent string) JSONPrinter {
+	return NewJSONPrinterTo(os.Stdout, indent)
+}
+
+// NewJSONPrinterTo creates a new JSONPrinter which writes to the given output
+func NewJSONPrinterTo(output io.Writer, indent string) JSONPrinter {
 	j := &jsonPrinter{
-		e: json.NewEncoder(os.Stdout),
+		e: json.NewEncoder(output),
 	}
 	if indent != "" {
 		j.e.SetIndent("", indent)
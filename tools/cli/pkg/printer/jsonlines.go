@@ -0,0 +1,54 @@
+package printer
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// JSONLinesPrinter prints objects in JSON Lines format, one compact JSON document per line
+type JSONLinesPrinter interface {
+	PrintObj(obj interface{}) error
+}
+
+type jsonLinesPrinter struct {
+	output io.Writer
+	e      *json.Encoder
+}
+
+// NewJSONLinesPrinter creates a new JSONLinesPrinter.
+// A slice is printed as one line per element, so the elements are displayed as soon as they are encoded and no document holds the whole slice.
+func NewJSONLinesPrinter() JSONLinesPrinter {
+	return NewJSONLinesPrinterTo(os.Stdout)
+}
+
+// NewJSONLinesPrinterTo creates a new JSONLinesPrinter which writes to the given output instead of the standard output.
+// If the output is buffered, i.e. it has the Flush method, it is flushed after each line.
+func NewJSONLinesPrinterTo(output io.Writer) JSONLinesPrinter {
+	return &jsonLinesPrinter{
+		output: output,
+		e:      json.NewEncoder(output),
+	}
+}
+
+func (j *jsonLinesPrinter) PrintObj(obj interface{}) error {
+	if !isSlice(obj) {
+		return j.printLine(obj)
+	}
+	for _, o := range toInterfaceSlice(obj) {
+		if err := j.printLine(o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jsonLinesPrinter) printLine(obj interface{}) error {
+	if err := j.e.Encode(obj); err != nil {
+		return err
+	}
+	if f, ok := j.output.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package printer
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLinesPrinter_PrintObj(t *testing.T) {
+	created := time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should print each element of slice in a separate line", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+		objs := []taggedObj{
+			{Name: "first", CreatedAt: created},
+			{Name: "second", CreatedAt: created, Labels: []string{"a", "b"}},
+		}
+
+		// when
+		err := NewJSONLinesPrinterTo(buf).PrintObj(objs)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"first","createdAt":"2021-01-13T10:00:00Z"}`+"\n"+
+			`{"name":"second","createdAt":"2021-01-13T10:00:00Z","labels":["a","b"]}`+"\n", buf.String())
+	})
+
+	t.Run("should print single object in one line", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+
+		// when
+		err := NewJSONLinesPrinterTo(buf).PrintObj(taggedObj{Name: "first", CreatedAt: created})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"first","createdAt":"2021-01-13T10:00:00Z"}`+"\n", buf.String())
+	})
+
+	t.Run("should print nothing for empty slice", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+
+		// when
+		err := NewJSONLinesPrinterTo(buf).PrintObj([]taggedObj{})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("should flush buffered output after each line", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+		w := bufio.NewWriter(buf)
+
+		// when
+		err := NewJSONLinesPrinterTo(w).PrintObj([]taggedObj{{Name: "first", CreatedAt: created}})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 0, w.Buffered())
+		assert.Equal(t, `{"name":"first","createdAt":"2021-01-13T10:00:00Z"}`+"\n", buf.String())
+	})
+}
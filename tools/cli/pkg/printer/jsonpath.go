@@ -0,0 +1,53 @@
+package printer
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPathPrinter prints the results of a JSONPath expression, in the same way as the jsonpath output of kubectl
+type JSONPathPrinter interface {
+	PrintObj(obj interface{}) error
+}
+
+type jsonPathPrinter struct {
+	output io.Writer
+	parser *jsonpath.JSONPath
+}
+
+// NewJSONPathPrinter creates a new JSONPathPrinter.
+// The expression is parsed with the client-go jsonpath package, an error is returned when the expression is malformed.
+func NewJSONPathPrinter(expr string) (JSONPathPrinter, error) {
+	return NewJSONPathPrinterTo(os.Stdout, expr)
+}
+
+// NewJSONPathPrinterTo creates a new JSONPathPrinter which writes to the given output instead of the standard output.
+func NewJSONPathPrinterTo(output io.Writer, expr string) (JSONPathPrinter, error) {
+	parser := jsonpath.New("output").AllowMissingKeys(true)
+	if err := parser.Parse(expr); err != nil {
+		return nil, err
+	}
+
+	return &jsonPathPrinter{
+		output: output,
+		parser: parser,
+	}, nil
+}
+
+// PrintObj evaluates the expression against the JSON representation of the object,
+// so the expression refers to the field names of the JSON output, e.g. {.data[*].shootName}
+func (j *jsonPathPrinter) PrintObj(obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	return j.parser.Execute(j.output, generic)
+}
@@ -0,0 +1,65 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPathPrinter_PrintObj(t *testing.T) {
+	created := time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)
+	page := map[string]interface{}{
+		"data": []taggedObj{
+			{Name: "first", CreatedAt: created},
+			{Name: "second", CreatedAt: created, Labels: []string{"a", "b"}},
+		},
+		"count": 2,
+	}
+
+	for name, tc := range map[string]struct {
+		expr     string
+		expected string
+	}{
+		"fields of all elements": {
+			expr:     "{.data[*].name}",
+			expected: "first second",
+		},
+		"range with text": {
+			expr:     `{range .data[*]}{.name}{"\t"}{.createdAt}{"\n"}{end}`,
+			expected: "first\t2021-01-13T10:00:00Z\nsecond\t2021-01-13T10:00:00Z\n",
+		},
+		"number": {
+			expr:     "count: {.count}",
+			expected: "count: 2",
+		},
+		"missing key": {
+			expr:     "{.data[0].labels}",
+			expected: "",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			buf := &bytes.Buffer{}
+			jp, err := NewJSONPathPrinterTo(buf, tc.expr)
+			require.NoError(t, err)
+
+			// when
+			err = jp.PrintObj(page)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestNewJSONPathPrinter_InvalidExpression(t *testing.T) {
+	// when
+	_, err := NewJSONPathPrinter("{.data[*].name")
+
+	// then
+	assert.Error(t, err)
+}
@@ -0,0 +1,22 @@
+package printer
+
+// ColumnValues returns the values which the column displays for each element of the objs slice, in the same order.
+// The values are extracted in the same way as by the printers, so they can be used to sort the objects by the column.
+func ColumnValues(column Column, objs interface{}) ([]string, error) {
+	columns := []Column{column}
+	if err := parseColumns(columns); err != nil {
+		return nil, err
+	}
+
+	elements := toInterfaceSlice(objs)
+	values := make([]string, 0, len(elements))
+	for _, obj := range elements {
+		value, err := columns[0].format(obj)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
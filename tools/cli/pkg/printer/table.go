@@ -1,11 +1,16 @@
 package printer
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
 	"github.com/liggitt/tabwriter"
 	"k8s.io/client-go/util/jsonpath"
 )
@@ -16,6 +21,9 @@ const (
 	tabwriterPadding  = 3
 	tabwriterPadChar  = ' '
 	tabwriterFlags    = tabwriter.RememberWidths
+
+	// errorValue is displayed in the cell whose FieldFormatter panicked
+	errorValue = "<error>"
 )
 
 // newTabWriter returns a tabwriter that translates tabbed columns in input into properly aligned text.
@@ -34,7 +42,92 @@ type Column struct {
 	FieldSpec string
 	// FieldFormatter is a formatter fuction to print complex columns derived from object field(s).
 	FieldFormatter FieldFormatterFunc
-	parser         *jsonpath.JSONPath
+	// Color is an optional function which selects the color of the values, used only by the TablePrinter created with NewColorTablePrinter
+	Color ColorFunc
+	// MaxWidth is an optional maximal number of characters of the values, the longer values are truncated with an ellipsis only by the TablePrinter
+	MaxWidth int
+	// Align is an optional alignment of the values used by the TablePrinter. By default, the columns with only numeric values are right-aligned.
+	Align  Align
+	parser *jsonpath.JSONPath
+}
+
+// Align is the alignment of the values of a column in the table
+type Align int
+
+const (
+	// AlignDefault right-aligns the column if all its values are numbers, and left-aligns it otherwise
+	AlignDefault Align = iota
+	AlignLeft
+	AlignRight
+)
+
+// Key returns the identifier of the column used in command options, which is the lowercase header with spaces replaced by dashes, e.g. created-at
+func (c Column) Key() string {
+	return strings.ToLower(strings.Join(strings.Fields(c.Header), "-"))
+}
+
+// ReorderColumns returns the columns identified by the given keys in the given order, followed by the remaining columns in their original relative order.
+// An error is returned when a key does not identify any column or is given more than once.
+func ReorderColumns(columns []Column, keys []string) ([]Column, error) {
+	positions := make(map[string]int, len(columns))
+	validKeys := make([]string, 0, len(columns))
+	for idx, col := range columns {
+		positions[col.Key()] = idx
+		validKeys = append(validKeys, col.Key())
+	}
+
+	ordered := make([]Column, 0, len(columns))
+	used := make(map[int]bool, len(keys))
+	for _, key := range keys {
+		idx, ok := positions[strings.ToLower(key)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q, the possible values are: %s", key, strings.Join(validKeys, ", "))
+		}
+		if used[idx] {
+			return nil, fmt.Errorf("column %q is specified more than once", key)
+		}
+		used[idx] = true
+		ordered = append(ordered, columns[idx])
+	}
+	for idx, col := range columns {
+		if !used[idx] {
+			ordered = append(ordered, col)
+		}
+	}
+
+	return ordered, nil
+}
+
+// SelectColumns returns only the columns identified by the given names in the given order.
+// A name is either the column header, compared case-insensitively (e.g. CREATED AT), or the column key (e.g. created-at).
+// An error is returned when a name does not identify any column or is given more than once.
+func SelectColumns(columns []Column, names []string) ([]Column, error) {
+	validHeaders := make([]string, 0, len(columns))
+	for _, col := range columns {
+		validHeaders = append(validHeaders, col.Header)
+	}
+
+	selected := make([]Column, 0, len(names))
+	used := make(map[int]bool, len(names))
+	for _, name := range names {
+		idx := -1
+		for i, col := range columns {
+			if strings.EqualFold(col.Header, strings.TrimSpace(name)) || col.Key() == strings.ToLower(strings.TrimSpace(name)) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("unknown column %q, the possible values are: %s", name, strings.Join(validHeaders, ", "))
+		}
+		if used[idx] {
+			return nil, fmt.Errorf("column %q is specified more than once", name)
+		}
+		used[idx] = true
+		selected = append(selected, columns[idx])
+	}
+
+	return selected, nil
 }
 
 // TablePrinter prints objects in table format, according to the given column definitions.
@@ -46,6 +139,7 @@ type tablePrinter struct {
 	writer         *tabwriter.Writer
 	columns        []Column
 	noHeaders      bool
+	colors         bool
 	headersPrinted bool
 }
 
@@ -53,49 +147,106 @@ type tablePrinter struct {
 // The parameter columns holds the non-empty list of Column specifications which comprises the table.
 // If the parameter noHeaders is true, the first header row will not be displayed.
 func NewTablePrinter(columns []Column, noHeaders bool) (TablePrinter, error) {
+	return newTablePrinter(os.Stdout, columns, noHeaders, false)
+}
+
+// NewTablePrinterTo creates a new TablePrinter which writes to the given output
+func NewTablePrinterTo(output io.Writer, columns []Column, noHeaders bool) (TablePrinter, error) {
+	return newTablePrinter(output, columns, noHeaders, false)
+}
+
+// NewColorTablePrinter creates a new TablePrinter which displays the values of the columns with the Color function in the selected colors.
+// The colors are ANSI escape sequences, so the printer should be used only when the output is a terminal.
+func NewColorTablePrinter(columns []Column, noHeaders bool) (TablePrinter, error) {
+	return newTablePrinter(os.Stdout, columns, noHeaders, true)
+}
+
+func newTablePrinter(output io.Writer, columns []Column, noHeaders, colors bool) (TablePrinter, error) {
 	t := &tablePrinter{
-		writer:    newTabWriter(os.Stdout),
+		writer:    newTabWriter(output),
 		columns:   columns,
 		noHeaders: noHeaders,
+		colors:    colors,
 	}
-	for idx := range t.columns {
-		if t.columns[idx].FieldFormatter == nil && t.columns[idx].FieldSpec != "" {
-			t.columns[idx].parser = jsonpath.New(fmt.Sprintf("column%d", idx)).AllowMissingKeys(true)
-			if err := t.columns[idx].parser.Parse(t.columns[idx].FieldSpec); err != nil {
-				return nil, err
+	if err := parseColumns(t.columns); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// parseColumns prepares the JSONPath parsers of the columns which are not printed with a FieldFormatter
+func parseColumns(columns []Column) error {
+	for idx := range columns {
+		if columns[idx].FieldFormatter == nil && columns[idx].FieldSpec != "" && columns[idx].parser == nil {
+			columns[idx].parser = jsonpath.New(fmt.Sprintf("column%d", idx)).AllowMissingKeys(true)
+			if err := columns[idx].parser.Parse(columns[idx].FieldSpec); err != nil {
+				return err
 			}
 		}
 	}
 
-	return t, nil
+	return nil
+}
+
+// format returns the value of the column for the given object
+func (c *Column) format(obj interface{}) (string, error) {
+	if c.FieldFormatter != nil {
+		return c.formatSafely(obj), nil
+	}
+	if c.parser == nil {
+		return "", nil
+	}
+	buf := &bytes.Buffer{}
+	err := c.parser.Execute(buf, obj)
+	return buf.String(), err
+}
+
+// formatSafely calls the FieldFormatter and recovers from its panic, e.g. caused by a failed type assertion of an unexpected object,
+// so that a single cell is displayed as <error> instead of crashing the whole command
+func (c *Column) formatSafely(obj interface{}) (value string) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.New().Warnf("while formatting column %s of %T: %v", c.Header, obj, r)
+			value = errorValue
+		}
+	}()
+	return c.FieldFormatter(obj)
 }
 
 func (t *tablePrinter) PrintObj(obj interface{}) error {
 	defer t.writer.Flush()
 
-	if !t.noHeaders && !t.headersPrinted {
-		t.printHeader()
-		t.headersPrinted = true
+	// Format all objects first, identify whether it is a slice of objects or single object
+	objs := []interface{}{obj}
+	if isSlice(obj) {
+		objs = toInterfaceSlice(obj)
 	}
-
-	// Print the object, identify whether it is a slice of objects or single object
-	s := reflect.ValueOf(obj)
-	if s.Kind() == reflect.Slice {
-		objs := toInterfaceSlice(obj)
-		for idx := range objs {
-			if err := t.printOneObj(objs[idx]); err != nil {
-				return err
-			}
-		}
-	} else {
-		if err := t.printOneObj(obj); err != nil {
+	rows := make([][]cell, 0, len(objs))
+	for _, o := range objs {
+		row, err := t.formatRow(o)
+		if err != nil {
 			return err
 		}
+		rows = append(rows, row)
+	}
+
+	widths := t.rightAlignedWidths(rows)
+	if !t.noHeaders && !t.headersPrinted {
+		t.printHeader(widths)
+		t.headersPrinted = true
+	}
+	for _, row := range rows {
+		t.printRow(row, widths)
 	}
 
 	return nil
 }
 
+func isSlice(obj interface{}) bool {
+	return reflect.ValueOf(obj).Kind() == reflect.Slice
+}
+
 func toInterfaceSlice(obj interface{}) []interface{} {
 	s := reflect.ValueOf(obj)
 	ret := make([]interface{}, s.Len())
@@ -107,26 +258,103 @@ func toInterfaceSlice(obj interface{}) []interface{} {
 	return ret
 }
 
-func (t *tablePrinter) printHeader() {
+// cell is the formatted value of a column, the color is selected by the value before it is truncated
+type cell struct {
+	value string
+	color Color
+}
+
+func (t *tablePrinter) formatRow(obj interface{}) ([]cell, error) {
+	row := make([]cell, 0, len(t.columns))
 	for idx := range t.columns {
-		fmt.Fprintf(t.writer, "%s\t", t.columns[idx].Header)
+		value, err := t.columns[idx].format(obj)
+		if err != nil {
+			return nil, err
+		}
+		c := cell{value: truncate(value, t.columns[idx].MaxWidth)}
+		if t.colors && t.columns[idx].Color != nil {
+			c.color = t.columns[idx].Color(value)
+		}
+		row = append(row, c)
 	}
-	fmt.Fprint(t.writer, "\n")
+	return row, nil
 }
 
-func (t *tablePrinter) printOneObj(obj interface{}) error {
+// rightAlignedWidths returns the widths of the right-aligned columns by their indexes, including the width of the header.
+// A column without the Align set is right-aligned if all its values are numbers.
+func (t *tablePrinter) rightAlignedWidths(rows [][]cell) map[int]int {
+	widths := make(map[int]int)
 	for idx := range t.columns {
-		if t.columns[idx].FieldFormatter != nil {
-			fmt.Fprintf(t.writer, "%s\t", t.columns[idx].FieldFormatter(obj))
-		} else {
-			err := t.columns[idx].parser.Execute(t.writer, obj)
-			fmt.Fprint(t.writer, "\t")
-			if err != nil {
-				return err
+		switch t.columns[idx].Align {
+		case AlignLeft:
+			continue
+		case AlignDefault:
+			if !numericColumn(rows, idx) {
+				continue
 			}
 		}
+
+		width := 0
+		if !t.noHeaders {
+			width = utf8.RuneCountInString(t.columns[idx].Header)
+		}
+		for _, row := range rows {
+			if w := utf8.RuneCountInString(row[idx].value); w > width {
+				width = w
+			}
+		}
+		widths[idx] = width
+	}
+	return widths
+}
+
+func numericColumn(rows [][]cell, idx int) bool {
+	if len(rows) == 0 {
+		return false
+	}
+	for _, row := range rows {
+		if _, err := strconv.ParseFloat(row[idx].value, 64); err != nil {
+			return false
+		}
 	}
+	return true
+}
 
+func (t *tablePrinter) printHeader(widths map[int]int) {
+	for idx := range t.columns {
+		header := padLeft(t.columns[idx].Header, widths[idx])
+		if t.colors && t.columns[idx].Color != nil {
+			// the header has the same escape sequences as the values, so the column stays aligned
+			header = colorize(header, ColorDefault)
+		}
+		fmt.Fprintf(t.writer, "%s\t", header)
+	}
 	fmt.Fprint(t.writer, "\n")
-	return nil
+}
+
+func (t *tablePrinter) printRow(row []cell, widths map[int]int) {
+	for idx, c := range row {
+		value := padLeft(c.value, widths[idx])
+		if t.colors && t.columns[idx].Color != nil {
+			value = colorize(value, c.color)
+		}
+		fmt.Fprintf(t.writer, "%s\t", value)
+	}
+	fmt.Fprint(t.writer, "\n")
+}
+
+// padLeft right-aligns the value within the given width, the tabwriter then pads all values of the column to the same width on the right
+func padLeft(value string, width int) string {
+	if n := utf8.RuneCountInString(value); n < width {
+		return strings.Repeat(" ", width-n) + value
+	}
+	return value
+}
+
+// truncate shortens the value to the given number of characters, the last of which is an ellipsis. The value is not truncated if maxWidth is not positive.
+func truncate(value string, maxWidth int) string {
+	if maxWidth <= 0 || utf8.RuneCountInString(value) <= maxWidth {
+		return value
+	}
+	return string([]rune(value)[:maxWidth-1]) + "…"
 }
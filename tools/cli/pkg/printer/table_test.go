@@ -0,0 +1,135 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTablePrinter_MaxWidth(t *testing.T) {
+	// given
+	columns := []Column{
+		{Header: "NAME", FieldSpec: "{.Name}", MaxWidth: 5},
+		{Header: "REGION", FieldSpec: "{.Region}"},
+	}
+	objs := []testObj{
+		{Name: "first", Region: "westeurope"},
+		{Name: "second", Region: "westeurope"},
+		{Name: "żółwik", Region: "westeurope"},
+	}
+	buf := &bytes.Buffer{}
+	printer, err := NewTablePrinterTo(buf, columns, false)
+	require.NoError(t, err)
+
+	// when
+	err = printer.PrintObj(objs)
+
+	// then
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, []string{"NAME", "REGION"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"first", "westeurope"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"seco…", "westeurope"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"żółw…", "westeurope"}, strings.Fields(lines[3]))
+}
+
+func TestTablePrinter_MaxWidthWithColors(t *testing.T) {
+	// given
+	columns := []Column{
+		{
+			Header:         "STATE",
+			FieldFormatter: func(obj interface{}) string { return obj.(testObj).Status.State },
+			Color: func(value string) Color {
+				if value == "failed (upgradeKyma)" {
+					return ColorRed
+				}
+				return ""
+			},
+			MaxWidth: 8,
+		},
+	}
+	buf := &bytes.Buffer{}
+	printer, err := newTablePrinter(buf, columns, false, true)
+	require.NoError(t, err)
+
+	// when
+	err = printer.PrintObj(testObj{Status: testStatus{State: "failed (upgradeKyma)"}})
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "\x1b[31mfailed (…\x1b[0m")
+}
+
+func TestTablePrinter_Align(t *testing.T) {
+	// given
+	type countObj struct {
+		Name  string
+		Count string
+		Mixed string
+	}
+	columns := []Column{
+		{Header: "NAME", FieldFormatter: func(obj interface{}) string { return obj.(countObj).Name }},
+		{Header: "COUNT", FieldFormatter: func(obj interface{}) string { return obj.(countObj).Count }},
+		{Header: "MIXED", FieldFormatter: func(obj interface{}) string { return obj.(countObj).Mixed }},
+		{Header: "R", FieldFormatter: func(obj interface{}) string { return obj.(countObj).Name }, Align: AlignRight},
+		{Header: "L", FieldFormatter: func(obj interface{}) string { return obj.(countObj).Count }, Align: AlignLeft},
+	}
+	objs := []countObj{
+		{Name: "first", Count: "5", Mixed: "1"},
+		{Name: "second", Count: "120", Mixed: "n/a"},
+		{Name: "third", Count: "-2.5", Mixed: "3"},
+	}
+	buf := &bytes.Buffer{}
+	printer, err := NewTablePrinterTo(buf, columns, false)
+	require.NoError(t, err)
+
+	// when
+	err = printer.PrintObj(objs)
+
+	// then
+	require.NoError(t, err)
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	assert.Equal(t, []string{
+		"NAME     COUNT   MIXED        R   L",
+		"first        5   1        first   5",
+		"second     120   n/a     second   120",
+		"third     -2.5   3        third   -2.5",
+	}, lines)
+}
+
+func TestTablePrinter_FormatterPanic(t *testing.T) {
+	// given
+	type nameObj struct {
+		Name string
+	}
+	columns := []Column{
+		{Header: "NAME", FieldFormatter: func(obj interface{}) string { return obj.(nameObj).Name }},
+		{Header: "VALUE", FieldFormatter: func(obj interface{}) string { return fmt.Sprint(obj) }},
+	}
+	buf := &bytes.Buffer{}
+	printer, err := NewTablePrinterTo(buf, columns, false)
+	require.NoError(t, err)
+
+	// when
+	err = printer.PrintObj([]string{"first", "second"})
+
+	// then
+	require.NoError(t, err)
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	assert.Equal(t, []string{
+		"NAME      VALUE",
+		"<error>   first",
+		"<error>   second",
+	}, lines)
+}
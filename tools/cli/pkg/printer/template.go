@@ -0,0 +1,51 @@
+package printer
+
+import (
+	"io"
+	"os"
+	"text/template"
+)
+
+// TemplatePrinter prints objects using a Go template, in the same way as the go-template output of kubectl
+type TemplatePrinter interface {
+	PrintObj(obj interface{}) error
+}
+
+type templatePrinter struct {
+	output   io.Writer
+	template *template.Template
+}
+
+// NewTemplatePrinter creates a new TemplatePrinter.
+// The template is parsed with text/template, an error is returned when the template is malformed.
+func NewTemplatePrinter(tmpl string) (TemplatePrinter, error) {
+	return NewTemplatePrinterTo(os.Stdout, tmpl)
+}
+
+// NewTemplatePrinterTo creates a new TemplatePrinter which writes to the given output instead of the standard output.
+func NewTemplatePrinterTo(output io.Writer, tmpl string) (TemplatePrinter, error) {
+	t, err := template.New("output").Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &templatePrinter{
+		output:   output,
+		template: t,
+	}, nil
+}
+
+// PrintObj executes the template against the object, or against each element if the object is a slice
+func (t *templatePrinter) PrintObj(obj interface{}) error {
+	objs := []interface{}{obj}
+	if isSlice(obj) {
+		objs = toInterfaceSlice(obj)
+	}
+	for _, o := range objs {
+		if err := t.template.Execute(t.output, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplatePrinter_PrintObj(t *testing.T) {
+	t.Run("should execute template against each element", func(t *testing.T) {
+		// given
+		buf := &bytes.Buffer{}
+		printer, err := NewTemplatePrinterTo(buf, "{{.Name}}={{.Status.State}}\n")
+		require.NoError(t, err)
+
+		// when
+		err = printer.PrintObj([]testObj{
+			{Name: "first", Status: testStatus{State: "succeeded"}},
+			{Name: "second", Status: testStatus{State: "failed"}},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "first=succeeded\nsecond=failed\n", buf.String())
+	})
+
+	t.Run("should reject malformed template", func(t *testing.T) {
+		// when
+		_, err := NewTemplatePrinterTo(&bytes.Buffer{}, "{{.Name")
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error of template execution", func(t *testing.T) {
+		// given
+		printer, err := NewTemplatePrinterTo(&bytes.Buffer{}, "{{.Unknown}}")
+		require.NoError(t, err)
+
+		// when
+		err = printer.PrintObj(testObj{Name: "first"})
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,39 @@
+package printer
+
+import (
+	"io"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// YAMLPrinter prints objects in YAML format
+type YAMLPrinter interface {
+	PrintObj(obj interface{}) error
+}
+
+type yamlPrinter struct {
+	output io.Writer
+}
+
+// NewYAMLPrinter creates a new YAMLPrinter.
+// The objects are converted to JSON first, so the field names match the JSON tags and the output can be converted back to the JSON output.
+func NewYAMLPrinter() YAMLPrinter {
+	return NewYAMLPrinterTo(os.Stdout)
+}
+
+// NewYAMLPrinterTo creates a new YAMLPrinter which writes to the given output instead of the standard output.
+func NewYAMLPrinterTo(output io.Writer) YAMLPrinter {
+	return &yamlPrinter{
+		output: output,
+	}
+}
+
+func (y *yamlPrinter) PrintObj(obj interface{}) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = y.output.Write(data)
+	return err
+}
@@ -0,0 +1,38 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+type taggedObj struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	Labels    []string  `json:"labels,omitempty"`
+}
+
+func TestYAMLPrinter_PrintObj(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+	obj := taggedObj{Name: "first", CreatedAt: time.Date(2021, 1, 13, 10, 0, 0, 0, time.UTC)}
+
+	// when
+	err := NewYAMLPrinterTo(buf).PrintObj(obj)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "createdAt: \"2021-01-13T10:00:00Z\"\nname: first\n", buf.String())
+
+	// the output converted to JSON is the same as the JSON of the object
+	actual, err := yaml.YAMLToJSON(buf.Bytes())
+	require.NoError(t, err)
+	expected, err := json.Marshal(obj)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
@@ -0,0 +1,35 @@
+package timewindow
+
+// Value implements the pflag.Value interface, so that the time flags of all commands share the syntax of the Parser.
+// The parsed window is stored in the target passed to NewValue.
+type Value struct {
+	parser *Parser
+	target *Window
+	raw    string
+}
+
+// NewValue constructs a new instance of Value which parses the flag value with the given parser and stores the result in target.
+func NewValue(parser *Parser, target *Window) *Value {
+	return &Value{parser: parser, target: target}
+}
+
+// String returns the flag value as provided on the command line.
+func (v *Value) String() string {
+	return v.raw
+}
+
+// Set parses the flag value and stores the resulting window.
+func (v *Value) Set(value string) error {
+	window, err := v.parser.Parse(value)
+	if err != nil {
+		return err
+	}
+	*v.target = window
+	v.raw = value
+	return nil
+}
+
+// Type returns the name of the flag value type displayed in the usage.
+func (v *Value) Type() string {
+	return "time"
+}
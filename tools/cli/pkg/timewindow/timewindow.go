@@ -0,0 +1,117 @@
+package timewindow
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Named windows supported by the parser, resolved against the current time of the parser's clock.
+const (
+	Today     = "today"
+	Yesterday = "yesterday"
+	LastWeek  = "last-week"
+)
+
+var relativeDays = regexp.MustCompile(`^([+-]?)([0-9]+)d$`)
+
+// Clock returns the current time. It is injectable so that relative and named windows can be resolved deterministically.
+type Clock func() time.Time
+
+// Window is a period of time. An absolute point in time is represented by a window with the same beginning and end.
+type Window struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains returns true if the given time is within the window, including its bounds.
+func (w Window) Contains(t time.Time) bool {
+	return !t.Before(w.From) && !t.After(w.To)
+}
+
+// Parser parses time values of the command line flags in one of the following syntaxes:
+//   - absolute time in the RFC3339 format, e.g. 2021-01-31T10:00:00Z
+//   - time relative to now, e.g. -2h, -30m or -7d
+//   - named window, one of today, yesterday and last-week
+type Parser struct {
+	now Clock
+}
+
+// NewParser constructs a new instance of Parser which resolves relative and named windows against the given clock.
+// If the clock is nil, time.Now is used.
+func NewParser(now Clock) *Parser {
+	if now == nil {
+		now = time.Now
+	}
+	return &Parser{now: now}
+}
+
+// Parse returns the window described by the given value.
+func (p *Parser) Parse(value string) (Window, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Window{}, fmt.Errorf("time value must not be empty")
+	}
+	now := p.now()
+
+	// the days are calendar days in the location of the clock, so they are not always 24 hours long
+	switch strings.ToLower(value) {
+	case Today:
+		midnight := startOfDay(now)
+		return Window{From: midnight, To: midnight.AddDate(0, 0, 1)}, nil
+	case Yesterday:
+		midnight := startOfDay(now)
+		return Window{From: midnight.AddDate(0, 0, -1), To: midnight}, nil
+	case LastWeek:
+		return Window{From: startOfDay(now).AddDate(0, 0, -7), To: now}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return Window{From: t, To: t}, nil
+	}
+
+	t, err := offsetTime(now, value)
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid time value %q: expected RFC3339 time (e.g. 2021-01-31T10:00:00Z), relative time (e.g. -2h, -7d), or one of %s, %s, %s",
+			value, Today, Yesterday, LastWeek)
+	}
+	if t.Before(now) {
+		return Window{From: t, To: now}, nil
+	}
+	return Window{From: now, To: t}, nil
+}
+
+// ParseTime returns the beginning of the window described by the given value.
+func (p *Parser) ParseTime(value string) (time.Time, error) {
+	window, err := p.Parse(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return window.From, nil
+}
+
+// offsetTime returns the time shifted by the given duration, which additionally supports calendar days, e.g. -7d.
+func offsetTime(now time.Time, value string) (time.Time, error) {
+	if match := relativeDays.FindStringSubmatch(value); match != nil {
+		days, err := strconv.Atoi(match[2])
+		if err != nil {
+			return time.Time{}, err
+		}
+		if match[1] == "-" {
+			days = -days
+		}
+		return now.AddDate(0, 0, days), nil
+	}
+	offset, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.Add(offset), nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, dayOfMonth := t.Date()
+	return time.Date(year, month, dayOfMonth, 0, 0, 0, 0, t.Location())
+}
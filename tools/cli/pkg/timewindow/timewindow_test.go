@@ -0,0 +1,151 @@
+package timewindow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Parse(t *testing.T) {
+	now := time.Date(2021, 1, 13, 15, 30, 0, 0, time.UTC)
+	midnight := time.Date(2021, 1, 13, 0, 0, 0, 0, time.UTC)
+	parser := NewParser(func() time.Time { return now })
+
+	for name, tc := range map[string]struct {
+		value    string
+		expected Window
+	}{
+		"absolute time": {
+			value:    "2021-01-10T08:00:00Z",
+			expected: Window{From: time.Date(2021, 1, 10, 8, 0, 0, 0, time.UTC), To: time.Date(2021, 1, 10, 8, 0, 0, 0, time.UTC)},
+		},
+		"relative hours": {
+			value:    "-2h",
+			expected: Window{From: now.Add(-2 * time.Hour), To: now},
+		},
+		"relative minutes": {
+			value:    "-30m",
+			expected: Window{From: now.Add(-30 * time.Minute), To: now},
+		},
+		"relative days": {
+			value:    "-7d",
+			expected: Window{From: now.Add(-7 * 24 * time.Hour), To: now},
+		},
+		"relative time in the future": {
+			value:    "1d",
+			expected: Window{From: now, To: now.Add(24 * time.Hour)},
+		},
+		"today": {
+			value:    "today",
+			expected: Window{From: midnight, To: midnight.Add(24 * time.Hour)},
+		},
+		"yesterday": {
+			value:    "Yesterday",
+			expected: Window{From: midnight.Add(-24 * time.Hour), To: midnight},
+		},
+		"last week": {
+			value:    "last-week",
+			expected: Window{From: midnight.Add(-7 * 24 * time.Hour), To: now},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			window, err := parser.Parse(tc.value)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, window)
+		})
+	}
+
+	for name, value := range map[string]string{
+		"empty":             "",
+		"unknown name":      "tomorrow",
+		"incomplete date":   "2021-01-10",
+		"missing unit":      "-2",
+		"unsupported unit":  "-2y",
+		"fractional days":   "-1.5d",
+		"invalid time zone": "2021-01-10T08:00:00",
+	} {
+		t.Run("should fail for "+name+" value", func(t *testing.T) {
+			// when
+			_, err := parser.Parse(value)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParser_ParseAcrossDaylightSavingTime(t *testing.T) {
+	// given
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// the clocks were moved forward on 28 March 2021, so the day was 23 hours long
+	now := time.Date(2021, 3, 28, 15, 0, 0, 0, location)
+	parser := NewParser(func() time.Time { return now })
+
+	for name, tc := range map[string]struct {
+		value    string
+		expected Window
+	}{
+		"today": {
+			value:    "today",
+			expected: Window{From: time.Date(2021, 3, 28, 0, 0, 0, 0, location), To: time.Date(2021, 3, 29, 0, 0, 0, 0, location)},
+		},
+		"yesterday": {
+			value:    "yesterday",
+			expected: Window{From: time.Date(2021, 3, 27, 0, 0, 0, 0, location), To: time.Date(2021, 3, 28, 0, 0, 0, 0, location)},
+		},
+		"relative days": {
+			value:    "-1d",
+			expected: Window{From: time.Date(2021, 3, 27, 15, 0, 0, 0, location), To: now},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			window, err := parser.Parse(tc.value)
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, tc.expected.From.Equal(window.From), "expected %s, got %s", tc.expected.From, window.From)
+			assert.True(t, tc.expected.To.Equal(window.To), "expected %s, got %s", tc.expected.To, window.To)
+		})
+	}
+}
+func TestParser_ParseTime(t *testing.T) {
+	// given
+	now := time.Date(2021, 1, 13, 15, 30, 0, 0, time.UTC)
+	parser := NewParser(func() time.Time { return now })
+
+	// when
+	since, err := parser.ParseTime("yesterday")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 1, 12, 0, 0, 0, 0, time.UTC), since)
+}
+
+func TestValue_Set(t *testing.T) {
+	// given
+	now := time.Date(2021, 1, 13, 15, 30, 0, 0, time.UTC)
+	var window Window
+	value := NewValue(NewParser(func() time.Time { return now }), &window)
+
+	// when
+	err := value.Set("-1h")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Window{From: now.Add(-time.Hour), To: now}, window)
+	assert.Equal(t, "-1h", value.String())
+
+	// when
+	err = value.Set("invalid")
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, "-1h", value.String())
+}